@@ -0,0 +1,19 @@
+package bigfloat
+
+import "math/big"
+
+// Square returns a big.Float representation of z². Precision and
+// rounding mode are the same as the ones of the argument, and z is not
+// modified. The function returns +0 when z = ±0, and +Inf when z =
+// ±Inf.
+func Square(z *big.Float) *big.Float {
+	return new(big.Float).SetPrec(z.Prec()).SetMode(z.Mode()).Mul(z, z)
+}
+
+// Reciprocal returns a big.Float representation of 1/z. Precision and
+// rounding mode are the same as the ones of the argument, and z is not
+// modified. The function returns ±Inf when z = ±0, and ±0 when z =
+// ±Inf.
+func Reciprocal(z *big.Float) *big.Float {
+	return new(big.Float).SetPrec(z.Prec()).SetMode(z.Mode()).Quo(big.NewFloat(1), z)
+}
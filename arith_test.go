@@ -0,0 +1,56 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestSquareReciprocal(t *testing.T) {
+	for _, f := range signValues {
+		z := big.NewFloat(f).SetPrec(100).SetMode(big.ToZero)
+
+		x := bigfloat.Square(z)
+		checkFloat64(t, "Square", x, f*f)
+		if x.Prec() != 100 || x.Mode() != big.ToZero {
+			t.Errorf("Square(%g) has precision %d and mode %s; want 100 and ToZero", f, x.Prec(), x.Mode())
+		}
+
+		x = bigfloat.Reciprocal(z)
+		checkFloat64(t, "Reciprocal", x, 1/f)
+		if x.Prec() != 100 || x.Mode() != big.ToZero {
+			t.Errorf("Reciprocal(%g) has precision %d and mode %s; want 100 and ToZero", f, x.Prec(), x.Mode())
+		}
+
+		// the argument must be left unmodified
+		checkFloat64(t, "z", z, f)
+	}
+}
+
+// The results are rounded to the precision and with the rounding mode
+// of the argument.
+func TestSquareReciprocalRounding(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		for _, mode := range []big.RoundingMode{big.ToNearestEven, big.ToZero, big.AwayFromZero} {
+			z := new(big.Float).SetPrec(prec).SetMode(mode)
+			z.Parse("3.14159265358979323846264338327950288419716939937510582097494459230781640628620899862803482534211706798214808651328230664709384460955058223172535940812848111745028410270193852110555964462294895493038196442881097566593344612847564823378678316527120190914564856692346034861045432664821339360726024914127372458700660631558817488152092096282925409171536436789259036", 10)
+
+			want := new(big.Float).SetPrec(prec).SetMode(mode).Mul(z, z)
+			if x := bigfloat.Square(z); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, mode = %s, Square(%g) =\ngot  %g;\nwant %g", prec, mode, z, x, want)
+			}
+
+			want.Quo(big.NewFloat(1), z)
+			if x := bigfloat.Reciprocal(z); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, mode = %s, Reciprocal(%g) =\ngot  %g;\nwant %g", prec, mode, z, x, want)
+			}
+		}
+	}
+
+	// 1/3 is not exact, and it's rounded to 24 bits
+	x := bigfloat.Reciprocal(big.NewFloat(3).SetPrec(24))
+	if x32, _ := x.Float32(); x32 != 1.0/3 || x.Prec() != 24 {
+		t.Errorf("Reciprocal(3) = %g (prec = %d); want %g (prec = 24)", x32, x.Prec(), float32(1.0/3))
+	}
+}
@@ -0,0 +1,256 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// Atan returns a big.Float representation of the arctangent of z,
+// in radians. Precision is the same as the one of the argument. The
+// function returns ±0 when z = ±0, and ±π/2 when z = ±Inf.
+func Atan(z *big.Float) *big.Float {
+
+	// Atan(±0) = ±0
+	if z.Sign() == 0 {
+		return new(big.Float).Copy(z)
+	}
+
+	// Atan(±Inf) = ±π/2
+	if z.IsInf() {
+		x := pi(infPrec(z))
+		x.SetMantExp(x, -1)
+		if z.Sign() < 0 {
+			x.Neg(x)
+		}
+		return x
+	}
+
+	// Each halving step below loses a bit, so add enough guard digits
+	// to cover them.
+	steps := reductionSteps(z.Prec())
+	prec := z.Prec() + 64 + uint(steps)
+
+	x := new(big.Float).SetPrec(prec).Abs(z)
+
+	// if |z| > 1 compute atan(|z|) as π/2 - atan(1/|z|)
+	inv := x.Cmp(big.NewFloat(1)) > 0
+	if inv {
+		x.Quo(big.NewFloat(1), x)
+	}
+
+	// Shrink x using
+	//   atan(x) = 2·atan(x / (1 + √(1 + x²)))
+	// until |x| < 2**-steps, so that the Taylor series converges
+	// faster, and keep track of the number of halvings.
+	one := big.NewFloat(1)
+	t := new(big.Float).SetPrec(prec)
+	k := 0
+	for x.MantExp(nil) > -steps {
+		t.Mul(x, x)
+		t.Add(t, one)
+		t = Sqrt(t)
+		t.Add(t, one)
+		x.Quo(x, t)
+		k++
+	}
+
+	x = atanTaylor(x)
+	x.SetMantExp(x, k) // scale back multiplying by 2**k
+
+	if inv {
+		halfPi := pi(prec)
+		halfPi.SetMantExp(halfPi, -1)
+		x.Sub(halfPi, x)
+	}
+
+	if z.Sign() < 0 {
+		x.Neg(x)
+	}
+
+	return x.SetPrec(z.Prec())
+}
+
+// reductionSteps returns the number of argument halvings that Atan,
+// Expm1 and Log1p perform before using a Taylor series, at precision
+// prec. Reducing |x| below 2**-k costs k steps and leaves about
+// prec/2k terms of the series, so balance the two with k ≈ √prec/2.
+func reductionSteps(prec uint) int {
+	k := int(math.Sqrt(float64(prec)) / 2)
+	if k < 4 {
+		k = 4
+	}
+	return k
+}
+
+// atanTaylor returns atan(x), computed with x's precision using the
+// Taylor series, for small |x|.
+func atanTaylor(x *big.Float) *big.Float {
+	prec := x.Prec()
+
+	x2 := new(big.Float).SetPrec(prec).Mul(x, x)
+	x2.Neg(x2)
+	pow := new(big.Float).SetPrec(prec).Set(x) // (-1)ⁿx²ⁿ⁺¹
+	term := new(big.Float).SetPrec(prec)
+	sum := new(big.Float).SetPrec(prec).Set(x)
+
+	// atan(x) = Σ (-1)ⁿ x²ⁿ⁺¹ / (2n+1)
+	for n := int64(1); ; n++ {
+		pow.Mul(pow, x2)
+		term.Quo(pow, new(big.Float).SetInt64(2*n+1))
+		if term.Sign() == 0 || term.MantExp(nil) < sum.MantExp(nil)-int(prec) {
+			break
+		}
+		sum.Add(sum, term)
+	}
+
+	return sum
+}
+
+// Atan2 returns a big.Float representation of the arctangent of y/x,
+// in radians, using the signs of the two arguments to determine the
+// quadrant of the result. Precision is the larger of the precisions
+// of the arguments. The result is in (-π, π]. On the axes,
+//
+//	Atan2(y, 0) = π/2 for y > 0, and -π/2 for y < 0
+//	Atan2(0, x) = 0 for x > 0, and π for x < 0
+//	Atan2(0, 0) = 0
+//
+// and the infinite cases follow math.Atan2.
+func Atan2(y, x *big.Float) *big.Float {
+
+	prec := y.Prec()
+	if x.Prec() > prec {
+		prec = x.Prec()
+	}
+
+	// both arguments are zeros or infinities with 0 bits of precision,
+	// and most of the results for them aren't 0
+	if prec == 0 {
+		prec = 53
+	}
+
+	// angle returns n·π/4 rounded to prec bits, with the sign of y
+	angle := func(n int64) *big.Float {
+		t := pi(prec + 64)
+		t.Mul(t, big.NewFloat(float64(n)))
+		t.SetMantExp(t, -2)
+		if y.Sign() < 0 {
+			t.Neg(t)
+		}
+		return t.SetPrec(prec)
+	}
+
+	switch {
+	case y.IsInf() && x.IsInf():
+		if x.Sign() > 0 {
+			return angle(1) // ±π/4
+		}
+		return angle(3) // ±3π/4
+	case y.IsInf():
+		return angle(2) // ±π/2
+	case x.IsInf() && x.Sign() > 0:
+		return new(big.Float).SetPrec(prec).Mul(y, big.NewFloat(0)) // ±0
+	case x.IsInf():
+		return angle(4) // ±π
+	}
+
+	// the axes
+	switch {
+	case y.Sign() == 0 && x.Sign() >= 0:
+		return new(big.Float).SetPrec(prec)
+	case y.Sign() == 0:
+		return pi(prec)
+	case x.Sign() == 0:
+		return angle(2)
+	}
+
+	z := new(big.Float).SetPrec(prec+64).Quo(y, x)
+	z = Atan(z)
+
+	// atan(y/x) is in (-π/2, π/2), so move the result to the second
+	// and third quadrant when x < 0
+	if x.Sign() < 0 {
+		if y.Sign() > 0 {
+			z.Add(z, pi(prec+64))
+		} else {
+			z.Sub(z, pi(prec+64))
+		}
+	}
+
+	return z.SetPrec(prec)
+}
+
+// Asin returns a big.Float representation of the arcsine of z, in
+// radians. Precision is the same as the one of the argument. The
+// function panics if |z| > 1, returns ±0 when z = ±0, and ±π/2 when z
+// = ±1.
+func Asin(z *big.Float) *big.Float {
+
+	// panic on |z| > 1
+	if z.IsInf() || new(big.Float).Abs(z).Cmp(big.NewFloat(1)) > 0 {
+		panic("Asin: argument is outside [-1, 1]")
+	}
+
+	// Asin(±0) = ±0
+	if z.Sign() == 0 {
+		return new(big.Float).Copy(z)
+	}
+
+	prec := z.Prec() + 64 // guard digits
+
+	// Compute asin(z) as atan(z / √(1 - z²)). For z close to ±1, 1 - z²
+	// suffers from cancellation, but (1 - z)(1 + z) doesn't, since
+	// both factors are computed exactly.
+	one := big.NewFloat(1)
+	zw := WithGuard(z, 64)
+	t := new(big.Float).SetPrec(prec).Sub(one, zw)
+	t.Mul(t, new(big.Float).SetPrec(prec).Add(one, zw))
+
+	// Asin(±1) = ±π/2
+	if t.Sign() == 0 {
+		x := pi(infPrec(z))
+		x.SetMantExp(x, -1)
+		if z.Sign() < 0 {
+			x.Neg(x)
+		}
+		return x
+	}
+
+	x := Atan(zw.Quo(zw, Sqrt(t)))
+	return x.SetPrec(z.Prec())
+}
+
+// Acos returns a big.Float representation of the arccosine of z, in
+// radians. Precision is the same as the one of the argument. The
+// function panics if |z| > 1, returns π/2 when z = 0, and 0 when z =
+// 1.
+func Acos(z *big.Float) *big.Float {
+
+	// panic on |z| > 1
+	if z.IsInf() || new(big.Float).Abs(z).Cmp(big.NewFloat(1)) > 0 {
+		panic("Acos: argument is outside [-1, 1]")
+	}
+
+	prec := z.Prec() + 64 // guard digits
+
+	// For z <= 0.5, compute acos(z) as π/2 - asin(z). For z close to
+	// 1 the difference loses a lot of bits, since both terms are
+	// close to π/2, so use
+	//   acos(z) = 2·atan(√((1 - z)/(1 + z)))
+	// instead, where 1 - z is exact.
+	if z.Cmp(big.NewFloat(0.5)) <= 0 {
+		x := pi(prec)
+		x.SetMantExp(x, -1)
+		x.Sub(x, Asin(WithGuard(z, 64)))
+		return x.SetPrec(z.Prec())
+	}
+
+	one := big.NewFloat(1)
+	zw := WithGuard(z, 64)
+	t := new(big.Float).SetPrec(prec).Sub(one, zw)
+	t.Quo(t, zw.Add(one, zw))
+	x := Atan(Sqrt(t))
+	x.SetMantExp(x, 1)
+
+	return x.SetPrec(z.Prec())
+}
@@ -0,0 +1,308 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestAtan(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"0.5", "0.46364760900080611621425623146121440202853705428612026381093308872019786416574170530060028398488789255652985225119083751350581818162501115547153056994410562071933626616488010153250275598792580551685388916747823728653879391801251719948401395583818511509502163330649387215460973207855555720860146322756524267305218045746400869745058389736389648900264869"},
+		{"2", "1.1071487177940905030170654601785370400700476454014326466765392074337103389773627940134171286861706414345441910054503158100411041231502799603911491341201349380058057851860891590202770663235486719483370930469272505464279291462253069174093776267974158394778026501552363021506174312455511395950286613430716196204511227003300787433098765840507305568550335"},
+		{"10", "1.4711276743037345918528755717617308518553063771832382624719635193438804556955538448934047882367721624115156568478137543539789952382121342030723776319789566558938988279378240515536595105350225967109198439332766642393615499509576705841506254256473427190813389588744580266985990227942120596286601488235354263312295667002702876626809339920673777495758273"},
+		{"-3", "-1.2490457723982544258299170772810901230778294041298967190546692367971519657372939549576089903204171595520668738795114141752792793340126567134028704219762259000819072918403647318088926671436630442494493802746809812030221554501314292579307097471559855923814337750373589593072233137406089056104165255128836738198038320363610524178308141380712100119314898"},
+		{"0.001", "0.00099999966666686666652380963492054401162093455426801309143104818764547234066956229127347490140840201321164921471028702091129927193365894047621142177670075537103429392197486383413934940797663880728909427774409979473481054209741341743633198070760473321646254257977596803626169397091494169837159722077988832502894770089190549409644697975635200492017837333"},
+		{"1e10", "1.5707963266948966192313216916400847754319180330208842438208056294872415507621521183616364601789950419275819797654867521691457679036131976537692176106022137632983466245555418768906902778689888637721416854499384446805336727983702207884245356901695321525194057311491140576778405329590135507170794665135073814331634173537221385355056474790297249053005618"},
+		{"-0.75", "-0.64350110879328438680280922871732263804151059111531238286560611871351247481162108871281684470128274887801433875425947829653528594152526880491961856417602931728646951902120905748777431033562286643148320387944901325988913522821278971792536367095923072438278101684874242999600769916699558238642719811550637694739894224286607004585929268668683406785238481"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+			if !exact(z, test.z) {
+				continue
+			}
+
+			x := bigfloat.Atan(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Atan(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestAtanOne(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		want := new(big.Float).SetPrec(prec)
+		want.Parse(piStr, 10)
+		want.Quo(want, big.NewFloat(4))
+
+		x := bigfloat.Atan(big.NewFloat(1).SetPrec(prec))
+
+		if x.Cmp(want) != 0 {
+			t.Errorf("prec = %d, Atan(1) =\ngot  %g;\nwant %g", prec, x, want)
+		}
+	}
+}
+
+func testAtanFloat64(scale float64, nTests int, t *testing.T) {
+	for i := 0; i < nTests; i++ {
+		r := rand.Float64() * scale
+
+		z := big.NewFloat(r).SetPrec(53)
+		x64, acc := bigfloat.Atan(z).Float64()
+
+		want := math.Atan(r)
+
+		// Unfortunately, the Go math.Atan function is not completely
+		// accurate, so just require a relative error smaller than
+		// 1e-15.
+		if math.Abs(x64-want)/math.Abs(want) > 1e-15 || acc != big.Exact {
+			t.Errorf("Atan(%g) =\n got %g (%s);\nwant %g (Exact)", z, x64, acc, want)
+		}
+	}
+}
+
+func TestAtanFloat64Small(t *testing.T) {
+	testAtanFloat64(-1e-5, 1e3, t)
+	testAtanFloat64(-1, 1e3, t)
+}
+
+func TestAtanFloat64Medium(t *testing.T) {
+	testAtanFloat64(10, 1e3, t)
+	testAtanFloat64(100, 1e3, t)
+}
+
+func TestAtanFloat64Big(t *testing.T) {
+	testAtanFloat64(-1e10, 1e3, t)
+	testAtanFloat64(1e100, 1e3, t)
+}
+
+func TestAtanSpecialValues(t *testing.T) {
+	for _, z := range []*big.Float{
+		big.NewFloat(+0.0),
+		big.NewFloat(math.Copysign(0, -1)),
+		big.NewFloat(math.Inf(+1)),
+		big.NewFloat(math.Inf(-1)),
+		new(big.Float).SetInf(false), // prec = 0
+		new(big.Float).SetInf(true),
+	} {
+		f, _ := z.Float64()
+		x := bigfloat.Atan(z)
+		x64, acc := x.Float64()
+		want := math.Atan(f)
+		if x64 != want || math.Signbit(x64) != math.Signbit(want) || acc != big.Exact {
+			t.Errorf("Atan(%g) =\n got %g (%s);\nwant %g (Exact)", f, x64, acc, want)
+		}
+	}
+}
+
+func TestAtan2(t *testing.T) {
+	for _, test := range []struct {
+		y, x string
+		want string
+	}{
+		{"1", "1", "0.78539816339744830961566084581987572104929234984377645524373614807695410157155224965700870633552926699553702162832057666177346115238764555793133985203212027936257102567548463027638991115573723873259549110720274391648336153211891205844669579131780047728641214173086508715261358166205334840181506228531843114675165157889704372038023024070731352292884109"},
+		{"1", "-1", "2.3561944901923449288469825374596271631478770495313293657312084442308623047146567489710261190065878009866110648849617299853203834571629366737940195560963608380877130770264538908291697334672117161977864733216082317494500845963567361753400873739534014318592364251925952614578407449861600452054451868559552934402549547366911311611406907221219405687865233"},
+		{"-1", "-1", "-2.3561944901923449288469825374596271631478770495313293657312084442308623047146567489710261190065878009866110648849617299853203834571629366737940195560963608380877130770264538908291697334672117161977864733216082317494500845963567361753400873739534014318592364251925952614578407449861600452054451868559552934402549547366911311611406907221219405687865233"},
+		{"-1", "1", "-0.78539816339744830961566084581987572104929234984377645524373614807695410157155224965700870633552926699553702162832057666177346115238764555793133985203212027936257102567548463027638991115573723873259549110720274391648336153211891205844669579131780047728641214173086508715261358166205334840181506228531843114675165157889704372038023024070731352292884109"},
+		{"3", "-4", "2.4980915447965088516598341545621802461556588082597934381093384735943039314745879099152179806408343191041337477590228283505585586680253134268057408439524518001638145836807294636177853342873260884988987605493619624060443109002628585158614194943119711847628675500747179186144466274812178112208330510257673476396076640727221048356616282761424200238629796"},
+		{"-0.5", "-2", "-2.8966139904629290842905609020682270732830253009939217538475686776404612866985669020535006676734150766183132820232451882727983591885555222547663724475142773822490070153143568653839729286403104042983297556415042255225178227649930086700889746436801286633104104318471603930415532008179471312770003333879926969807730460402270996793423468243607389310227241"},
+		{"1e-10", "-1", "3.1415926534897932384626433832798362175305027327084371543082779256411497539052566176756538728500535759186560230221279054926926902083884887696318973146664543220234886759065111374434701001804633412373326676643439325135003958626080449053179272728051331070922300146108442319830676962831202475207095910841442437266667205115162259762661079604443519511582440"},
+		{"2", "1e-5", "1.5707913267948966608979883576814181087764120806397168489795402271378547181894730633420846145215066379586791202063667817475827336852030487978123284030504081411467114074234193835666648419501176546766731844052702271379413771442390045774198225801104681490274154629095425391376462078598331808673501622271914094959614009205780463865095467298727011047604770"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			y := new(big.Float).SetPrec(prec)
+			y.Parse(test.y, 10)
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+			if !exact(y, test.y) || !exact(x, test.x) {
+				continue
+			}
+
+			z := bigfloat.Atan2(y, x)
+
+			if z.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Atan2(%v, %v) =\ngot  %g;\nwant %g", prec, test.y, test.x, z, want)
+			}
+		}
+	}
+}
+
+func TestAtan2Axes(t *testing.T) {
+	pi := new(big.Float).SetPrec(200)
+	pi.Parse(piStr, 10)
+	halfPi := new(big.Float).Quo(pi, big.NewFloat(2))
+	for _, test := range []struct {
+		y, x float64
+		want *big.Float
+	}{
+		{2, 0, halfPi},
+		{-2, 0, new(big.Float).Neg(halfPi)},
+		{0, 2, new(big.Float)},
+		{0, -2, pi},
+		{0, 0, new(big.Float)},
+	} {
+		y := big.NewFloat(test.y).SetPrec(200)
+		x := big.NewFloat(test.x).SetPrec(200)
+		if z := bigfloat.Atan2(y, x); z.Cmp(test.want) != 0 || z.Prec() != 200 {
+			t.Errorf("Atan2(%g, %g) =\ngot  %g;\nwant %g", test.y, test.x, z, test.want)
+		}
+	}
+}
+
+func TestAtan2Float64(t *testing.T) {
+	inf := math.Inf(+1)
+	for _, f := range [][2]float64{
+		{1, 2}, {1, -2}, {-1, -2}, {-1, 2},
+		{inf, 3}, {-inf, 3}, {inf, inf}, {inf, -inf}, {-inf, inf}, {-inf, -inf},
+		{3, inf}, {-3, inf}, {3, -inf}, {-3, -inf},
+	} {
+		y := big.NewFloat(f[0]).SetPrec(53)
+		x := big.NewFloat(f[1]).SetPrec(53)
+		z64, _ := bigfloat.Atan2(y, x).Float64()
+		want := math.Atan2(f[0], f[1])
+		if math.Abs(z64-want) > 1e-15*math.Abs(want) || math.Signbit(z64) != math.Signbit(want) {
+			t.Errorf("Atan2(%g, %g) = %g; want %g", f[0], f[1], z64, want)
+		}
+	}
+
+	// zeros and infinities with 0 bits of precision
+	zero := new(big.Float)
+	for _, args := range [][2]*big.Float{
+		{new(big.Float).SetInf(false), new(big.Float).SetInf(false)},
+		{new(big.Float).SetInf(true), new(big.Float).SetInf(true)},
+		{new(big.Float).SetInf(false), zero},
+		{zero, new(big.Float).SetInf(true)},
+		{new(big.Float).SetInf(true), zero},
+		{zero, zero},
+	} {
+		y64, _ := args[0].Float64()
+		x64, _ := args[1].Float64()
+		z64, _ := bigfloat.Atan2(args[0], args[1]).Float64()
+		want := math.Atan2(y64, x64)
+		if z64 != want || math.Signbit(z64) != math.Signbit(want) {
+			t.Errorf("Atan2(%g, %g) = %g; want %g", y64, x64, z64, want)
+		}
+	}
+}
+
+func TestAsinAcos(t *testing.T) {
+	for _, test := range []struct {
+		z          string
+		asin, acos string
+	}{
+		{"0.5", "0.52359877559829887307710723054658381403286156656251763682915743205130273438103483310467247089035284466369134775221371777451564076825843037195422656802141351957504735045032308685092660743715815915506366073813516261098890768807927470563113052754520031819094142782057672476840905444136889893454337485687895409783443438593136248025348682713820901528589406", "1.0471975511965977461542144610931676280657231331250352736583148641026054687620696662093449417807056893273826955044274355490312815365168607439084531360428270391500947009006461737018532148743163183101273214762703252219778153761585494112622610550904006363818828556411534495368181088827377978690867497137579081956688687718627249605069736542764180305717881"},
+		{"-0.25", "-0.25268025514207865348565743699371097225219373309683819363392377874057506048102122241174874222801460160509260290941406656626731922032400564613743074967604394882626988653054841196626581030513382930938607929255625989149778731374367136525342900742609681642805854765967860425886871355933537049956680415351812765906816233127017548972167876809960836251047490", "1.8234765819369752727169791286334624143507784327843911041213960748944832636241257217257661548990731355961666461660552198898142415250992967620001104537402845075514119378815176725190456326166083067745770615069617477244645103779814954821468205900616977710008828311214087785640958768834420673031969287241549899525714654890642629304821392495142354083681571"},
+		{"0.75", "0.84806207898148100805294433899841808007336621326311264286071816357020082122847423434918980173195723030099522726530753183383445387878373613879408611961067214820382174069516812427196471399116890688514624353319464318237359263821834813012524082216958907409730165975607388297422504616414451916225036615460629764883378235374592407232858601304689772748588925", "0.72273424781341561117837735264133336202521848642444026762675413258370738191463026496482761093910130369007881599133362148971246842599155497706859358445356841052132031065580113628081510832030557058004473868121084465059313042601947598676815076046601188047552262370565629133100211715996217764137975841603056464466952080404816336843187446836772931837179293"},
+		{"0.999", "1.5260712396261631879816254589682003721944041429253947276568345901727461026555510573556497361850791429238432836722160806661010993456555388700533798329301494182295736111096299152365117287396640056002102424210289500267496401605970023356177839503243511615124951260079322752704728868739985119970494262560831852306611698769118863730519022882090422641315952", "0.044725087168733431249696232671551069904180556762158182830637705981162100487553441958367676485979391067230759584425072657445822959119752245809299871134091140495568440241339345316268093571810471864980739793376537806217082903640821781275607632311249793060329157453797899034754276450108184806580698314553677062842133280882201067708558193205584781726086949"},
+		{"-0.9999", "-1.5566540733173837416350814658220953363741816334585574808661378481674460282054328755939548410484830199298543498265434934687491441270864672033673810602673555467976143742315628853120233062137278825797273910569873177088355060031798232469984479568672160153816226204321508084946998217520047428511342893640403542324878588334641239475106408172635062444114273", "3.1274504001122803608664031574618467784727663331461103913536101443213542313485373749079722537195415539209283930831846467922960664318617583192300607643315961055227564255825321458648031285252023600449183732713928055418022290674176473638918395395028169699544469038938809827999269850761114396547644139346772165259911619912582113882711012986781332902691095"},
+		{"0.99999999999999911182158029987476766109466552734375", "1.5707962846480481083372867851773412565214219184665488154332115687962461247502309094154614710713312091442035620570907792758012708712790110559743149202401484363874670367041358710204431537729074198424776989520288531586279675099402656788067694539616068680629199285269222044610223396511498599199116098304598490000917456240589739381758068259087695048218675", "4.2146848510894034906462410185577162781221004095054260727357662078392873589898555941599727324846870481199550374047745651433496280059888364783824092122337675014646833389532336668538567057622713283262376634674338755554297558438086622128673994086509904354934807969844204823672956836883718514740177013293411557533735113502584653655505857541035814636786901e-8"},
+		{"-0.999999999068677425384521484375", "-1.5707531684220181142121926081436603912155218578887548969070271052950892541979113461358244833639217073225841413257244158801331077265185939503268798302972713844200446722189173981758317803069875364232984057753135775915478725112696305924113216963747607911039370938332340621745850988883091324906719672428374882497143345781699201329089472483619076318903768", "3.1415494952169147334435142997834118333141065575763078073944994014489974573410158454498418960349802413136581845823655692036800300312938850661895595343615119431451867235698866587286116026184620138884893879897190654245145955755074547093047132790103617456767613772949642364798122622124158292943020918134743505432176377359640075736694077297765346777480590"},
+		{"1e-10", "1.0000000000000000000016666666666666666666741666666666666666667113095238095238095241133432539682539682562054698773448773448946976417679542679544076027054542679542691094480438819248561993236814442071704311320433845643679975754819233132628085562149184477237651004260797886837245361848240841343374795648091752168640000331844842399142972485697411550110456e-10", "1.5707963266948966192313216916395847754319180330208854938208056294872415320121521183616364602597242085942486464310206063358124345702858213516858842772687997984545966245555423496079754341189888575328228377936884447197623846078010243593451992513093200989513324386893536642626191844557342431851477161572031143370223856361076874374420120574231973210007081"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+			if !exact(z, test.z) {
+				continue
+			}
+
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.asin, 10)
+			if x := bigfloat.Asin(z); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Asin(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+
+			want.Parse(test.acos, 10)
+			if x := bigfloat.Acos(z); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Acos(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestAsinAcosFloat64(t *testing.T) {
+	for i := 0; i < 1e3; i++ {
+		// math.Acos computes π/2 - math.Asin, which loses a few bits
+		// when the argument is close to 1, so stay away from ±1.
+		r := 1.8*rand.Float64() - 0.9
+
+		z := big.NewFloat(r).SetPrec(53)
+		asin, _ := bigfloat.Asin(z).Float64()
+		acos, _ := bigfloat.Acos(z).Float64()
+
+		if want := math.Asin(r); math.Abs(asin-want) > 1e-15*math.Abs(want) {
+			t.Errorf("Asin(%g) = %g; want %g", r, asin, want)
+		}
+		if want := math.Acos(r); math.Abs(acos-want) > 1e-15*math.Abs(want) {
+			t.Errorf("Acos(%g) = %g; want %g", r, acos, want)
+		}
+	}
+}
+
+func TestAsinAcosSpecialValues(t *testing.T) {
+	pi := new(big.Float).SetPrec(200)
+	pi.Parse(piStr, 10)
+	halfPi := new(big.Float).Quo(pi, big.NewFloat(2))
+	for _, test := range []struct {
+		z          float64
+		asin, acos *big.Float
+	}{
+		{0, new(big.Float), halfPi},
+		{1, halfPi, new(big.Float)},
+		{-1, new(big.Float).Neg(halfPi), pi},
+	} {
+		z := big.NewFloat(test.z).SetPrec(200)
+		if x := bigfloat.Asin(z); x.Cmp(test.asin) != 0 || x.Prec() != 200 {
+			t.Errorf("Asin(%g) = %g; want %g", test.z, x, test.asin)
+		}
+		if x := bigfloat.Acos(z); x.Cmp(test.acos) != 0 || x.Prec() != 200 {
+			t.Errorf("Acos(%g) = %g; want %g", test.z, x, test.acos)
+		}
+	}
+
+	for _, f := range []float64{1.5, -2, math.Inf(+1)} {
+		for name, fn := range map[string]func(*big.Float) *big.Float{"Asin": bigfloat.Asin, "Acos": bigfloat.Acos} {
+			func() {
+				defer func() {
+					if r := recover(); r == nil {
+						t.Errorf("%s(%g) did not panic", name, f)
+					}
+				}()
+				fn(big.NewFloat(f))
+			}()
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkAtan(b *testing.B) {
+	z := big.NewFloat(0.5).SetPrec(1e5)
+	_ = bigfloat.Atan(z) // fill pi cache before benchmarking
+
+	for _, prec := range []uint{1e2, 1e3, 1e4, 1e5} {
+		z = big.NewFloat(0.5).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Atan(z)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package bigfloat
+
+import (
+	"fmt"
+	"math/big"
+	"runtime"
+	"sync"
+)
+
+// IndexError records an error on one element of a slice passed to
+// one of the batch functions of the package.
+type IndexError struct {
+	Index int   // index of the element in the slice
+	Err   error // error on the element
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("%v (index %d)", e.Err, e.Index)
+}
+
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+// SqrtSlice returns a new slice with the square roots of the elements
+// of vals, in the same order. Each result has the precision and
+// rounding mode of the corresponding element, and the special cases
+// are handled per element: √±0 = ±0, and √+Inf = +Inf. The function
+// panics if any of the elements is negative, before computing any
+// root; use SqrtSliceErr to get an error instead.
+func SqrtSlice(vals []*big.Float) []*big.Float {
+	res, err := SqrtSliceErr(vals)
+	if err != nil {
+		panic(fmt.Sprintf("SqrtSlice: argument %d is negative", err.(*IndexError).Index))
+	}
+	return res
+}
+
+// SqrtSliceErr is like SqrtSlice, but instead of panicking it returns
+// an *IndexError wrapping ErrNegative, with the index of the first
+// negative element of vals.
+func SqrtSliceErr(vals []*big.Float) ([]*big.Float, error) {
+
+	// check all the elements before doing any work, so that a bad
+	// element at the end of vals doesn't waste the whole batch
+	if i := firstNegative(vals); i >= 0 {
+		return nil, &IndexError{Index: i, Err: ErrNegative}
+	}
+
+	res := make([]*big.Float, len(vals))
+	for i, z := range vals {
+		res[i] = SqrtPrec(z, 0, z.Mode())
+	}
+
+	return res, nil
+}
+
+// SqrtParallel is like SqrtSlice, but it computes the roots using the
+// given number of goroutines. If workers is <= 0, runtime.GOMAXPROCS
+// goroutines are used. The results are in the same order as vals.
+//
+// The elements of vals are only read, and every root is computed in
+// values allocated by the goroutine that computes it, so no big.Float
+// is written by more than one goroutine; vals must not be modified
+// until SqrtParallel returns.
+func SqrtParallel(vals []*big.Float, workers int) []*big.Float {
+
+	// panic on negative elements, before starting any goroutine
+	if i := firstNegative(vals); i >= 0 {
+		panic(fmt.Sprintf("SqrtParallel: argument %d is negative", i))
+	}
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(vals) {
+		workers = len(vals)
+	}
+
+	res := make([]*big.Float, len(vals))
+	idx := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range idx {
+				res[i] = SqrtPrec(vals[i], 0, vals[i].Mode())
+			}
+		}()
+	}
+
+	for i := range vals {
+		idx <- i
+	}
+	close(idx)
+	wg.Wait()
+
+	return res
+}
+
+// firstNegative returns the index of the first negative element of
+// vals, or -1 if there are none.
+func firstNegative(vals []*big.Float) int {
+	for i, z := range vals {
+		if z.Sign() < 0 {
+			return i
+		}
+	}
+	return -1
+}
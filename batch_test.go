@@ -0,0 +1,160 @@
+package bigfloat_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestSqrtSlice(t *testing.T) {
+	var vals []*big.Float
+	for i, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		vals = append(vals, big.NewFloat(float64(i+2)).SetPrec(prec))
+	}
+
+	res := bigfloat.SqrtSlice(vals)
+	if len(res) != len(vals) {
+		t.Fatalf("SqrtSlice returned %d results; want %d", len(res), len(vals))
+	}
+	for i, z := range vals {
+		want := bigfloat.Sqrt(z)
+		if res[i].Cmp(want) != 0 || res[i].Prec() != z.Prec() {
+			t.Errorf("SqrtSlice: element %d =\ngot  %g (prec = %d);\nwant %g (prec = %d)", i, res[i], res[i].Prec(), want, z.Prec())
+		}
+	}
+}
+
+func TestSqrtSliceEmpty(t *testing.T) {
+	if res := bigfloat.SqrtSlice(nil); len(res) != 0 {
+		t.Errorf("SqrtSlice(nil) = %v; want empty", res)
+	}
+}
+
+func TestSqrtSliceSpecialValues(t *testing.T) {
+	fs := []float64{+0.0, -0.0, math.Inf(+1), 4}
+	var vals []*big.Float
+	for _, f := range fs {
+		vals = append(vals, big.NewFloat(f).SetPrec(100))
+	}
+
+	for i, x := range bigfloat.SqrtSlice(vals) {
+		x64, acc := x.Float64()
+		want := math.Sqrt(fs[i])
+		if x64 != want || math.Signbit(x64) != math.Signbit(want) || acc != big.Exact || x.Prec() != 100 {
+			t.Errorf("SqrtSlice: element %d =\n got %g (%s, prec = %d);\nwant %g (Exact, prec = 100)", i, x64, acc, x.Prec(), want)
+		}
+	}
+}
+
+func TestSqrtSliceErr(t *testing.T) {
+	vals := []*big.Float{
+		big.NewFloat(1),
+		big.NewFloat(2),
+		big.NewFloat(-3),
+		big.NewFloat(4),
+		big.NewFloat(-5),
+	}
+
+	res, err := bigfloat.SqrtSliceErr(vals)
+	if res != nil {
+		t.Errorf("SqrtSliceErr returned %v; want nil", res)
+	}
+	if !errors.Is(err, bigfloat.ErrNegative) {
+		t.Fatalf("SqrtSliceErr returned error %v; want ErrNegative", err)
+	}
+	var ie *bigfloat.IndexError
+	if !errors.As(err, &ie) || ie.Index != 2 {
+		t.Errorf("SqrtSliceErr returned error %v; want index 2", err)
+	}
+
+	res, err = bigfloat.SqrtSliceErr(vals[:2])
+	if err != nil || len(res) != 2 {
+		t.Errorf("SqrtSliceErr(%v) = %v, %v; want 2 results, nil", vals[:2], res, err)
+	}
+}
+
+func TestSqrtSlicePanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("SqrtSlice did not panic")
+		}
+	}()
+	bigfloat.SqrtSlice([]*big.Float{big.NewFloat(1), big.NewFloat(-1)})
+}
+
+func TestSqrtParallel(t *testing.T) {
+	var vals []*big.Float
+	for i := 0; i < 100; i++ {
+		prec := uint(24 + 37*i)
+		vals = append(vals, big.NewFloat(float64(i)+0.5).SetPrec(prec))
+	}
+	vals = append(vals, big.NewFloat(0), big.NewFloat(math.Inf(+1)))
+	vals = append(vals, vals[7]) // the same value twice
+
+	want := bigfloat.SqrtSlice(vals)
+	for _, workers := range []int{-1, 0, 1, 2, 7, 1000} {
+		res := bigfloat.SqrtParallel(vals, workers)
+		if len(res) != len(want) {
+			t.Fatalf("workers = %d: SqrtParallel returned %d results; want %d", workers, len(res), len(want))
+		}
+		for i := range want {
+			if res[i].Cmp(want[i]) != 0 || res[i].Prec() != want[i].Prec() {
+				t.Errorf("workers = %d: element %d =\ngot  %g;\nwant %g", workers, i, res[i], want[i])
+			}
+		}
+	}
+
+	if res := bigfloat.SqrtParallel(nil, 4); len(res) != 0 {
+		t.Errorf("SqrtParallel(nil, 4) = %v; want empty", res)
+	}
+}
+
+func TestSqrtParallelPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("SqrtParallel did not panic")
+		}
+	}()
+	bigfloat.SqrtParallel([]*big.Float{big.NewFloat(1), big.NewFloat(-1)}, 2)
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkSqrtSlice(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		vals := make([]*big.Float, 16)
+		for i := range vals {
+			vals[i] = big.NewFloat(float64(i + 2)).SetPrec(prec)
+		}
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.SqrtSlice(vals)
+			}
+		})
+	}
+}
+
+func BenchmarkSqrtParallel(b *testing.B) {
+	vals := make([]*big.Float, 64)
+	for i := range vals {
+		vals[i] = big.NewFloat(float64(i + 2)).SetPrec(1e5)
+	}
+
+	b.Run("serial", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			bigfloat.SqrtSlice(vals)
+		}
+	})
+	for _, workers := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				bigfloat.SqrtParallel(vals, workers)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package bigfloat
+
+import (
+	"math/big"
+	"sync"
+	"sync/atomic"
+)
+
+// A constCache holds a mathematical constant computed with the
+// highest precision requested so far. Requests for an equal or lower
+// precision are served by rounding the cached value, without taking
+// any lock.
+type constCache struct {
+	v       atomic.Value // *constValue, nil if not computed yet
+	mu      sync.Mutex   // serializes the extensions of the cache
+	compute func(prec uint) *big.Float
+}
+
+// A constValue is a snapshot of a constCache. It's never modified
+// once it's stored, so it can be read concurrently.
+type constValue struct {
+	x    *big.Float
+	prec uint
+}
+
+var (
+	piCache         = &constCache{compute: computePi}
+	eCache          = &constCache{compute: computeE}
+	ln2Cache        = &constCache{compute: computeLn2}
+	ln10Cache       = &constCache{compute: computeLn10}
+	eulerGammaCache = &constCache{compute: computeEulerGamma}
+	catalanCache    = &constCache{compute: computeCatalan}
+	phiCache        = &constCache{compute: computePhi}
+	sqrt2Cache      = &constCache{compute: computeSqrt2}
+	sqrt3Cache      = &constCache{compute: computeSqrt3}
+	sqrtPiCache     = &constCache{compute: computeSqrtPi}
+)
+
+// load returns the current snapshot of c, or nil.
+func (c *constCache) load() *constValue {
+	v, _ := c.v.Load().(*constValue)
+	return v
+}
+
+// store replaces the snapshot of c with a copy of x.
+func (c *constCache) store(x *big.Float, prec uint) {
+	c.v.Store(&constValue{new(big.Float).Copy(x), prec})
+}
+
+// get returns the constant rounded to prec bits, computing it and
+// extending the cache if needed. Only one goroutine at a time computes
+// the constant; the others wait for it, and then check the new
+// snapshot before computing it again. The constant may need others
+// to be computed, but not itself, so holding c.mu while computing it
+// doesn't deadlock.
+func (c *constCache) get(prec uint) *big.Float {
+	if v := c.load(); v != nil && prec <= v.prec {
+		return new(big.Float).Copy(v.x).SetPrec(prec)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if v := c.load(); v != nil && prec <= v.prec {
+		return new(big.Float).Copy(v.x).SetPrec(prec)
+	}
+
+	x := c.compute(prec)
+	c.store(x, prec)
+	return x
+}
+
+// ResetConstantCache frees the cached values of the constants used by
+// the package, π, e, log(2), log(10), γ, Catalan's constant, φ, √2,
+// √3 and √π, which are kept with the highest precision requested so
+// far and can be large. They are computed again when they are next needed.
+// ResetConstantCache is safe for concurrent use with the functions of
+// the package.
+func ResetConstantCache() {
+	for _, c := range []*constCache{
+		piCache, eCache, ln2Cache, ln10Cache, eulerGammaCache, catalanCache,
+		phiCache, sqrt2Cache, sqrt3Cache, sqrtPiCache,
+	} {
+		c.mu.Lock()
+		c.v.Store((*constValue)(nil))
+		c.mu.Unlock()
+	}
+}
@@ -0,0 +1,110 @@
+package bigfloat
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+)
+
+var constCaches = []struct {
+	name string
+	c    *constCache
+	f    func(uint) *big.Float
+}{
+	{"pi", piCache, pi},
+	{"E", eCache, E},
+	{"ln2", ln2Cache, ln2},
+	{"ln10", ln10Cache, ln10},
+	{"EulerGamma", eulerGammaCache, EulerGamma},
+	{"Catalan", catalanCache, Catalan},
+	{"Phi", phiCache, Phi},
+	{"Sqrt2", sqrt2Cache, Sqrt2},
+	{"Sqrt3", sqrt3Cache, Sqrt3},
+	{"SqrtPi", sqrtPiCache, SqrtPi},
+}
+
+// cachePrec returns the precision of the value cached by c, or 0.
+func cachePrec(c *constCache) uint {
+	if v := c.load(); v != nil {
+		return v.prec
+	}
+	return 0
+}
+
+func TestConstCacheExtend(t *testing.T) {
+	for _, test := range constCaches {
+		want := test.c.compute(1500)
+
+		// a request for a higher precision extends the cache...
+		prec := cachePrec(test.c) + 2000
+		if x := test.f(prec); x.Prec() != prec || cachePrec(test.c) != prec {
+			t.Fatalf("%s(%d) returned prec = %d, cache prec = %d; want %d", test.name, prec, x.Prec(), cachePrec(test.c), prec)
+		}
+
+		// ...and the ones for lower precisions are served by rounding
+		// the cached value
+		for _, p := range []uint{24, 53, 100, 1000, 1500} {
+			if x := test.f(p); x.Cmp(new(big.Float).Copy(want).SetPrec(p)) != 0 || x.Prec() != p {
+				t.Errorf("%s(%d) = %g (prec = %d)", test.name, p, x, x.Prec())
+			}
+		}
+		if cachePrec(test.c) != prec {
+			t.Errorf("%s: cache prec = %d after lower precision requests; want %d", test.name, cachePrec(test.c), prec)
+		}
+	}
+}
+
+func TestResetConstantCache(t *testing.T) {
+	for _, test := range constCaches {
+		test.f(2000)
+
+		// Replace the cached value with a fake one: if the cache is
+		// cleared, the constant is computed again and it's not returned.
+		test.c.store(big.NewFloat(3), cachePrec(test.c))
+		if x := test.f(100); x.Cmp(big.NewFloat(3)) != 0 {
+			t.Fatalf("%s(100) = %g was not served from the cache", test.name, x)
+		}
+
+		ResetConstantCache()
+		if test.c.load() != nil {
+			t.Fatalf("%s: cache not cleared, prec = %d", test.name, cachePrec(test.c))
+		}
+
+		want := test.c.compute(100)
+		if x := test.f(100); x.Cmp(want) != 0 {
+			t.Errorf("%s(100) = %g after ResetConstantCache; want %g", test.name, x, want)
+		}
+		if cachePrec(test.c) != 100 {
+			t.Errorf("%s: cache prec = %d after ResetConstantCache and a request for 100 bits", test.name, cachePrec(test.c))
+		}
+	}
+}
+
+// Run with -race: most of the calls read the cache while some of
+// them extend it.
+func TestConstCacheConcurrent(t *testing.T) {
+	piStr := "3.1415926535897932384626433832795028841971693993751058209749445923078164062862089986280348253421170679821480865132823066470938446095505822317253594081284811174502841027019385211055596446229489549303819644288109756659334461284756482337867831652712019091456485669234603486104543266482133936072602491412737245870066063155881748815209209628292540917153644"
+	precs := []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000}
+	want := make(map[uint]*big.Float)
+	for _, prec := range precs {
+		want[prec] = new(big.Float).SetPrec(prec)
+		want[prec].Parse(piStr, 10)
+	}
+
+	ResetConstantCache()
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				prec := precs[(i*7+j)%len(precs)]
+				if z := Pi(prec); z.Cmp(want[prec]) != 0 || z.Prec() != prec {
+					t.Errorf("Pi(%d) =\ngot  %g;\nwant %g", prec, z, want[prec])
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,191 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// Derivative returns an approximation of the derivative of f at x,
+// with prec bits of precision, computed with the central difference
+//
+//	f'(x) ≈ (f(x+h) - f(x-h))/2h
+//
+// using a step h of about 2**(-prec/2)·|x|, or 2**(-prec/2) when x =
+// 0. If prec is 0, x's precision is used. f is called with arguments
+// of precision prec+64, and it should compute its result with the
+// precision of its argument, as the functions of the package do.
+//
+// The truncation error of the central difference is about
+// h²·|f⁽³⁾(x)|/6, and the rounding error about 2**(-prec-64)·|f(x)|/h,
+// so the result has about prec/2 correct bits for any smooth f, and
+// more when the third derivative of f is small. f must be defined on
+// [x-h, x+h].
+func Derivative(f func(*big.Float) *big.Float, x *big.Float, prec uint) *big.Float {
+	if prec == 0 {
+		prec = x.Prec()
+	}
+	p := prec + 64 // guard digits
+
+	// h = 2**(-prec/2)·|x|, rounded to a power of two
+	exp := 1
+	if x.Sign() != 0 {
+		exp = x.MantExp(nil)
+	}
+	h := big.NewFloat(1).SetPrec(p)
+	h.SetMantExp(h, exp-int(prec/2))
+
+	xp := new(big.Float).SetPrec(p).Add(x, h)
+	xm := new(big.Float).SetPrec(p).Sub(x, h)
+
+	// x ± h may be rounded, so divide by the step that was actually
+	// taken; xp and xm are close, so their difference is exact
+	d := new(big.Float).SetPrec(p).Sub(xp, xm)
+
+	y := new(big.Float).SetPrec(p).Sub(f(xp), f(xm))
+	return y.Quo(y, d).SetPrec(prec)
+}
+
+// Integrate returns an approximation of the definite integral of f
+// from a to b, with prec bits of precision, computed with tanh-sinh
+// quadrature. If prec is 0, the larger of the precisions of a and b is
+// used. As for Derivative, f is called with arguments of precision
+// prec+64, and it should compute its result with the precision of its
+// argument. f is never called at a and b, so it can have integrable
+// singularities there, as log(x) or 1/√x at 0.
+//
+// The substitution x = tanh(π/2·sinh(t)) turns the integral into one
+// over the whole real line of a function that decays doubly
+// exponentially, which the trapezoidal rule with step h integrates
+// with an error of about exp(-π²/h) when f is analytic on [a, b]. The
+// step is halved, reusing the previous nodes, until two estimates
+// agree to the precision; if they still don't after 2·log2(prec)+4
+// halvings, which happens when f is not smooth enough, the last
+// estimate is returned. Integrate(f, b, a) is -Integrate(f, a, b). The
+// function panics if a or b is infinite.
+func Integrate(f func(*big.Float) *big.Float, a, b *big.Float, prec uint) *big.Float {
+
+	if a.IsInf() || b.IsInf() {
+		panic("Integrate: bound is infinite")
+	}
+
+	if prec == 0 {
+		prec = a.Prec()
+		if b.Prec() > prec {
+			prec = b.Prec()
+		}
+	}
+	p := prec + 64 // guard digits
+
+	if a.Cmp(b) == 0 {
+		return new(big.Float).SetPrec(prec)
+	}
+
+	// With t = tanh(u), u = π/2·sinh(kh), and c = (1 - t)/2 =
+	// 1/(e^(2u) + 1), the nodes are a + (b-a)·c and b - (b-a)·c, and
+	// their weight is (b-a)·π·cosh(kh)·c·(1-c)·h. Computing c directly
+	// keeps the nodes close to a and b accurate.
+	width := new(big.Float).SetPrec(p).Sub(b, a)
+	halfPi := pi(p)
+	halfPi.SetMantExp(halfPi, -1)
+	one := big.NewFloat(1)
+
+	// the weights are below 2**-(p+16) of the one at the centre when
+	// c is, that is when 2u > (p+16)·log(2), and then the remaining
+	// terms are negligible
+	maxU := float64(p+16) * math.Ln2
+
+	// term returns the contribution of the nodes ±k·h, without the
+	// factor (b-a)·π·h, or nil when it's negligible or the nodes are
+	// the bounds. e is e^(kh).
+	term := func(e *big.Float) *big.Float {
+		ei := new(big.Float).Quo(one, e)
+		cosh := new(big.Float).Add(e, ei)
+		cosh.SetMantExp(cosh, -1)
+		u := new(big.Float).Sub(e, ei) // 2·sinh(kh)
+		u.Mul(u, halfPi)               // 2u
+		if uf, _ := u.Float64(); uf > maxU {
+			return nil
+		}
+		c := expReduced(u)
+		c.Quo(one, c.Add(c, one))
+
+		d := new(big.Float).SetPrec(p).Mul(width, c)
+		xa := new(big.Float).SetPrec(p).Add(a, d)
+		xb := new(big.Float).SetPrec(p).Sub(b, d)
+		if xa.Cmp(a) == 0 || xb.Cmp(b) == 0 {
+			return nil
+		}
+		y := new(big.Float).SetPrec(p).Add(f(xa), f(xb))
+
+		w := new(big.Float).Sub(one, c)
+		w.Mul(w, c)
+		w.Mul(w, cosh)
+		return y.Mul(y, w)
+	}
+
+	// addTerms adds to sum the terms of the nodes k·h, for k = k0,
+	// k0+step, ..., with e^(kh) computed by repeated multiplications by
+	// e^(step·h); there are a few tens of them at most, so the rounding
+	// errors stay well within the guard digits.
+	sum := new(big.Float).SetPrec(p)
+	addTerms := func(h *big.Float, k0, step int64) {
+		e := expReduced(new(big.Float).SetPrec(p).Mul(h, new(big.Float).SetInt64(k0)))
+		de := expReduced(new(big.Float).SetPrec(p).Mul(h, new(big.Float).SetInt64(step)))
+		for t := term(e); t != nil; t = term(e.Mul(e, de)) {
+			sum.Add(sum, t)
+		}
+	}
+
+	// level 0: h = 1, with the centre counted once, and weight 1/4
+	mid := new(big.Float).SetPrec(p).Quo(width, big.NewFloat(2))
+	sum.Set(f(mid.Add(a, mid)))
+	sum.SetMantExp(sum, -2)
+	h := big.NewFloat(1).SetPrec(p)
+	addTerms(h, 1, 1)
+
+	scale := new(big.Float).SetPrec(p).Mul(width, pi(p))
+	res := new(big.Float).SetPrec(p).Mul(sum, scale)
+	prev := new(big.Float).SetPrec(p)
+	d := new(big.Float).SetPrec(p)
+
+	// the error about squares at every level, so the estimates agree
+	// to the precision after a few levels of any prec
+	maxLevel := 2*bits.Len(prec) + 4
+	for level := 1; level <= maxLevel; level++ {
+
+		// the new nodes are at the odd multiples of h = 2**-level
+		h.SetMantExp(h, -1)
+		addTerms(h, 1, 2)
+
+		prev.Set(res)
+		res.Mul(sum, scale)
+		res.Mul(res, h)
+		d.Sub(res, prev)
+		if d.Sign() == 0 || (res.Sign() != 0 && d.MantExp(nil) < res.MantExp(nil)-int(prec)-8) {
+			break
+		}
+	}
+
+	return res.SetPrec(prec)
+}
+
+// expReduced returns exp(x) for a positive x of moderate size, as
+// (1 + Expm1(x·2**-k))**(2**k) with x·2**-k < 1/2. For the arguments
+// Integrate needs it's much faster than Exp, whose Newton iteration
+// calls Log. The squarings double the relative error k times, so they
+// are done with k more guard digits.
+func expReduced(x *big.Float) *big.Float {
+	k := 0
+	if e := x.MantExp(nil); e > -1 {
+		k = e + 1
+	}
+	prec := x.Prec()
+	y := new(big.Float).SetPrec(prec+uint(k)).SetMantExp(x, -k)
+	y = Expm1(y)
+	y.Add(y, big.NewFloat(1))
+	for ; k > 0; k-- {
+		y.Mul(y, y)
+	}
+	return y.SetPrec(prec)
+}
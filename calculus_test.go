@@ -0,0 +1,137 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+// relErr returns |x - want|/|want|.
+func relErr(x, want *big.Float) *big.Float {
+	d := new(big.Float).SetPrec(want.Prec()).Sub(x, want)
+	d.Quo(d, want)
+	return d.Abs(d)
+}
+
+func TestDerivative(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		f    func(*big.Float) *big.Float
+		x    float64
+		df   func(*big.Float) *big.Float // the exact derivative
+	}{
+		{"Sqrt", bigfloat.Sqrt, 4, func(x *big.Float) *big.Float { return big.NewFloat(0.25).SetPrec(x.Prec()) }},
+		{"Exp", bigfloat.Exp, 1.5, bigfloat.Exp},
+		{"Exp", bigfloat.Exp, -20, bigfloat.Exp},
+		{"Sin", bigfloat.Sin, 0, bigfloat.Cos},
+		{"Sin", bigfloat.Sin, 0.7, bigfloat.Cos},
+		{"Log", bigfloat.Log, 1e10, func(x *big.Float) *big.Float { return new(big.Float).Quo(big.NewFloat(1), x) }},
+		{"Log", bigfloat.Log, 1e-10, func(x *big.Float) *big.Float { return new(big.Float).Quo(big.NewFloat(1), x) }},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			x := big.NewFloat(test.x).SetPrec(prec)
+			d := bigfloat.Derivative(test.f, x, prec)
+			want := test.df(x)
+
+			// about half of the bits must be correct
+			tol := new(big.Float).SetMantExp(big.NewFloat(1), -int(prec/2)+2)
+			if d.Prec() != prec || relErr(d, want).Cmp(tol) > 0 {
+				t.Errorf("prec = %d, Derivative(%s, %g) =\ngot  %g (prec = %d);\nwant %g", prec, test.name, test.x, d, d.Prec(), want)
+			}
+		}
+	}
+}
+
+func TestDerivativePrecZero(t *testing.T) {
+	x := big.NewFloat(4).SetPrec(200)
+	if d := bigfloat.Derivative(bigfloat.Sqrt, x, 0); d.Prec() != 200 {
+		t.Errorf("Derivative(Sqrt, 4, 0) has precision %d; want 200", d.Prec())
+	}
+}
+
+func TestIntegrate(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		f       func(*big.Float) *big.Float
+		a, b    func(prec uint) *big.Float
+		want    func(prec uint) *big.Float
+		maxPrec uint // the integrals of slow functions are checked at lower precisions
+	}{
+		{
+			"x²",
+			func(x *big.Float) *big.Float { return new(big.Float).Mul(x, x) },
+			func(prec uint) *big.Float { return new(big.Float).SetPrec(prec) },
+			func(prec uint) *big.Float { return big.NewFloat(1).SetPrec(prec) },
+			func(prec uint) *big.Float { return new(big.Float).SetPrec(prec).Quo(big.NewFloat(1), big.NewFloat(3)) },
+			1000,
+		},
+		{
+			"Sin",
+			bigfloat.Sin,
+			func(prec uint) *big.Float { return new(big.Float).SetPrec(prec) },
+			func(prec uint) *big.Float { return bigfloat.Pi(prec + 64) },
+			func(prec uint) *big.Float { return big.NewFloat(2).SetPrec(prec) },
+			500,
+		},
+		{
+			"1/x",
+			func(x *big.Float) *big.Float { return new(big.Float).Quo(big.NewFloat(1), x) },
+			func(prec uint) *big.Float { return big.NewFloat(1).SetPrec(prec) },
+			func(prec uint) *big.Float { return big.NewFloat(2).SetPrec(prec) },
+			func(prec uint) *big.Float { return bigfloat.Log(big.NewFloat(2).SetPrec(prec)) },
+			1000,
+		},
+		{
+			// an infinite derivative at 0
+			"Sqrt",
+			bigfloat.Sqrt,
+			func(prec uint) *big.Float { return new(big.Float).SetPrec(prec) },
+			func(prec uint) *big.Float { return big.NewFloat(1).SetPrec(prec) },
+			func(prec uint) *big.Float { return new(big.Float).SetPrec(prec).Quo(big.NewFloat(2), big.NewFloat(3)) },
+			500,
+		},
+		{
+			// a singularity at 0: ∫₀¹ log(x) dx = -1
+			"Log",
+			bigfloat.Log,
+			func(prec uint) *big.Float { return new(big.Float).SetPrec(prec) },
+			func(prec uint) *big.Float { return big.NewFloat(1).SetPrec(prec) },
+			func(prec uint) *big.Float { return big.NewFloat(-1).SetPrec(prec) },
+			400,
+		},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			if prec > test.maxPrec {
+				break
+			}
+			a, b, want := test.a(prec), test.b(prec), test.want(prec)
+			x := bigfloat.Integrate(test.f, a, b, prec)
+
+			// all but the last couple of bits must be correct
+			tol := new(big.Float).SetMantExp(big.NewFloat(1), -int(prec)+2)
+			if x.Prec() != prec || relErr(x, want).Cmp(tol) > 0 {
+				t.Errorf("prec = %d, Integrate(%s, %g, %g) =\ngot  %g (prec = %d);\nwant %g", prec, test.name, a, b, x, x.Prec(), want)
+			}
+
+			// swapping the bounds negates the result
+			if y := bigfloat.Integrate(test.f, b, a, prec); y.Cmp(x.Neg(x)) != 0 {
+				t.Errorf("prec = %d, Integrate(%s, %g, %g) = %g is not the negation", prec, test.name, b, a, y)
+			}
+		}
+	}
+}
+
+func TestIntegrateSpecialValues(t *testing.T) {
+	one := big.NewFloat(1).SetPrec(100)
+	if x := bigfloat.Integrate(bigfloat.Exp, one, one, 0); x.Sign() != 0 || x.Prec() != 100 {
+		t.Errorf("Integrate(Exp, 1, 1, 0) = %g (prec = %d); want 0 (prec = 100)", x, x.Prec())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Integrate(Exp, 1, +Inf) did not panic")
+		}
+	}()
+	bigfloat.Integrate(bigfloat.Exp, one, new(big.Float).SetInf(false), 0)
+}
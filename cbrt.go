@@ -0,0 +1,101 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// Cbrt returns a big.Float representation of the cube root of
+// z. Precision is the same as the one of the argument. The function
+// returns ±0 when z = ±0, and ±Inf when z = ±Inf.
+func Cbrt(z *big.Float) *big.Float {
+
+	// ∛±0 = ±0
+	// ∛±Inf = ±Inf
+	if z.Sign() == 0 || z.IsInf() {
+		return new(big.Float).Copy(z)
+	}
+
+	// ∛(-z) = -∛z
+	neg := z.Sign() < 0
+
+	// Compute ∛(a·2**b) as ∛(a·2**r)·2**((b-r)/3), where r is the
+	// non-negative remainder of b modulo 3.
+	mant := new(big.Float)
+	exp := z.MantExp(mant)
+	mant.Abs(mant)
+	r := exp % 3
+	if r < 0 {
+		r += 3
+	}
+	mant.SetMantExp(mant, r)
+	exp -= r
+
+	// Same as in Sqrt: solving x³ - z = 0 directly requires a Quo
+	// call, but it's faster for small precisions. Solving 1/x³ - z =
+	// 0 avoids the Quo call and is much faster for high precisions.
+	var x *big.Float
+	if z.Prec() <= 128 {
+		x = cbrtDirect(mant)
+	} else {
+		x = cbrtInverse(mant)
+	}
+
+	// re-attach the exponent and sign and return
+	x.SetMantExp(x, exp/3)
+	if neg {
+		x.Neg(x)
+	}
+	return x
+}
+
+// compute ∛z using newton to solve
+// t³ - z = 0 for t
+func cbrtDirect(z *big.Float) *big.Float {
+	// f(t)/f'(t) = (t³ - z)/3t²
+	three := big.NewFloat(3)
+	f := func(t *big.Float) *big.Float {
+		x := new(big.Float).Mul(t, t) // x = t²
+		u := new(big.Float).Mul(three, x)
+		x.Mul(x, t)        // x = t³
+		x.Sub(x, z)        // x = t³ - z
+		return x.Quo(x, u) // return x = (t³ - z)/3t²
+	}
+
+	// initial guess
+	zf, _ := z.Float64()
+	guess := big.NewFloat(math.Cbrt(zf))
+
+	return newton(f, guess, z.Prec())
+}
+
+// compute ∛z using newton to solve
+// 1/t³ - z = 0 for t and then computing zt².
+func cbrtInverse(z *big.Float) *big.Float {
+	// f(t)/f'(t) = t(zt³ - 1)/3
+	//
+	// 1/3 is not exactly representable, so compute it once at the
+	// final precision (plus guard digits) instead of calling Quo at
+	// every iteration.
+	third := new(big.Float).SetPrec(z.Prec() + 64)
+	third.Quo(big.NewFloat(1), big.NewFloat(3))
+	one := big.NewFloat(1)
+	f := func(t *big.Float) *big.Float {
+		u := new(big.Float)
+		u.Mul(t, t)                     // u = t²
+		u.Mul(u, t)                     // u = t³
+		u.Mul(u, z)                     // u = zt³
+		u.Sub(u, one)                   // u = zt³ - 1
+		u.Mul(u, third)                 // u = (zt³ - 1)/3
+		return new(big.Float).Mul(t, u) // x = t(zt³ - 1)/3
+	}
+
+	// initial guess
+	zf, _ := z.Float64()
+	guess := big.NewFloat(1 / math.Cbrt(zf))
+
+	// Force a few guard digits, as in sqrtInverse.
+	x := newton(f, guess, z.Prec()+32)
+	x.Mul(x, x)
+	return x.Mul(z, x).SetPrec(z.Prec())
+}
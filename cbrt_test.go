@@ -0,0 +1,119 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+// See note in sqrt_test.go about which numbers
+// can we safely test this way.
+
+func TestCbrt(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		// 350 decimal digits are enough to give us up to 1000 binary digits
+		{"0.5", "0.79370052598409973737585281963615413019574666394992650490414288091260825281210958663677210663111047851146738084066100895174882994907637613907000552227072330968775913928121843664525624253614616872488713768230358376855333190923785587617578753085228013639621325438390785723470347549812242252548193893501115864616130471248354239830224957077540054970053927"},
+		{"2", "1.2599210498948731647672106072782283505702514647015079800819751121552996765139594837293965624362550941543102560356156652593990240406137372284591103042693552469606426166250009774745265654803068671854055186892458725167641993737096950983827831613991551293136953661839474634485765703031190958959847411059811629070535908164780114735213254847712978802422086"},
+		{"3", "1.4422495703074083823216383107801095883918692534993505775464161945416875968299973398547554797056452566868350808544895499664254239461102597148689501571852372270903320238475984450610855400272600881454988727513673553524678660747156884392233189182017038998238223321296166355085262673491335016654548957881758552741755933631318741467200604638466647569374364"},
+		{"10", "2.1544346900318837217592935665193504952593449421921085824892355063464111066483408001854415035432432761012612204917809204465575051000832749571206753778093319327305836534892638281254969314038783827968633151615752725693778372934970683568763101881668266147059903345049436171293525496169098347413979669736925921971249146750614140234563308859377534574613646"},
+		{"100", "4.6415888336127788924100763509194465765513491250112436376506928586847778696928448261899590708975713798415433082282654048205102702874957743773623223950302146509417742671965091629545214608976336693810411628606533596551384853869619496157227826277315767548830171692074480985569341563629166892879966111952461667967007729354812468687176525906572547133734153"},
+
+		{"8", "2"},
+		{"27", "3"},
+		{"-0.125", "-0.5"},
+		{"-2", "-1.2599210498948731647672106072782283505702514647015079800819751121552996765139594837293965624362550941543102560356156652593990240406137372284591103042693552469606426166250009774745265654803068671854055186892458725167641993737096950983827831613991551293136953661839474634485765703031190958959847411059811629070535908164780114735213254847712978802422086"},
+
+		{"1p300", "1p100"},
+		{"1p-300", "1p-100"},
+		{"1p1023", "1p341"},
+		{"1p-1023", "1p-341"},
+		{"1p2046", "1p682"},
+		{"1p-2046", "1p-682"},
+		{"2p1022", "1p341"},
+		{"4p-1022", "1p-340"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Cbrt(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Cbrt(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func testCbrtFloat64(scale float64, nTests int, t *testing.T) {
+	for i := 0; i < nTests; i++ {
+		r := rand.Float64() * scale
+
+		z := big.NewFloat(r)
+		x64, acc := bigfloat.Cbrt(z).Float64()
+
+		want := math.Cbrt(r)
+
+		// math.Cbrt is not guaranteed to be correctly rounded, so
+		// just require a relative error smaller than 1e-15.
+		if math.Abs((x64-want)/want) > 1e-15 || acc != big.Exact {
+			t.Errorf("Cbrt(%g) =\n got %g (%s);\nwant %g (Exact)", z, x64, acc, want)
+		}
+	}
+}
+
+func TestCbrtFloat64Small(t *testing.T) {
+	testCbrtFloat64(1e-100, 1e5, t)
+	testCbrtFloat64(-1e-10, 1e5, t)
+}
+
+func TestCbrtFloat64Medium(t *testing.T) {
+	testCbrtFloat64(1, 1e5, t)
+	testCbrtFloat64(-100, 1e5, t)
+}
+
+func TestCbrtFloat64Big(t *testing.T) {
+	testCbrtFloat64(-1e10, 1e5, t)
+	testCbrtFloat64(1e100, 1e5, t)
+}
+
+func TestCbrtSpecialValues(t *testing.T) {
+	for _, f := range []float64{
+		+0.0,
+		-0.0,
+		math.Inf(+1),
+		math.Inf(-1),
+	} {
+		z := big.NewFloat(f)
+		x := bigfloat.Cbrt(z)
+		x64, acc := x.Float64()
+		want := math.Cbrt(f)
+		if x64 != want || x.Signbit() != math.Signbit(want) || acc != big.Exact {
+			t.Errorf("Cbrt(%g) =\n got %g (%s);\nwant %g (Exact)", z, x64, acc, want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkCbrt(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4, 1e5} {
+		z := big.NewFloat(2).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Cbrt(z)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package bigfloat
+
+import "math/big"
+
+// Min returns the smaller of a and b. The result is one of the
+// arguments, not a copy. As in big.Float.Cmp, ±Inf compare as
+// expected, and +0 and -0 are equal; when a and b are equal, Min
+// returns a.
+func Min(a, b *big.Float) *big.Float {
+	if b.Cmp(a) < 0 {
+		return b
+	}
+	return a
+}
+
+// Max returns the larger of a and b. The result is one of the
+// arguments, not a copy. As in big.Float.Cmp, ±Inf compare as
+// expected, and +0 and -0 are equal; when a and b are equal, Max
+// returns a.
+func Max(a, b *big.Float) *big.Float {
+	if b.Cmp(a) > 0 {
+		return b
+	}
+	return a
+}
+
+// Clamp returns x limited to the interval [lo, hi]: lo if x < lo, hi
+// if x > hi, and x otherwise. The result is one of the arguments, not
+// a copy, and x is returned when it's equal to one of the bounds. The
+// function panics if lo > hi.
+func Clamp(x, lo, hi *big.Float) *big.Float {
+	if lo.Cmp(hi) > 0 {
+		panic("Clamp: lo is greater than hi")
+	}
+
+	switch {
+	case x.Cmp(lo) < 0:
+		return lo
+	case x.Cmp(hi) > 0:
+		return hi
+	}
+	return x
+}
+
+// MinSlice returns the smallest element of vals. The result is an
+// element of vals, not a copy; if the smallest value appears more than
+// once, the first one is returned. The function panics if vals is
+// empty.
+func MinSlice(vals []*big.Float) *big.Float {
+	if len(vals) == 0 {
+		panic("MinSlice: empty slice")
+	}
+
+	m := vals[0]
+	for _, x := range vals[1:] {
+		m = Min(m, x)
+	}
+	return m
+}
+
+// MaxSlice returns the largest element of vals. The result is an
+// element of vals, not a copy; if the largest value appears more than
+// once, the first one is returned. The function panics if vals is
+// empty.
+func MaxSlice(vals []*big.Float) *big.Float {
+	if len(vals) == 0 {
+		panic("MaxSlice: empty slice")
+	}
+
+	m := vals[0]
+	for _, x := range vals[1:] {
+		m = Max(m, x)
+	}
+	return m
+}
+
+// AlmostEqual reports whether a and b are within ulps ulps of each
+// other, that is, whether there are at most ulps-1 floats between
+// them with the larger of their precisions. Adjacent floats are one
+// ulp apart also when they have different exponents, +0 and -0 are
+// equal, and the smallest positive and negative values are two ulps
+// apart. ±Inf are only almost equal to themselves.
+func AlmostEqual(a, b *big.Float, ulps uint) bool {
+	if a.Cmp(b) == 0 {
+		return true
+	}
+	if a.IsInf() || b.IsInf() {
+		return false
+	}
+
+	prec := a.Prec()
+	if b.Prec() > prec {
+		prec = b.Prec()
+	}
+
+	d := ulpIndex(a, prec)
+	d.Sub(d, ulpIndex(b, prec))
+	return d.CmpAbs(new(big.Int).SetUint64(uint64(ulps))) <= 0
+}
+
+// ulpIndex returns the position of the finite x among the floats with
+// prec bits, counting from 0 at ±0, and negative for negative x.
+func ulpIndex(x *big.Float, prec uint) *big.Int {
+	if x.Sign() == 0 {
+		return new(big.Int)
+	}
+
+	// x = ±m·2**(e-prec), with 2**(prec-1) <= m < 2**prec, and the
+	// floats with exponent e start after the 2**(prec-1)·(e-MinExp)
+	// ones with smaller exponents
+	frac := new(big.Float)
+	e := x.MantExp(frac)
+	m, _ := frac.SetMantExp(frac.Abs(frac), int(prec)).Int(nil)
+	m.SetBit(m, int(prec)-1, 0)
+
+	k := big.NewInt(int64(e) - big.MinExp)
+	k.Lsh(k, prec-1)
+	k.Add(k, m)
+	k.Add(k, big.NewInt(1))
+	if x.Sign() < 0 {
+		k.Neg(k)
+	}
+	return k
+}
@@ -0,0 +1,193 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestMinMax(t *testing.T) {
+	inf, ninf := math.Inf(+1), math.Inf(-1)
+	for _, test := range []struct {
+		a, b     float64
+		min, max float64
+	}{
+		{1, 2, 1, 2},
+		{2, 1, 1, 2},
+		{-1, 1, -1, 1},
+		{1, inf, 1, inf},
+		{ninf, 1, ninf, 1},
+		{ninf, inf, ninf, inf},
+		{inf, inf, inf, inf},
+		{1e300, -1e300, -1e300, 1e300},
+	} {
+		a, b := big.NewFloat(test.a), big.NewFloat(test.b)
+		if x := bigfloat.Min(a, b); x.Cmp(big.NewFloat(test.min)) != 0 || (x != a && x != b) {
+			t.Errorf("Min(%g, %g) = %g; want %g", test.a, test.b, x, test.min)
+		}
+		if x := bigfloat.Max(a, b); x.Cmp(big.NewFloat(test.max)) != 0 || (x != a && x != b) {
+			t.Errorf("Max(%g, %g) = %g; want %g", test.a, test.b, x, test.max)
+		}
+	}
+}
+
+// On equal values, including +0 and -0, Min and Max return their
+// first argument.
+func TestMinMaxTies(t *testing.T) {
+	for _, test := range []struct {
+		a, b float64
+	}{
+		{1, 1},
+		{+0.0, math.Copysign(0, -1)},
+		{math.Copysign(0, -1), +0.0},
+		{math.Inf(+1), math.Inf(+1)},
+	} {
+		a := big.NewFloat(test.a)
+		b := big.NewFloat(test.b).SetPrec(100) // tell a and b apart
+		if x := bigfloat.Min(a, b); x != a {
+			t.Errorf("Min(%g, %g) returned the second argument", test.a, test.b)
+		}
+		if x := bigfloat.Max(a, b); x != a {
+			t.Errorf("Max(%g, %g) returned the second argument", test.a, test.b)
+		}
+	}
+}
+
+func TestClamp(t *testing.T) {
+	inf, ninf := math.Inf(+1), math.Inf(-1)
+	for _, test := range []struct {
+		x, lo, hi float64
+		want      float64
+	}{
+		{0.5, 0, 1, 0.5},
+		{-1, 0, 1, 0},
+		{2, 0, 1, 1},
+		{0, 0, 1, 0},
+		{1, 0, 1, 1},
+		{inf, 0, 1, 1},
+		{ninf, 0, 1, 0},
+		{inf, ninf, inf, inf},
+		{5, 3, 3, 3},
+	} {
+		x, lo, hi := big.NewFloat(test.x), big.NewFloat(test.lo), big.NewFloat(test.hi)
+		got := bigfloat.Clamp(x, lo, hi)
+		if got.Cmp(big.NewFloat(test.want)) != 0 || (got != x && got != lo && got != hi) {
+			t.Errorf("Clamp(%g, %g, %g) = %g; want %g", test.x, test.lo, test.hi, got, test.want)
+		}
+	}
+
+	// x is returned when it's equal to one of the bounds
+	x, lo := big.NewFloat(0), big.NewFloat(math.Copysign(0, -1))
+	if got := bigfloat.Clamp(x, lo, big.NewFloat(1)); got != x {
+		t.Errorf("Clamp(+0, -0, 1) did not return x")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Clamp(0, 1, 0) did not panic")
+		}
+	}()
+	bigfloat.Clamp(big.NewFloat(0), big.NewFloat(1), big.NewFloat(0))
+}
+
+func TestMinMaxSlice(t *testing.T) {
+	vals := []*big.Float{
+		big.NewFloat(3),
+		big.NewFloat(math.Inf(-1)),
+		big.NewFloat(7),
+		big.NewFloat(math.Inf(+1)),
+		big.NewFloat(-2),
+		big.NewFloat(math.Inf(+1)),
+	}
+	if x := bigfloat.MinSlice(vals); x != vals[1] {
+		t.Errorf("MinSlice = %g; want -Inf", x)
+	}
+	if x := bigfloat.MaxSlice(vals); x != vals[3] {
+		t.Errorf("MaxSlice = %g; want the first +Inf", x)
+	}
+	if x := bigfloat.MinSlice(vals[2:3]); x != vals[2] {
+		t.Errorf("MinSlice(7) = %g; want 7", x)
+	}
+
+	for name, f := range map[string]func([]*big.Float) *big.Float{
+		"MinSlice": bigfloat.MinSlice,
+		"MaxSlice": bigfloat.MaxSlice,
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s(nil) did not panic", name)
+				}
+			}()
+			f(nil)
+		}()
+	}
+}
+
+func TestAlmostEqual(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		for _, f := range []float64{1, 1.5, -3, 1e-300, 1e300} {
+
+			// y goes n floats away from x, crossing the power of two
+			// below 1 and above 1.5
+			x := big.NewFloat(f).SetPrec(prec)
+			for _, dir := range []float64{math.Inf(+1), math.Inf(-1)} {
+				y := x
+				for n := uint(0); n <= 5; n++ {
+					if !bigfloat.AlmostEqual(x, y, n) || !bigfloat.AlmostEqual(y, x, n) {
+						t.Errorf("prec = %d, AlmostEqual(%g, %g, %d) = false; want true", prec, x, y, n)
+					}
+					if n > 0 && (bigfloat.AlmostEqual(x, y, n-1) || bigfloat.AlmostEqual(y, x, n-1)) {
+						t.Errorf("prec = %d, AlmostEqual(%g, %g, %d) = true; want false", prec, x, y, n-1)
+					}
+					y = bigfloat.NextAfter(y, big.NewFloat(dir))
+				}
+			}
+		}
+	}
+}
+
+func TestAlmostEqualPowerOfTwo(t *testing.T) {
+	for _, prec := range []uint{24, 53, 100, 1000} {
+		one := big.NewFloat(1).SetPrec(prec)
+		below := bigfloat.NextAfter(one, big.NewFloat(0))
+		above := bigfloat.NextAfter(one, big.NewFloat(2))
+		if !bigfloat.AlmostEqual(below, one, 1) || bigfloat.AlmostEqual(below, one, 0) {
+			t.Errorf("prec = %d, 1 and the float below it are not one ulp apart", prec)
+		}
+		if !bigfloat.AlmostEqual(below, above, 2) || bigfloat.AlmostEqual(below, above, 1) {
+			t.Errorf("prec = %d, the floats around 1 are not two ulps apart", prec)
+		}
+	}
+}
+
+func TestAlmostEqualSpecialValues(t *testing.T) {
+	inf, ninf := math.Inf(+1), math.Inf(-1)
+	smallest := big.NewFloat(0.5)
+	smallest.SetMantExp(smallest, big.MinExp)
+	for _, test := range []struct {
+		a, b *big.Float
+		ulps uint
+		want bool
+	}{
+		{big.NewFloat(0), big.NewFloat(math.Copysign(0, -1)), 0, true},
+		{big.NewFloat(0), smallest, 1, true},
+		{big.NewFloat(0), smallest, 0, false},
+		{new(big.Float).Neg(smallest), smallest, 2, true},
+		{new(big.Float).Neg(smallest), smallest, 1, false},
+		{big.NewFloat(1), big.NewFloat(-1), 1 << 62, false},
+		{big.NewFloat(inf), big.NewFloat(inf), 0, true},
+		{big.NewFloat(inf), big.NewFloat(ninf), 1 << 62, false},
+		{big.NewFloat(inf), big.NewFloat(math.MaxFloat64), 1 << 62, false},
+
+		// the ulps are those of the larger precision
+		{big.NewFloat(1), new(big.Float).SetPrec(100).Add(big.NewFloat(1), big.NewFloat(0x1p-99)), 1, true},
+		{big.NewFloat(1), new(big.Float).SetPrec(100).Add(big.NewFloat(1), big.NewFloat(0x1p-98)), 1, false},
+	} {
+		if got := bigfloat.AlmostEqual(test.a, test.b, test.ulps); got != test.want {
+			t.Errorf("AlmostEqual(%g, %g, %d) = %t; want %t", test.a, test.b, test.ulps, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// Erf returns a big.Float representation of the error function of z.
+// Precision is the same as the one of the argument. The function
+// returns ±0 when z = ±0, and ±1 when z = ±Inf.
+func Erf(z *big.Float) *big.Float {
+
+	// Erf(±0) = ±0
+	if z.Sign() == 0 {
+		return new(big.Float).Copy(z)
+	}
+
+	// Erf(±Inf) = ±1
+	if z.IsInf() {
+		return big.NewFloat(float64(z.Sign())).SetPrec(infPrec(z))
+	}
+
+	// for large |z| 1 - erf(|z|) < e^(-z²) is below the precision, so
+	// the result rounds to ±1
+	if erfcNegligible(z, z.Prec()+2) {
+		return big.NewFloat(float64(z.Sign())).SetPrec(z.Prec())
+	}
+
+	x := erfSeries(new(big.Float).SetPrec(z.Prec() + 64).Abs(z))
+	if z.Sign() < 0 {
+		x.Neg(x)
+	}
+
+	return x.SetPrec(z.Prec())
+}
+
+// Erfc returns a big.Float representation of the complementary error
+// function of z, 1 - Erf(z). Precision is the same as the one of the
+// argument. Unlike 1 - Erf(z), the result is accurate for large z.
+// The function returns 1 when z = ±0, 0 when z = +Inf, and 2 when z
+// = -Inf. For very large z, e^(-z²) is outside the exponent range of
+// big.Float and the result is 0.
+func Erfc(z *big.Float) *big.Float {
+
+	// Erfc(±0) = 1
+	if z.Sign() == 0 {
+		return big.NewFloat(1).SetPrec(z.Prec())
+	}
+
+	// Erfc(+Inf) = 0, Erfc(-Inf) = 2
+	if z.IsInf() {
+		return big.NewFloat(float64(1 - z.Sign())).SetPrec(infPrec(z))
+	}
+
+	prec := z.Prec() + 64 // guard digits
+
+	// For z < 0, erfc(z) = 1 + erf(|z|) doesn't suffer from
+	// cancellation.
+	if z.Sign() < 0 {
+		x := Erf(new(big.Float).SetPrec(prec).Neg(z))
+		return x.Add(x, big.NewFloat(1)).SetPrec(z.Prec())
+	}
+
+	// When e^(-z²) is below the precision the asymptotic expansion is
+	// accurate enough.
+	if erfcNegligible(z, prec) {
+		return erfcAsymptotic(WithGuard(z, 64)).SetPrec(z.Prec())
+	}
+
+	// Otherwise compute 1 - erf(z). erfc(z) is about e^(-z²), so the
+	// difference loses about z²·log2(e) bits; add as many more guard
+	// digits.
+	zf, _ := z.Float64()
+	prec += uint(zf*zf*math.Log2E) + 1
+	x := erfSeries(new(big.Float).SetPrec(prec).Set(z))
+	x.Sub(big.NewFloat(1), x)
+
+	return x.SetPrec(z.Prec())
+}
+
+// erfcNegligible reports whether e^(-z²) < 2**-prec.
+func erfcNegligible(z *big.Float, prec uint) bool {
+	zf, _ := new(big.Float).Abs(z).Float64()
+	return zf*zf > float64(prec)*math.Ln2
+}
+
+// erfSeries returns erf(z), computed with z's precision using the
+// series
+//
+//	erf(z) = 2/√π·e^(-z²)·Σ 2ⁿz²ⁿ⁺¹/(1·3·5···(2n+1))
+//
+// for z > 0. All the terms are positive, so unlike the Maclaurin
+// series it doesn't suffer from cancellation for large z.
+func erfSeries(z *big.Float) *big.Float {
+
+	// The terms grow until n ≈ z² and then decrease, and each of them
+	// carries a rounding error, so add guard digits to cover them.
+	zf, _ := z.Float64()
+	prec := z.Prec() + 2*uint(math.Log2(zf*zf+2))
+
+	z2 := new(big.Float).SetPrec(prec).Mul(z, z)
+	twoZ2 := new(big.Float).SetPrec(prec).Mul(z2, big.NewFloat(2))
+	term := new(big.Float).SetPrec(prec).Set(z)
+	sum := new(big.Float).SetPrec(prec).Set(z)
+	for n := int64(1); ; n++ {
+		term.Mul(term, twoZ2)
+		term.Quo(term, new(big.Float).SetInt64(2*n+1))
+		sum.Add(sum, term)
+		if float64(n) > zf*zf && term.MantExp(nil) < sum.MantExp(nil)-int(prec) {
+			break
+		}
+	}
+
+	// multiply by 2/√π·e^(-z²)
+	x := Exp(z2.Neg(z2))
+	x.Mul(x, sum)
+	x.Quo(x, Sqrt(pi(prec)))
+	x.SetMantExp(x, 1)
+
+	return x
+}
+
+// erfcAsymptotic returns erfc(z), computed with z's precision using
+// the asymptotic expansion
+//
+//	erfc(z) ~ e^(-z²)/(z√π)·Σ (-1)ⁿ(1·3·5···(2n-1))/(2z²)ⁿ
+//
+// which is accurate to z's precision if e^(-z²) is below it.
+func erfcAsymptotic(z *big.Float) *big.Float {
+	prec := z.Prec()
+
+	twoZ2 := new(big.Float).SetPrec(prec).Mul(z, z)
+	twoZ2.SetMantExp(twoZ2, 1)
+	term := big.NewFloat(1).SetPrec(prec)
+	sum := big.NewFloat(1).SetPrec(prec)
+	for n := int64(1); ; n++ {
+		// the ratio between consecutive terms is (2n-1)/2z², so they
+		// start growing (and the expansion diverges) when it's >= 1
+		r := new(big.Float).SetPrec(prec).SetInt64(2*n - 1)
+		r.Quo(r, twoZ2)
+		if r.Cmp(big.NewFloat(1)) >= 0 {
+			break
+		}
+
+		term.Mul(term, r)
+		term.Neg(term)
+		if term.Sign() == 0 || term.MantExp(nil) < sum.MantExp(nil)-int(prec) {
+			break
+		}
+		sum.Add(sum, term)
+	}
+
+	// multiply by e^(-z²)/(z√π)
+	x := new(big.Float).SetPrec(prec).Mul(z, z)
+	x = Exp(x.Neg(x))
+	x.Mul(x, sum)
+	x.Quo(x, z)
+	x.Quo(x, Sqrt(pi(prec)))
+
+	return x
+}
@@ -0,0 +1,144 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestErf(t *testing.T) {
+	for _, test := range []struct {
+		z         string
+		erf, erfc string
+	}{
+		{"1", "0.84270079294971486934122063508260925929606699796630290845993789783471725409601084126198332534814488845415826153202169436485233905825520678977343978705929558133861350351469641943929315680589912071863871281944829395869379291546094931956036527468177658915908436590270852325506774507182759931377566806003260943951297520961744834254972309062361008697450559", "0.15729920705028513065877936491739074070393300203369709154006210216528274590398915873801667465185511154584173846797830563514766094174479321022656021294070441866138649648530358056070684319410087928136128718055170604130620708453905068043963472531822341084091563409729147674493225492817240068622433193996739056048702479038255165745027690937638991302549441"},
+		{"0.5", "0.52049987781304653768274665389196452873645157575796370005880572564719352171685357091478821873478775703296612438619439123606541469059089077460621809802503697417001919711186197446166540544109888249018844108050082845304975729473637323052291620075341321703749337460388343886003747430577853287993019034530288498247759038123553439418583973329653191145738051", "0.47950012218695346231725334610803547126354842424203629994119427435280647828314642908521178126521224296703387561380560876393458530940910922539378190197496302582998080288813802553833459455890111750981155891949917154695024270526362676947708379924658678296250662539611656113996252569422146712006980965469711501752240961876446560581416026670346808854261949"},
+		{"-2", "-0.99532226501895273416206925636725292861089179704006007673835232620043728071999517736762900801968068048793932871559475578526423580712807981712721596437683746316212458492640055197639230070680510259609877122853617957386416822269003032641264371869846124569263254746292152532088676030990802452288081504982483788856254733974386465521806344553068321662667371", "1.9953222650189527341620692563672529286108917970400600767383523262004372807199951773676290080196806804879393287155947557852642358071280798171272159643768374631621245849264005519763923007068051025960987712285361795738641682226900303264126437186984612456926325474629215253208867603099080245228808150498248378885625473397438646552180634455306832166266737"},
+		{"0.001", "0.0011283787909692363799484776569048125992468632126466421387975623811772852508510619217932169460941249851053153847440812374188533352185487744971594870078210731114167206513576530885732014683022229350035459105757168437679892827379179468567145857875700079259666386642887534711784150526862328378370774339370024098582735340629481150739786985622136099549512938", "0.99887162120903076362005152234309518740075313678735335786120243761882271474914893807820678305390587501489468461525591876258114666478145122550284051299217892688858327934864234691142679853169777706499645408942428315623201071726208205314328541421242999207403336133571124652882158494731376716216292256606299759014172646593705188492602130143778639004504871"},
+		{"3.5", "0.99999925690162765858725447631624390436427933990782720253740889043005649996040947060072577065342078390280211236271216595918939045439347168317980792068080032627772464935703497817187125314883948939427764312561652878387072860079344110819673147481853936827862229814480770373543530477990578010958060902713855554329652630100401731180108580816736682930119013", "7.4309837234141274552368375609563572066009217279746259110956994350003959052939927422934657921609719788763728783404081060954560652831682019207931919967372227535064296502182812874685116051060572235687438347121612927139920655889180326852518146063172137770185519229626456469522009421989041939097286144445670347369899598268819891419183263317069880987022132e-7"},
+		{"-0.125", "-0.14031620480133381739302944652162339818697958314984547311417302642763646873661225663608179035569379807872375464909962100827255825585457275231880095489245521355390327802239000925252180727530332721482076919232432915755101495832339027232160852569820360359858117531301367166155789910879101507551360493071728748023065785623250225729626245516872759370825452", "1.1403162048013338173930294465216233981869795831498454731141730264276364687366122566360817903556937980787237546490996210082725582558545727523188009548924552135539032780223900092525218072753033272148207691923243291575510149583233902723216085256982036035985811753130136716615578991087910150755136049307172874802306578562325022572962624551687275937082545"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+			if !exact(z, test.z) {
+				continue
+			}
+
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.erf, 10)
+			if x := bigfloat.Erf(z); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Erf(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+
+			want.Parse(test.erfc, 10)
+			if x := bigfloat.Erfc(z); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Erfc(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+// In the tail erfc(z) is tiny, and it must keep full relative
+// precision.
+func TestErfcTail(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"10", "2.0884875837625447570007862949577886115608181193211637270122137139381746958334402906107663842857235539815259392365240398623421150209194114523894764818663073112974049585695926170732469542885760918632358949403916099935357080265117015576262390751186848596932204354358802651029674898233428546079294713234354417991780696658851274239856802171224992158078993e-45"},
+		{"27", "5.2370489237892556850160676828495470909339125479686707995992159452467728792652977006952136063128839416015270569852858664102409293059146087960279073424613211864235843417380570951744643323639455467513592538113612956847331142925071898162202737601500575794366247298634985707149287628148069306690578743164423272722579530723946064820151425984250302830293569e-319"},
+		{"40", "1.8969610599662765092682782597134154349369075639291861834628347529004118052051118866052566907767600413653059830346805621047050332815666929526046108725475941955075765307661397852514839745944541763124556987815495050395812097569250589637267680867409157005507260894090765064953592043640860881994579904269083010487189437462633168864515990990928383164192929e-697"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			if x := bigfloat.Erfc(z); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Erfc(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func testErfFloat64(scale float64, nTests int, t *testing.T) {
+	for i := 0; i < nTests; i++ {
+		r := rand.Float64() * scale
+		z := big.NewFloat(r).SetPrec(53)
+
+		// The Go math functions are not correctly rounded, so just
+		// require a relative error smaller than 1e-14.
+		x64, _ := bigfloat.Erf(z).Float64()
+		if want := math.Erf(r); math.Abs(x64-want) > 1e-14*math.Abs(want) {
+			t.Errorf("Erf(%g) = %g; want %g", r, x64, want)
+		}
+		x64, _ = bigfloat.Erfc(z).Float64()
+		if want := math.Erfc(r); math.Abs(x64-want) > 1e-14*math.Abs(want) {
+			t.Errorf("Erfc(%g) = %g; want %g", r, x64, want)
+		}
+	}
+}
+
+func TestErfFloat64Small(t *testing.T) {
+	testErfFloat64(-1e-5, 300, t)
+	testErfFloat64(1, 300, t)
+}
+
+func TestErfFloat64Medium(t *testing.T) {
+	testErfFloat64(-4, 300, t)
+	testErfFloat64(6, 300, t)
+}
+
+func TestErfFloat64Big(t *testing.T) {
+	testErfFloat64(26, 300, t)
+}
+
+func TestErfSpecialValues(t *testing.T) {
+	for _, z := range []*big.Float{
+		big.NewFloat(+0.0),
+		big.NewFloat(math.Copysign(0, -1)),
+		big.NewFloat(math.Inf(+1)),
+		big.NewFloat(math.Inf(-1)),
+		new(big.Float).SetInf(false), // prec = 0
+		new(big.Float).SetInf(true),
+	} {
+		f, _ := z.Float64()
+		for _, test := range []struct {
+			name string
+			x    *big.Float
+			want float64
+		}{
+			{"Erf", bigfloat.Erf(z), math.Erf(f)},
+			{"Erfc", bigfloat.Erfc(z), math.Erfc(f)},
+		} {
+			x64, acc := test.x.Float64()
+			if x64 != test.want || math.Signbit(x64) != math.Signbit(test.want) || acc != big.Exact {
+				t.Errorf("%s(%g) =\n got %g (%s);\nwant %g (Exact)", test.name, f, x64, acc, test.want)
+			}
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkErf(b *testing.B) {
+	z := big.NewFloat(1.5).SetPrec(1e4)
+	_ = bigfloat.Erf(z) // fill pi cache before benchmarking
+
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		z = big.NewFloat(1.5).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Erf(z)
+			}
+		})
+	}
+}
@@ -1,28 +1,40 @@
 package bigfloat
 
 import (
+	"context"
 	"math"
 	"math/big"
+	"math/bits"
 )
 
 // Exp returns a big.Float representation of exp(z). Precision is
-// the same as the one of the argument. The function returns +Inf
-// when z = +Inf, and 0 when z = -Inf.
+// the same as the one of the argument. The function returns 1 when
+// z = ±0, +Inf when z = +Inf, and +0 when z = -Inf. If exp(z) is
+// outside the exponent range of big.Float, the result is +Inf (for
+// large positive z) or +0 (for large negative z).
 func Exp(z *big.Float) *big.Float {
+	x, _ := ExpContext(context.Background(), z)
+	return x
+}
+
+// ExpContext is like Exp, but it stops the computation and returns
+// ctx.Err() if ctx is done before the result is ready. The context
+// is checked at the start of every iteration.
+func ExpContext(ctx context.Context, z *big.Float) (*big.Float, error) {
 
-	// exp(0) == 1
+	// Exp(±0) = 1, exactly
 	if z.Sign() == 0 {
-		return big.NewFloat(1).SetPrec(z.Prec())
+		return big.NewFloat(1).SetPrec(z.Prec()), nil
 	}
 
 	// Exp(+Inf) = +Inf
 	if z.IsInf() && z.Sign() > 0 {
-		return big.NewFloat(math.Inf(+1)).SetPrec(z.Prec())
+		return big.NewFloat(math.Inf(+1)).SetPrec(z.Prec()), nil
 	}
 
-	// Exp(-Inf) = 0
+	// Exp(-Inf) = +0
 	if z.IsInf() && z.Sign() < 0 {
-		return big.NewFloat(0).SetPrec(z.Prec())
+		return big.NewFloat(0).SetPrec(z.Prec()), nil
 	}
 
 	guess := new(big.Float)
@@ -34,8 +46,11 @@ func Exp(z *big.Float) *big.Float {
 		// perform argument reduction using
 		//     e^{2z} = (e^z)²
 		halfZ := new(big.Float).Mul(z, big.NewFloat(0.5))
-		halfExp := Exp(halfZ.SetPrec(z.Prec() + 64))
-		return new(big.Float).Mul(halfExp, halfExp).SetPrec(z.Prec())
+		halfExp, err := ExpContext(ctx, halfZ.SetPrec(z.Prec()+64))
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Float).Mul(halfExp, halfExp).SetPrec(z.Prec()), nil
 	} else {
 		// we got a nice IEEE-754 estimate
 		guess.SetFloat64(zfs)
@@ -48,7 +63,259 @@ func Exp(z *big.Float) *big.Float {
 		return x.Mul(x, t)
 	}
 
-	x := newton(f, guess, z.Prec())
+	x, _, err := newtonContext(ctx, f, guess, z.Prec(), 0)
+	if err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// Expm1 returns a big.Float representation of exp(z) - 1. Precision
+// is the same as the one of the argument. Unlike Exp(z) - 1, the
+// result is accurate when z is close to 0. The function returns ±0
+// when z = ±0, +Inf when z = +Inf, and -1 when z = -Inf.
+func Expm1(z *big.Float) *big.Float {
 
-	return x
+	// Expm1(±0) = ±0, Expm1(+Inf) = +Inf
+	if z.Sign() == 0 || (z.IsInf() && z.Sign() > 0) {
+		return new(big.Float).Copy(z)
+	}
+
+	// Expm1(-Inf) = -1
+	if z.IsInf() {
+		return big.NewFloat(-1).SetPrec(infPrec(z))
+	}
+
+	// For |z| >= 1 there's no cancellation in exp(z) - 1.
+	exp := z.MantExp(nil)
+	if exp > 0 {
+		x := Exp(WithGuard(z, 64))
+		return x.Sub(x, big.NewFloat(1)).SetPrec(z.Prec())
+	}
+
+	// expm1(z) = z + z²/2 + ..., so if z² is below the precision the
+	// result rounds to z
+	if 2*(-exp) > int(z.Prec())+2 {
+		return new(big.Float).Copy(z)
+	}
+
+	// Halve z until |z| < 2**-steps, sum the Taylor series, and then
+	// scale back using
+	//   expm1(2x) = expm1(x)·(expm1(x) + 2)
+	// which doesn't suffer from cancellation. Each doubling step loses
+	// a bit, so add as many guard digits.
+	steps := reductionSteps(z.Prec())
+	prec := z.Prec() + 64 + uint(steps)
+
+	x := new(big.Float).SetPrec(prec).Set(z)
+	k := 0
+	if exp > -steps {
+		k = exp + steps
+		x.SetMantExp(x, -k)
+	}
+
+	// expm1(x) = Σ xⁿ/n!, for n >= 1
+	term := new(big.Float).SetPrec(prec).Set(x)
+	sum := new(big.Float).SetPrec(prec).Set(x)
+	for n := int64(2); ; n++ {
+		term.Mul(term, x)
+		term.Quo(term, new(big.Float).SetInt64(n))
+		if term.Sign() == 0 || term.MantExp(nil) < sum.MantExp(nil)-int(prec) {
+			break
+		}
+		sum.Add(sum, term)
+	}
+
+	two := big.NewFloat(2)
+	t := new(big.Float).SetPrec(prec)
+	for ; k > 0; k-- {
+		t.Add(sum, two)
+		sum.Mul(sum, t)
+	}
+
+	return sum.SetPrec(z.Prec())
+}
+
+// Exp2 returns a big.Float representation of 2**z. Precision is the
+// same as the one of the argument. When z is an integer the result is
+// exact, and it's computed by setting the exponent directly. The
+// function returns 1 when z = ±0, +Inf when z = +Inf, and 0 when z =
+// -Inf. If 2**z is outside the exponent range of big.Float, the result
+// is +Inf (for large positive z) or +0 (for large negative z).
+func Exp2(z *big.Float) *big.Float {
+
+	// Exp2(±0) = 1
+	if z.Sign() == 0 {
+		return big.NewFloat(1).SetPrec(z.Prec())
+	}
+
+	// Exp2(+Inf) = +Inf, Exp2(-Inf) = 0
+	if z.IsInf() {
+		return expOverflow(z)
+	}
+
+	// 2**z = 2**n · 2**f, with n = ⌊z⌋ and 0 <= f < 1. The first
+	// factor is exact, and z - n is computed exactly.
+	n, acc := Floor(z).Int64()
+	if acc != big.Exact {
+		return expOverflow(z)
+	}
+	x := big.NewFloat(1).SetPrec(z.Prec())
+	if !z.IsInt() {
+		prec := z.Prec() + 64 // guard digits
+		f := new(big.Float).SetPrec(prec).Sub(z, new(big.Float).SetInt64(n))
+		x = Exp(f.Mul(f, ln2(prec)))
+	}
+
+	return x.SetMantExp(x, int(n)).SetPrec(z.Prec())
+}
+
+// Exp10 returns a big.Float representation of 10**z. Precision is the
+// same as the one of the argument. When z is an integer the result is
+// computed using binary exponentiation, and it's exact when 10**z is
+// representable in z's precision. The function returns 1 when z = ±0,
+// +Inf when z = +Inf, and 0 when z = -Inf. If 10**z is outside the
+// exponent range of big.Float, the result is +Inf (for large positive
+// z) or +0 (for large negative z).
+func Exp10(z *big.Float) *big.Float {
+
+	// Exp10(±0) = 1
+	if z.Sign() == 0 {
+		return big.NewFloat(1).SetPrec(z.Prec())
+	}
+
+	// Exp10(+Inf) = +Inf, Exp10(-Inf) = 0
+	if z.IsInf() {
+		return expOverflow(z)
+	}
+
+	if z.IsInt() {
+		n, acc := z.Int64()
+		if acc != big.Exact {
+			return expOverflow(z)
+		}
+		// 10 needs 3 bits
+		prec := z.Prec()
+		if prec < 3 {
+			prec = 3
+		}
+		return PowInt(big.NewFloat(10).SetPrec(prec), int(n)).SetPrec(z.Prec())
+	}
+
+	// 10**z = exp(z·log(10)). The product has about log2|z| bits
+	// before the binary point, which are lost when taking the
+	// exponential, so add as many guard digits.
+	prec := z.Prec() + 64
+	if exp := z.MantExp(nil); exp > 0 {
+		prec += uint(exp) + 2
+	}
+	x := new(big.Float).SetPrec(prec).Mul(z, ln10(prec))
+
+	return Exp(x).SetPrec(z.Prec())
+}
+
+// Sigmoid returns a big.Float representation of the logistic function
+// of z, 1/(1 + e⁻ᶻ). Precision is the same as the one of the argument.
+// The function returns 0.5 when z = ±0, 1 when z = +Inf, and 0 when z
+// = -Inf.
+func Sigmoid(z *big.Float) *big.Float {
+
+	// Sigmoid(±0) = 0.5
+	if z.Sign() == 0 {
+		return big.NewFloat(0.5).SetPrec(z.Prec())
+	}
+
+	// Sigmoid(+Inf) = 1, Sigmoid(-Inf) = 0
+	if z.IsInf() {
+		return big.NewFloat(float64(z.Sign()+1) / 2).SetPrec(infPrec(z))
+	}
+
+	prec := z.Prec() + 64 // guard digits
+
+	// Compute e^(-|z|), which is at most 1, and then
+	//   1/(1 + e⁻ᶻ)    for z > 0
+	//   eᶻ/(1 + eᶻ)    for z < 0
+	// so that neither branch takes the exponential of a large
+	// positive value.
+	x := new(big.Float).SetPrec(prec).Abs(z)
+	x = Exp(x.Neg(x))
+	d := new(big.Float).SetPrec(prec).Add(x, big.NewFloat(1))
+	if z.Sign() > 0 {
+		x.SetInt64(1)
+	}
+
+	return x.Quo(x, d).SetPrec(z.Prec())
+}
+
+// Softmax returns a new slice with the softmax of vals,
+//
+//	eˣⁱ/(eˣ¹ + eˣ² + ... + eˣⁿ)
+//
+// in the same order. Precision is the largest of the precisions of the
+// elements. The maximum is subtracted from the elements before taking
+// the exponentials, so they don't overflow, and the results sum to 1
+// to within an ulp per element. If some of the elements are +Inf, they
+// share the total equally and the others are 0. The function panics if
+// all the elements are -Inf, and returns an empty slice if vals is
+// empty.
+func Softmax(vals []*big.Float) []*big.Float {
+
+	res := make([]*big.Float, len(vals))
+	if len(vals) == 0 {
+		return res
+	}
+
+	var prec uint
+	for _, x := range vals {
+		if x.Prec() > prec {
+			prec = x.Prec()
+		}
+	}
+
+	m := MaxSlice(vals)
+	if m.IsInf() {
+		if m.Sign() < 0 {
+			panic("Softmax: all the arguments are -Inf")
+		}
+
+		// the +Inf elements share the total
+		var n int64
+		for _, x := range vals {
+			if x.IsInf() && x.Sign() > 0 {
+				n++
+			}
+		}
+		for i, x := range vals {
+			res[i] = new(big.Float).SetPrec(prec)
+			if x.IsInf() && x.Sign() > 0 {
+				res[i].Quo(big.NewFloat(1), new(big.Float).SetInt64(n))
+			}
+		}
+		return res
+	}
+
+	// the sum of the n exponentials carries n rounding errors, so add
+	// log2(n) more guard digits
+	p := prec + 64 + uint(bits.Len(uint(len(vals)))) // guard digits
+
+	t := new(big.Float).SetPrec(p)
+	for i, x := range vals {
+		res[i] = Exp(t.Sub(x, m))
+	}
+	s := Sum(res)
+	for _, x := range res {
+		x.Quo(x, s).SetPrec(prec)
+	}
+
+	return res
+}
+
+// expOverflow returns the result of Exp2 and Exp10 for z = ±Inf, or
+// when z is so large that the result is outside the exponent range of
+// big.Float: +Inf for z > 0, and +0 for z < 0.
+func expOverflow(z *big.Float) *big.Float {
+	if z.Sign() > 0 {
+		return new(big.Float).SetPrec(z.Prec()).SetInf(false)
+	}
+	return new(big.Float).SetPrec(z.Prec())
 }
@@ -1,6 +1,7 @@
 package bigfloat_test
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"math/big"
@@ -102,6 +103,412 @@ func TestExpSpecialValues(t *testing.T) {
 	}
 }
 
+// exp(z) overflows the big.Float exponent range (MaxExp ≈ 2**31)
+// for z > MaxExp·ln2 ≈ 1.488e9.
+func TestExpOverflow(t *testing.T) {
+	for _, test := range []struct {
+		z       string
+		inf     bool
+		zero    bool
+		wantExp int
+	}{
+		{"1e9", false, false, 1442695041},
+		{"-1e9", false, false, -1442695040},
+		{"1.5e9", true, false, 0},
+		{"-1.5e9", false, true, 0},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200} {
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Exp(z)
+
+			switch {
+			case test.inf:
+				if !x.IsInf() || x.Sign() < 0 {
+					t.Errorf("prec = %d, Exp(%v) = %g; want +Inf", prec, test.z, x)
+				}
+			case test.zero:
+				if x.Sign() != 0 || x.Signbit() {
+					t.Errorf("prec = %d, Exp(%v) = %g; want +0", prec, test.z, x)
+				}
+			default:
+				if x.IsInf() || x.MantExp(nil) != test.wantExp {
+					t.Errorf("prec = %d, Exp(%v) has exponent %d; want %d", prec, test.z, x.MantExp(nil), test.wantExp)
+				}
+			}
+		}
+	}
+}
+
+func TestExpContext(t *testing.T) {
+	for _, f := range []float64{0.5, 3, 100} {
+		z := big.NewFloat(f).SetPrec(1000)
+
+		x, err := bigfloat.ExpContext(context.Background(), z)
+		if want := bigfloat.Exp(z); err != nil || x.Cmp(want) != 0 {
+			t.Errorf("ExpContext(%g) = %g, %v; want %g, nil", f, x, err, want)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if x, err := bigfloat.ExpContext(ctx, z); err != context.Canceled {
+			t.Errorf("ExpContext(cancelled, %g) = %g, %v; want nil, %v", f, x, err, context.Canceled)
+		}
+
+		ctx = &countCtx{context.Background(), 1}
+		if x, err := bigfloat.ExpContext(ctx, z); err != context.Canceled {
+			t.Errorf("ExpContext(cancelled after 1 iteration, %g) = %g, %v; want nil, %v", f, x, err, context.Canceled)
+		}
+	}
+}
+
+func TestExpm1(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"1e-30", "1.0000000000000000000000000000005000000000000000000000000000001666666666666666666666666666667083333333333333333333333333333416666666666666666666666666666680555555555555555555555555555557539682539682539682539682539682787698412698412698412698412698440255731922398589065255731922401344797178130511463844797178130761984928651595318261984928651616195018973e-30"},
+		{"-1e-10", "-9.9999999995000000000166666666662500000000083333333331944444444464285714285466269841272597001763640873015873266394099725345385067623348873348758641417372340818108009095732310046138876281888468437471576510452290573798444347645624701733998356688223337190556824279619475849031609703406080850868023811846259470155060516322437139326296909871053061792021559e-11"},
+		{"0.3", "0.34985880757600310398374431332800733037829969735936580304991798993961258739953989129379648578409671518299391504649664982355528131790474617633017469791413461162351018034877672888213410765889314288623754631526895642365947078097831275718127672836501522625389092486500748236107974243154446716317520301100324043075013626627538383671033940360545996238238569"},
+		{"-0.7", "-0.50341469620859048529519990660247103829233283428818373794528850298275642992709666682495849693809186866943807931588736807522176908375956714004508587145531402790677842210319180971813748109687315088726870097119291208486175569106556500259329187425346296643047756162805108218768532901873204882397961871017454935287333446181712899677738352094688232129783640"},
+		{"2", "6.3890560989306502272304274605750078131803155705518473240871278225225737960790577633843124850791217947737531612654788661238846036927812733744783922133980777749001228956074107537023913309475506820865818202696478682084042209822552348757424625414146799281293318880707633010193378997407299869600953033075153208188236846947930299135587714456831239232727646"},
+		{"-50", "-0.99999999999999999999980712501520360822169826571834729874252471673487697370891021908961794883750203534083476266212222648630021550802106023816796251020669266196750048496111884159436997575390461785079524932463678481864313495069686304821772714269030714593574375602599649490387005472729434452307806949014487726818529773617655450072486186461520650998681440"},
+		{"1e-5", "0.000010000050000166667083334166668055557539685019844025575947974286518040859924541627171360141414808527964710456114808568579194429073542519236311802480484946481318067459005203787629467197723492854996446403371670522585399835010157731202234906636252385776731649333229600538757687559125747276070627404309222464387085120467139817150375170887195437599793185931"},
+		{"0.75", "1.1170000166126746685453698198370956101344915847024034217791330308109845333640128200027915602666157982188859047190155142623585203389722060194287309647850753815687763740346986575676391837495167514651568288075236749574281618696995461994430935724354794718402112273103810579343166906610252897495336585152351542812834985201608679677929578034355629957631891"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+			if !exact(z, test.z) {
+				continue
+			}
+
+			x := bigfloat.Expm1(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Expm1(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestExpm1Tiny(t *testing.T) {
+	// Expm1(1e-30) is checked against the exact value in TestExpm1,
+	// while Exp(1e-30) - 1 loses about 100 of the 200 bits to
+	// cancellation.
+	z := new(big.Float).SetPrec(200)
+	z.Parse("1e-30", 10)
+
+	x := bigfloat.Expm1(z)
+	y := bigfloat.Exp(z)
+	y.Sub(y, big.NewFloat(1))
+	d := new(big.Float).Sub(x, y)
+	if d.Sign() == 0 || d.MantExp(nil) < x.MantExp(nil)-120 {
+		t.Errorf("Exp(1e-30) - 1 = %g is unexpectedly accurate", y)
+	}
+}
+
+func TestExpm1SpecialValues(t *testing.T) {
+	for _, f := range []float64{
+		+0.0,
+		-0.0,
+		math.Inf(+1),
+		math.Inf(-1),
+	} {
+		z := big.NewFloat(f).SetPrec(53)
+		x64, acc := bigfloat.Expm1(z).Float64()
+		want := math.Expm1(f)
+		if x64 != want || math.Signbit(x64) != math.Signbit(want) || acc != big.Exact {
+			t.Errorf("Expm1(%g) =\n got %g (%s);\nwant %g (Exact)", f, x64, acc, want)
+		}
+	}
+
+	// infinities with 0 bits of precision
+	if x := bigfloat.Expm1(new(big.Float).SetInf(true)); x.Cmp(big.NewFloat(-1)) != 0 {
+		t.Errorf("Expm1(-Inf (prec = 0)) = %g; want -1", x)
+	}
+	if x := bigfloat.Expm1(new(big.Float).SetInf(false)); !x.IsInf() || x.Sign() < 0 {
+		t.Errorf("Expm1(+Inf (prec = 0)) = %g; want +Inf", x)
+	}
+}
+
+func TestExp2(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"10", "1024"},
+		{"-3", "0.125"},
+		{"1000", "1p1000"},
+		{"-100000", "1p-100000"},
+		{"0.5", "1.4142135623730950488016887242096980785696718753769480731766797379907324784621070388503875343276415727350138462309122970249248360558507372126441214970999358314132226659275055927557999505011527820605714701095599716059702745345968620147285174186408891986095523292304843087143214508397626036279952514079896872533965463318088296406206152583523950547457503"},
+		{"-3.25", "0.10511205190671431787889068452915186188000428279459806385165326074686559436923777997679050052491156702160019671793988563502360954052224428604084017939524400629949054332140162980741654049297861772431263591306989130291759740122852020209363636889837903962617385447626790875400560103678589401793114491499337045181515141500901775172830710044801768550331619"},
+		{"100.75", "2131925691052275356552421013298.0220944740998709565850696081414699364483314264725076885925741380635987768404375116268740184722694280253834725216612273298498450855676526723566289978623289721446695362342939564132836599225024605957548962037894246875943623058500950333613762347731262895816207756211597051069753565936629896747499802758580675243085655507692"},
+		{"0.0009765625", "1.0006771306930663566781727848746471948378219842487376938696040679547579958003103997203954325569675272656376048393075422278479726850332984973679703358999345685057005957162851902602276057240925061652943746830573270297436719842708541493761719969907465524174311674928634278310904288688148327927683982660119515371035818949620219318575683594436309974220312"},
+		{"-1000.5", "6.5991703327832115730626027661165667824167767568064882455758198576469443361662353940387058820908858885863882204045345443260515646299385590892789072959207273208449244086937949656576454882969678838600880601072808934480298115097249548314040650983451290294602536315801275919895080342227971667319043630003991861435461493964915092112814577817517000998329234e-302"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+			if !exact(z, test.z) {
+				continue
+			}
+
+			if x := bigfloat.Exp2(z); x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, Exp2(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestExp10(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"3", "1000"},
+		{"-2", "0.01"},
+		{"22", "1e22"},
+		{"100", "1e100"},
+		{"-100", "1e-100"},
+		{"0.5", "3.1622776601683793319988935444327185337195551393252168268575048527925944386392382213442481083793002951873472841528400551485488560304538800146905195967001539033449216571792599406591501534741133394841240853169295770904715764610443692578790620378086099418283717115484063285529991185968245642033269616046913143361289497918902665295436126761787813500613882"},
+		{"-1.5", "0.031622776601683793319988935444327185337195551393252168268575048527925944386392382213442481083793002951873472841528400551485488560304538800146905195967001539033449216571792599406591501534741133394841240853169295770904715764610443692578790620378086099418283717115484063285529991185968245642033269616046913143361289497918902665295436126761787813500613882"},
+		{"20.25", "177827941003892280122.54211951926848447357905264022553580118307227763018815394938049003003992787021550882790481595358077931526152511399122389161746063678363153889926043472883228817837777575789134679305972890277106525325937115702860238090346135277501065180549181465214103468667360553227490331124809362304847341533243344571365132562404768151141760358749"},
+		{"0.0009765625", "1.0022511482929129154656736388665711924542411302082270992084205451248897601200295892332629046652501812633734239741938986063096780983259127238584639303012818152099944166693221004991826042083425634607518942811726377235731685226463352362434235211395599085474932205453212011393656766113557607102156539361057183980420059599402087862124198304780061385182589"},
+		{"-300.125", "7.4989420933245582730218427561513643844186791816497101462041900542982752516716062798067369598314455624659208400772405854520423536652404971628440010487562360554225718169228352258270558444974677091331369568779831849648711400542526892462689052466996532386508319910998099357867271529481563612020121367651662843701349146168790457721617844932713921073857215e-301"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+			if !exact(z, test.z) {
+				continue
+			}
+
+			if x := bigfloat.Exp10(z); x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, Exp10(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestExp2Exact(t *testing.T) {
+	for n := -1074; n <= 1023; n++ {
+		z := big.NewFloat(float64(n)).SetPrec(24)
+		x64, acc := bigfloat.Exp2(z).Float64()
+		if want := math.Ldexp(1, n); x64 != want || acc != big.Exact {
+			t.Errorf("Exp2(%d) = %g (%s); want %g (Exact)", n, x64, acc, want)
+		}
+	}
+}
+
+func TestExp2AgreesWithPow(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		z := big.NewFloat(rand.Float64()*200 - 100).SetPrec(500)
+		if x, want := bigfloat.Exp2(z), bigfloat.Pow(big.NewFloat(2).SetPrec(500), z); x.Cmp(want) != 0 {
+			t.Errorf("Exp2(%g) =\ngot  %g;\nwant %g", z, x, want)
+		}
+		if x, want := bigfloat.Exp10(z), bigfloat.Pow(big.NewFloat(10).SetPrec(500), z); x.Cmp(want) != 0 {
+			t.Errorf("Exp10(%g) =\ngot  %g;\nwant %g", z, x, want)
+		}
+	}
+}
+
+func TestExp2SpecialValues(t *testing.T) {
+	for _, f := range []float64{
+		+0.0,
+		-0.0,
+		math.Inf(+1),
+		math.Inf(-1),
+		1e12,
+		-1e12,
+		1e300,
+		-1e300,
+	} {
+		z := big.NewFloat(f)
+		for _, test := range []struct {
+			name string
+			x    *big.Float
+			want float64
+		}{
+			{"Exp2", bigfloat.Exp2(z), math.Exp2(f)},
+			{"Exp10", bigfloat.Exp10(z), math.Pow(10, f)},
+		} {
+			x64, acc := test.x.Float64()
+			if x64 != test.want || math.Signbit(x64) != math.Signbit(test.want) || acc != big.Exact {
+				t.Errorf("%s(%g) =\n got %g (%s);\nwant %g (Exact)", test.name, f, x64, acc, test.want)
+			}
+		}
+	}
+}
+
+func TestSigmoid(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"1", "0.73105857863000487925115924182183627436514464016505651927636590791904045307020463938747453207598124529217446649314077456644113273093479894797857230580793928166456635208338846959868628396538513501865072430429963120479089180594644957335190138461066784089142386666109497063239400653967064386903710010004796478783879921717566625736135639980121668785368023"},
+		{"-2.5", "0.075858180021243551193306176646247773130712039660784238836327358482083323649810463478543201721795166630671928886935196740197691500364051726037544474898990817765046698222224699298079423358450668185905933592606435339568115784527323298072147961029297542580158447329331631225817132865982627329982430176009913276963128905556600736355075532000616601319767969"},
+		{"0.125", "0.53120937337375625724507144559710566705347016076310486806587218455173729865935878694901256601269387825953673017553676092484088780781182137533625389756524723939640632983357749576290864026630911972747941389487249984229406036045558837340557127534250038016820549876805699648772078209987571969953477840934877283055532112012118622848572740931847730080831641"},
+		{"10", "0.99995460213129756560549522376723651054490630799527393136821443613128050816696050841149333071443214337525525456152793059954380432110365691524341879332087752384843320714585073690728363947868973872599651672659480590398633963816279040644590823311001006119121992520183370531693135742289961525496327109759109224721964018870746882887336875188946703861646529"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Sigmoid(z)
+
+			if x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, Sigmoid(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+// For large |z| Sigmoid(z) rounds to 1, and Sigmoid(-z) is about e⁻ᶻ,
+// with full relative precision.
+func TestSigmoidLarge(t *testing.T) {
+	for _, f := range []float64{1000, 1e5, 1e9} {
+		for _, prec := range []uint{53, 100, 1000} {
+			z := big.NewFloat(f).SetPrec(prec)
+			if x := bigfloat.Sigmoid(z); x.Cmp(big.NewFloat(1)) != 0 {
+				t.Errorf("prec = %d, Sigmoid(%g) = %g; want 1", prec, f, x)
+			}
+
+			z.Neg(z)
+			want := bigfloat.Exp(z)
+			if x := bigfloat.Sigmoid(z); x.Cmp(want) != 0 || x.Sign() <= 0 {
+				t.Errorf("prec = %d, Sigmoid(%g) =\ngot  %g;\nwant %g", prec, -f, x, want)
+			}
+		}
+	}
+}
+
+func TestSigmoidSpecialValues(t *testing.T) {
+	for _, test := range []struct {
+		z    *big.Float
+		want float64
+	}{
+		{big.NewFloat(0), 0.5},
+		{big.NewFloat(math.Copysign(0, -1)), 0.5},
+		{big.NewFloat(math.Inf(+1)), 1},
+		{big.NewFloat(math.Inf(-1)), 0},
+		{new(big.Float).SetInf(false), 1},
+		{new(big.Float).SetInf(true), 0},
+	} {
+		x := bigfloat.Sigmoid(test.z)
+		if x64, _ := x.Float64(); x64 != test.want || math.Signbit(x64) || x.Prec() == 0 {
+			t.Errorf("Sigmoid(%g) = %g (prec = %d); want %g", test.z, x, x.Prec(), test.want)
+		}
+	}
+}
+
+func TestSoftmax(t *testing.T) {
+	rnd := rand.New(rand.NewSource(76))
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		for _, n := range []int{1, 2, 10, 50} {
+			vals := make([]*big.Float, n)
+			shifted := make([]*big.Float, n)
+			for i := range vals {
+				// the shift by 1000 is exact with 24 bits
+				vals[i] = big.NewFloat(float64(rnd.Intn(1<<12))/64 - 32).SetPrec(prec)
+				shifted[i] = new(big.Float).SetPrec(prec).Add(vals[i], big.NewFloat(1000))
+			}
+
+			res := bigfloat.Softmax(vals)
+
+			// the results sum to 1, within an ulp per element
+			ulps := new(big.Float).SetMantExp(big.NewFloat(float64(n)), -int(prec))
+			d := new(big.Float).SetPrec(2*prec).Sub(bigfloat.Sum(res), big.NewFloat(1))
+			if d.Abs(d).Cmp(ulps) > 0 {
+				t.Errorf("prec = %d, n = %d: the results sum to 1 + %g", prec, n, d)
+			}
+			for i, x := range res {
+				if x.Prec() != prec || x.Sign() <= 0 {
+					t.Errorf("prec = %d, n = %d: result %d is %g (prec = %d)", prec, n, i, x, x.Prec())
+				}
+			}
+
+			// adding a constant to the arguments doesn't change the
+			// results
+			for i, x := range bigfloat.Softmax(shifted) {
+				if x.Cmp(res[i]) != 0 {
+					t.Errorf("prec = %d, n = %d: result %d is %g after the shift; want %g", prec, n, i, x, res[i])
+				}
+			}
+		}
+	}
+}
+
+// Softmax(a, b) = (Sigmoid(a-b), Sigmoid(b-a)), even when eᵃ and eᵇ
+// are huge.
+func TestSoftmaxSigmoid(t *testing.T) {
+	for _, test := range []struct {
+		a, b float64
+	}{
+		{1, 2},
+		{1e9, 1e9 - 3},
+		{-1e9, -1e9 + 0.5},
+	} {
+		a, b := big.NewFloat(test.a).SetPrec(100), big.NewFloat(test.b).SetPrec(100)
+		res := bigfloat.Softmax([]*big.Float{a, b})
+		for i, want := range []*big.Float{
+			bigfloat.Sigmoid(new(big.Float).Sub(a, b)),
+			bigfloat.Sigmoid(new(big.Float).Sub(b, a)),
+		} {
+			d := new(big.Float).Sub(res[i], want)
+			if d.Sign() != 0 && d.MantExp(nil) > want.MantExp(nil)-99 {
+				t.Errorf("Softmax(%g, %g)[%d] =\ngot  %g;\nwant %g", test.a, test.b, i, res[i], want)
+			}
+		}
+	}
+}
+
+func TestSoftmaxSpecialValues(t *testing.T) {
+	if res := bigfloat.Softmax(nil); res == nil || len(res) != 0 {
+		t.Errorf("Softmax(nil) = %v; want an empty slice", res)
+	}
+
+	inf, ninf := math.Inf(+1), math.Inf(-1)
+	for _, test := range []struct {
+		vals []float64
+		want []float64
+	}{
+		{[]float64{1, inf, 2, inf}, []float64{0, 0.5, 0, 0.5}},
+		{[]float64{ninf, 0, ninf}, []float64{0, 1, 0}},
+		{[]float64{ninf, 3, 3}, []float64{0, 0.5, 0.5}},
+	} {
+		var vals []*big.Float
+		for _, f := range test.vals {
+			vals = append(vals, big.NewFloat(f))
+		}
+		for i, x := range bigfloat.Softmax(vals) {
+			if x.Cmp(big.NewFloat(test.want[i])) != 0 {
+				t.Errorf("Softmax(%v)[%d] = %g; want %g", test.vals, i, x, test.want[i])
+			}
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Softmax(-Inf, -Inf) did not panic")
+		}
+	}()
+	bigfloat.Softmax([]*big.Float{big.NewFloat(ninf), big.NewFloat(ninf)})
+}
+
 // ---------- Benchmarks ----------
 
 func BenchmarkExp(b *testing.B) {
@@ -118,3 +525,15 @@ func BenchmarkExp(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkExp2(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		z := big.NewFloat(2.5).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Exp2(z)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package bigfloat
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// Float wraps a *big.Float and provides methods that store the
+// result of the package operations in the receiver, following the
+// z.Op(x) convention of the math/big package.
+//
+// Reusing the same Float across many calls avoids allocating a new
+// big.Float (and a new mantissa) for every result. The intermediate
+// values of the computations are still allocated on every call.
+//
+// A Float with a nil *big.Float is ready to use: a new big.Float is
+// allocated the first time a result is stored in it.
+type Float struct {
+	*big.Float
+}
+
+// Sqrt sets z to the square root of x, rounded according to z's
+// precision and rounding mode, and returns z. If z's precision is 0,
+// it is changed to x's precision before the operation. Sqrt panics
+// if x is negative.
+func (z *Float) Sqrt(x *big.Float) *Float {
+
+	// panic on negative x
+	if x.Sign() == -1 {
+		panic("Sqrt: argument is negative")
+	}
+
+	if z.Float == nil {
+		z.Float = new(big.Float)
+	}
+
+	prec := z.Prec()
+	if prec == 0 {
+		prec = x.Prec()
+	}
+
+	// √±0 = ±0
+	if x.Sign() == 0 {
+		z.SetPrec(prec).Set(x)
+		return z
+	}
+
+	// √+Inf  = +Inf
+	if x.IsInf() {
+		z.SetPrec(prec).SetInf(false)
+		return z
+	}
+
+	// the root is written into z before sqrtRound checks it against
+	// x, so x can't be z's big.Float
+	if x == z.Float {
+		x = new(big.Float).Copy(x)
+	}
+
+	// sqrt computes the root with x's precision, so make sure x has
+	// at least prec bits. The root is rounded to nearest and then
+	// adjusted to z's rounding mode.
+	t := x
+	if x.Prec() < prec {
+		t = new(big.Float).SetPrec(prec).Set(x)
+	}
+	mode := z.Mode()
+	sqrt(z.SetMode(big.ToNearestEven), t).SetPrec(prec)
+	sqrtRound(z.Float, x, mode)
+
+	return z
+}
+
+// floatJSON is the JSON encoding of a Float. Value holds the shortest
+// decimal representation that identifies the value uniquely at Prec
+// bits, or "+Inf" or "-Inf" for the infinities.
+type floatJSON struct {
+	Value string `json:"value"`
+	Prec  uint   `json:"prec"`
+	Mode  string `json:"mode"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. The value is
+// encoded with its precision and rounding mode, as in
+//
+//	{"value":"1.4142135623730950488","prec":64,"mode":"ToNearestEven"}
+//
+// so that unmarshaling it reproduces the same big.Float. The accuracy
+// is not encoded. A Float with a nil *big.Float is encoded as null.
+func (z Float) MarshalJSON() ([]byte, error) {
+	if z.Float == nil {
+		return []byte("null"), nil
+	}
+
+	v := floatJSON{Prec: z.Prec(), Mode: z.Mode().String()}
+	switch {
+	case z.IsInf() && z.Sign() > 0:
+		v.Value = "+Inf"
+	case z.IsInf():
+		v.Value = "-Inf"
+	default:
+		v.Value = z.Text('g', -1)
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, decoding
+// the encoding produced by MarshalJSON. The value is parsed at the
+// stored precision, and the rounding mode is set to the stored one.
+// Unmarshaling null leaves z unchanged.
+func (z *Float) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var v floatJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	if v.Prec > big.MaxPrec {
+		return fmt.Errorf("bigfloat: precision %d is too large", v.Prec)
+	}
+
+	mode, ok := parseMode(v.Mode)
+	if !ok {
+		return fmt.Errorf("bigfloat: invalid rounding mode %q", v.Mode)
+	}
+
+	// Parse sets a precision of 64 when the receiver's is 0, so use at
+	// least 1 bit and fix the precision after parsing; a value with 0
+	// bits of precision is ±0 or ±Inf, and SetPrec(0) preserves both.
+	x := new(big.Float).SetPrec(v.Prec).SetMode(big.ToNearestEven)
+	if v.Prec == 0 {
+		x.SetPrec(1)
+	}
+	switch v.Value {
+	case "+Inf":
+		x.SetInf(false)
+	case "-Inf":
+		x.SetInf(true)
+	default:
+		if _, _, err := x.Parse(v.Value, 10); err != nil {
+			return fmt.Errorf("bigfloat: invalid value %q", v.Value)
+		}
+	}
+	x.SetPrec(v.Prec).SetMode(mode)
+
+	if z.Float == nil {
+		z.Float = new(big.Float)
+	}
+	z.Float.Copy(x)
+
+	return nil
+}
+
+// parseMode returns the rounding mode whose String method returns s.
+func parseMode(s string) (big.RoundingMode, bool) {
+	for m := big.ToNearestEven; m <= big.ToPositiveInf; m++ {
+		if m.String() == s {
+			return m, true
+		}
+	}
+	return 0, false
+}
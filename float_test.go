@@ -0,0 +1,181 @@
+package bigfloat_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestFloatSqrt(t *testing.T) {
+	var z bigfloat.Float
+	for i := 0; i < 1e3; i++ {
+		x := big.NewFloat(rand.Float64() * 1e10)
+		for _, prec := range []uint{24, 53, 64, 100, 200, 500, 1000} {
+			x.SetPrec(prec)
+			z.Float = nil // take the precision from x
+
+			z.Sqrt(x)
+			want := bigfloat.Sqrt(x)
+
+			if z.Cmp(want) != 0 || z.Prec() != prec {
+				t.Errorf("prec = %d, Float.Sqrt(%g) =\ngot  %g (prec = %d);\nwant %g", prec, x, z.Float, z.Prec(), want)
+			}
+		}
+	}
+}
+
+func TestFloatSqrtReceiverPrec(t *testing.T) {
+	x := big.NewFloat(2).SetPrec(1000)
+	for _, prec := range []uint{24, 53, 64, 100, 200, 500, 1000, 2000} {
+		z := bigfloat.Float{Float: new(big.Float).SetPrec(prec)}
+		z.Sqrt(x)
+
+		want := new(big.Float).SetPrec(prec).Set(x)
+		want = bigfloat.Sqrt(want)
+
+		if z.Cmp(want) != 0 || z.Prec() != prec {
+			t.Errorf("prec = %d, Float.Sqrt(2) =\ngot  %g (prec = %d);\nwant %g", prec, z.Float, z.Prec(), want)
+		}
+	}
+}
+
+func TestFloatSqrtReceiverMode(t *testing.T) {
+	two := big.NewFloat(2).SetPrec(200)
+	for _, prec := range []uint{24, 53, 64, 100, 200} {
+		lo := bigfloat.Float{Float: new(big.Float).SetPrec(prec).SetMode(big.ToZero)}
+		hi := bigfloat.Float{Float: new(big.Float).SetPrec(prec).SetMode(big.AwayFromZero)}
+		lo.Sqrt(two)
+		hi.Sqrt(two)
+
+		if lo.Mode() != big.ToZero || hi.Mode() != big.AwayFromZero {
+			t.Errorf("prec = %d, Float.Sqrt changed the receiver's rounding mode", prec)
+		}
+
+		// √2 is irrational, so the two results must be different, and
+		// their squares must lie on opposite sides of 2.
+		sqLo := new(big.Float).SetPrec(2*prec).Mul(lo.Float, lo.Float)
+		sqHi := new(big.Float).SetPrec(2*prec).Mul(hi.Float, hi.Float)
+		if lo.Cmp(hi.Float) >= 0 || sqLo.Cmp(two) >= 0 || sqHi.Cmp(two) <= 0 {
+			t.Errorf("prec = %d, Float.Sqrt(2) =\nToZero       %g;\nAwayFromZero %g", prec, lo.Float, hi.Float)
+		}
+	}
+}
+
+// z.Sqrt(z.Float) must give the same result as with a separate
+// argument.
+func TestFloatSqrtAliasing(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 500, 1000} {
+		for _, mode := range []big.RoundingMode{big.ToNearestEven, big.ToZero, big.AwayFromZero, big.ToNegativeInf, big.ToPositiveInf} {
+			for _, f := range []float64{2, 3, 0.1, 1e100} {
+				z := bigfloat.Float{Float: big.NewFloat(f).SetPrec(prec).SetMode(mode)}
+				want := bigfloat.SqrtPrec(z.Float, prec, mode)
+				if z.Sqrt(z.Float); z.Cmp(want) != 0 || z.Prec() != prec || z.Mode() != mode {
+					t.Errorf("prec = %d, mode = %s, z = %g, z.Sqrt(z) =\ngot  %g;\nwant %g", prec, mode, f, z.Float, want)
+				}
+			}
+		}
+	}
+}
+
+func TestFloatJSON(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		for _, mode := range []big.RoundingMode{big.ToNearestEven, big.ToZero, big.ToPositiveInf} {
+			x := bigfloat.Sqrt(big.NewFloat(2).SetPrec(prec)).SetMode(mode)
+
+			data, err := json.Marshal(bigfloat.Float{Float: x})
+			if err != nil {
+				t.Fatalf("prec = %d, Marshal(√2) returned error %v", prec, err)
+			}
+
+			var z bigfloat.Float
+			if err := json.Unmarshal(data, &z); err != nil {
+				t.Fatalf("prec = %d, Unmarshal(%s) returned error %v", prec, data, err)
+			}
+
+			if z.Cmp(x) != 0 || z.Prec() != prec || z.Mode() != mode {
+				t.Errorf("prec = %d, Unmarshal(Marshal(√2)) =\ngot  %g (prec = %d, %s);\nwant %g (prec = %d, %s)", prec, z.Float, z.Prec(), z.Mode(), x, prec, mode)
+			}
+		}
+	}
+}
+
+func TestFloatJSONSpecialValues(t *testing.T) {
+	for _, f := range []float64{
+		+0.0,
+		-0.0,
+		math.Inf(+1),
+		math.Inf(-1),
+	} {
+		x := big.NewFloat(f).SetPrec(100)
+		data, err := json.Marshal(bigfloat.Float{Float: x})
+		if err != nil {
+			t.Fatalf("Marshal(%g) returned error %v", f, err)
+		}
+
+		var z bigfloat.Float
+		if err := json.Unmarshal(data, &z); err != nil {
+			t.Fatalf("Unmarshal(%s) returned error %v", data, err)
+		}
+		if x64, _ := z.Float64(); x64 != f || math.Signbit(x64) != math.Signbit(f) || z.Prec() != 100 {
+			t.Errorf("Unmarshal(Marshal(%g)) = %g (prec = %d); want %g (prec = 100)", f, x64, z.Prec(), f)
+		}
+	}
+}
+
+func TestFloatJSONInStruct(t *testing.T) {
+	type point struct {
+		X, Y bigfloat.Float
+		Z    *bigfloat.Float
+	}
+	p := point{
+		X: bigfloat.Float{Float: bigfloat.Pi(300)},
+		Y: bigfloat.Float{Float: big.NewFloat(math.Inf(+1))},
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal returned error %v", err)
+	}
+
+	var q point
+	if err := json.Unmarshal(data, &q); err != nil {
+		t.Fatalf("Unmarshal(%s) returned error %v", data, err)
+	}
+	if q.X.Cmp(p.X.Float) != 0 || q.X.Prec() != 300 || !q.Y.IsInf() || q.Z != nil {
+		t.Errorf("Unmarshal(%s) = %+v", data, q)
+	}
+}
+
+func TestFloatJSONErrors(t *testing.T) {
+	for _, s := range []string{
+		`{"value":"foo","prec":53,"mode":"ToNearestEven"}`,
+		`{"value":"1.5","prec":53,"mode":"ToNearest"}`,
+		`{"value":"1.5","prec":4294967296,"mode":"ToNearestEven"}`,
+		`"1.5"`,
+	} {
+		var z bigfloat.Float
+		if err := json.Unmarshal([]byte(s), &z); err == nil {
+			t.Errorf("Unmarshal(%s) = %g; want error", s, z.Float)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkFloatSqrt(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4, 1e5} {
+		x := big.NewFloat(2).SetPrec(prec)
+		z := bigfloat.Float{Float: new(big.Float).SetPrec(prec)}
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				z.Sqrt(x)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package bigfloat
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// FormatFixed returns z formatted with exactly places digits after the
+// decimal point, like z.Text('f', places), but rounding half away from
+// zero as RoundDecimal does. The digits are computed exactly from z,
+// so the result is correctly rounded. Unlike Text, FormatFixed never
+// returns a negative zero: -0, and negative values that round to 0, are
+// formatted as "0.00...". ±Inf are formatted as "Inf" and "-Inf". The
+// function panics if places is negative.
+func FormatFixed(z *big.Float, places int) string {
+
+	if places < 0 {
+		panic("FormatFixed: places is negative")
+	}
+
+	if z.IsInf() {
+		if z.Sign() < 0 {
+			return "-Inf"
+		}
+		return "Inf"
+	}
+
+	digits := "0"
+	neg := false
+	if z.Sign() != 0 {
+		i, _ := roundScaled(z, places)
+		digits = i.String()
+		neg = z.Sign() < 0 && i.Sign() != 0
+	}
+
+	// pad with zeros so that there's at least one digit before the
+	// point
+	if len(digits) <= places {
+		digits = strings.Repeat("0", places-len(digits)+1) + digits
+	}
+
+	var b strings.Builder
+	if neg {
+		b.WriteByte('-')
+	}
+	b.WriteString(digits[:len(digits)-places])
+	if places > 0 {
+		b.WriteByte('.')
+		b.WriteString(digits[len(digits)-places:])
+	}
+	return b.String()
+}
+
+// Parse returns the value of the decimal number s, rounded to prec
+// bits, ready to be passed to the functions of the package. s can use
+// scientific notation, as in "1.5e10", it can be "Inf" or "-Inf", and
+// leading and trailing white space is ignored. If prec is 0, 64 bits
+// are used, as in big.Float.Parse. If s is not a valid number, Parse
+// returns nil and an error saying so.
+func Parse(s string, prec uint) (*big.Float, error) {
+	t := strings.TrimSpace(s)
+	if prec == 0 {
+		prec = 64
+	}
+
+	x, _, err := new(big.Float).SetPrec(prec).Parse(t, 10)
+	if err != nil {
+		return nil, fmt.Errorf("bigfloat: invalid number %q", s)
+	}
+	return x, nil
+}
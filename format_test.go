@@ -0,0 +1,128 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestFormatFixed(t *testing.T) {
+	for _, test := range []struct {
+		z      string
+		places int
+		want   string
+	}{
+		{"2.675", 2, "2.67"}, // the binary 2.675 is below the tie
+		{"2.625", 2, "2.63"}, // exact tie, away from zero
+		{"-2.625", 2, "-2.63"},
+		{"2.5", 0, "3"},
+		{"1.5", 3, "1.500"},
+		{"0.001", 5, "0.00100"},
+		{"0.0004", 3, "0.000"},
+		{"-0.0004", 3, "0.000"},
+		{"-0.0005", 3, "-0.001"},
+		{"0.9999", 3, "1.000"},
+		{"-9.9996", 3, "-10.000"},
+		{"12345.678", 1, "12345.7"},
+		{"1e20", 2, "100000000000000000000.00"},
+		{"3.14159265358979323846264338327950288419716939937510582097494459", 30, "3.141592653589793238462643383280"},
+	} {
+		z := new(big.Float).SetPrec(300)
+		z.Parse(test.z, 10)
+		if s := bigfloat.FormatFixed(z, test.places); s != test.want {
+			t.Errorf("FormatFixed(%s, %d) = %q; want %q", test.z, test.places, s, test.want)
+		}
+	}
+}
+
+// FormatFixed agrees with Text('f', places) except on exact ties.
+func TestFormatFixedText(t *testing.T) {
+	for _, f := range []float64{0.1, 1.0 / 3, math.Pi, -math.E, 1e-10, 123456789.987654321, -1e15} {
+		z := big.NewFloat(f)
+		for _, places := range []int{0, 1, 2, 5, 10, 20} {
+			if s, want := bigfloat.FormatFixed(z, places), z.Text('f', places); s != want {
+				t.Errorf("FormatFixed(%g, %d) = %q; want %q", f, places, s, want)
+			}
+		}
+	}
+}
+
+func TestFormatFixedSpecialValues(t *testing.T) {
+	for _, test := range []struct {
+		z      float64
+		places int
+		want   string
+	}{
+		{+0.0, 2, "0.00"},
+		{math.Copysign(0, -1), 2, "0.00"},
+		{math.Copysign(0, -1), 0, "0"},
+		{math.Inf(+1), 2, "Inf"},
+		{math.Inf(-1), 2, "-Inf"},
+	} {
+		if s := bigfloat.FormatFixed(big.NewFloat(test.z), test.places); s != test.want {
+			t.Errorf("FormatFixed(%g, %d) = %q; want %q", test.z, test.places, s, test.want)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("FormatFixed(1, -1) did not panic")
+		}
+	}()
+	bigfloat.FormatFixed(big.NewFloat(1), -1)
+}
+
+func TestParse(t *testing.T) {
+	for _, test := range []struct {
+		s    string
+		prec uint
+		want string
+	}{
+		{"1.5e10", 53, "15000000000"},
+		{"  2.25  ", 53, "2.25"},
+		{"\t-0.125\n", 24, "-0.125"},
+		{"1E-3", 100, "0.001"},
+		{"Inf", 53, "+Inf"},
+		{" -Inf", 53, "-Inf"},
+		{"3.14159265358979323846264338327950288419716939937510582097494459", 200, "3.14159265358979323846264338327950288419716939937510582097494459"},
+		{"0.1", 0, "0.1"},
+	} {
+		x, err := bigfloat.Parse(test.s, test.prec)
+		if err != nil {
+			t.Errorf("Parse(%q, %d) returned error %v", test.s, test.prec, err)
+			continue
+		}
+
+		prec := test.prec
+		if prec == 0 {
+			prec = 64
+		}
+		want := new(big.Float).SetPrec(prec)
+		want.Parse(test.want, 10)
+		if x.Cmp(want) != 0 || x.Prec() != prec {
+			t.Errorf("Parse(%q, %d) = %g (prec = %d); want %g (prec = %d)", test.s, test.prec, x, x.Prec(), want, prec)
+		}
+	}
+
+	// the result can be passed to Sqrt directly
+	x, _ := bigfloat.Parse(" 2.25 ", 100)
+	if r := bigfloat.Sqrt(x); r.Cmp(big.NewFloat(1.5)) != 0 || r.Prec() != 100 {
+		t.Errorf("Sqrt(Parse(2.25)) = %g (prec = %d); want 1.5 (prec = 100)", r, r.Prec())
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, s := range []string{"", "   ", "abc", "1.5.2", "1e", "0x10", "1,5", "2.25 3"} {
+		x, err := bigfloat.Parse(s, 53)
+		if err == nil || x != nil {
+			t.Errorf("Parse(%q) = %g, %v; want an error", s, x, err)
+			continue
+		}
+		if want := fmt.Sprintf("bigfloat: invalid number %q", s); err.Error() != want {
+			t.Errorf("Parse(%q) returned error %q; want %q", s, err, want)
+		}
+	}
+}
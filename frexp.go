@@ -0,0 +1,26 @@
+package bigfloat
+
+import "math/big"
+
+// Frexp breaks z into a fraction and an integral power of two, and
+// returns frac and exp satisfying
+//
+//	z = frac × 2**exp
+//
+// with 0.5 <= |frac| < 1 and frac of the same sign as z. frac has the
+// same precision and rounding mode as z, and z is not modified. As in
+// math.Frexp, Frexp(±0) = ±0, 0 and Frexp(±Inf) = ±Inf, 0.
+func Frexp(z *big.Float) (frac *big.Float, exp int) {
+	frac = new(big.Float)
+	exp = z.MantExp(frac)
+	return frac, exp
+}
+
+// Ldexp is the inverse of Frexp, and returns a big.Float
+// representation of frac × 2**exp. Precision and rounding mode are
+// the same as the ones of frac, and the result is exact unless it's
+// outside of the big.Float exponent range, in which case it's ±Inf or
+// ±0. Ldexp(±0, exp) = ±0 and Ldexp(±Inf, exp) = ±Inf.
+func Ldexp(frac *big.Float, exp int) *big.Float {
+	return new(big.Float).SetMantExp(frac, exp)
+}
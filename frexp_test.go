@@ -0,0 +1,76 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestFrexpFloat64(t *testing.T) {
+	for i := 0; i < 1e4; i++ {
+		f := rand.NormFloat64() * math.Pow(2, float64(rand.Intn(2000)-1000))
+		wantFrac, wantExp := math.Frexp(f)
+
+		frac, exp := bigfloat.Frexp(big.NewFloat(f))
+		if frac64, _ := frac.Float64(); frac64 != wantFrac || exp != wantExp {
+			t.Errorf("Frexp(%g) = %g, %d; want %g, %d", f, frac64, exp, wantFrac, wantExp)
+		}
+
+		if x64, _ := bigfloat.Ldexp(big.NewFloat(wantFrac), wantExp).Float64(); x64 != f {
+			t.Errorf("Ldexp(%g, %d) = %g; want %g", wantFrac, wantExp, x64, f)
+		}
+	}
+}
+
+func TestFrexpRoundTrip(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		for _, e := range []int{0, 1, -1, 100, -100, 1 << 20, -(1 << 20), big.MaxExp - 10, big.MinExp + 10} {
+			z := bigfloat.Sqrt(big.NewFloat(3).SetPrec(prec))
+			z.Neg(z)
+			z.SetMantExp(z, e)
+			orig := new(big.Float).Copy(z)
+
+			frac, exp := bigfloat.Frexp(z)
+			a := new(big.Float).Abs(frac)
+			if a.Cmp(big.NewFloat(0.5)) < 0 || a.Cmp(big.NewFloat(1)) >= 0 || frac.Sign() != z.Sign() || frac.Prec() != prec {
+				t.Errorf("prec = %d, Frexp(z) = %g (prec = %d), %d; want 0.5 <= |frac| < 1", prec, frac, frac.Prec(), exp)
+			}
+
+			x := bigfloat.Ldexp(frac, exp)
+			if x.Cmp(z) != 0 || x.Prec() != prec || z.Cmp(orig) != 0 {
+				t.Errorf("prec = %d, Ldexp(Frexp(z)) =\ngot  %g;\nwant %g", prec, x, z)
+			}
+		}
+	}
+}
+
+func TestFrexpSpecialValues(t *testing.T) {
+	for _, f := range []float64{
+		+0.0,
+		math.Copysign(0, -1),
+		math.Inf(+1),
+		math.Inf(-1),
+	} {
+		frac, exp := bigfloat.Frexp(big.NewFloat(f))
+		frac64, _ := frac.Float64()
+		if frac64 != f || math.Signbit(frac64) != math.Signbit(f) || exp != 0 {
+			t.Errorf("Frexp(%g) = %g, %d; want %g, 0", f, frac64, exp, f)
+		}
+
+		x64, _ := bigfloat.Ldexp(big.NewFloat(f), 10).Float64()
+		if x64 != f || math.Signbit(x64) != math.Signbit(f) {
+			t.Errorf("Ldexp(%g, 10) = %g; want %g", f, x64, f)
+		}
+	}
+
+	// out of the exponent range
+	if x := bigfloat.Ldexp(big.NewFloat(0.5), big.MaxExp+1); !x.IsInf() || x.Sign() < 0 {
+		t.Errorf("Ldexp(0.5, MaxExp+1) = %g; want +Inf", x)
+	}
+	if x := bigfloat.Ldexp(big.NewFloat(-0.5), big.MinExp-1); x.Sign() != 0 || !x.Signbit() {
+		t.Errorf("Ldexp(-0.5, MinExp-1) = %g; want -0", x)
+	}
+}
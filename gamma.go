@@ -0,0 +1,735 @@
+package bigfloat
+
+import (
+	"math/big"
+	"sync"
+)
+
+// gammaIntMax is the largest integer argument for which Gamma and
+// Factorial compute the result directly as a product of integers.
+const gammaIntMax = 1 << 12
+
+// Gamma returns a big.Float representation of the Gamma function of
+// z. Precision is the same as the one of the argument. The function
+// panics if z is a negative integer or -Inf, returns ±Inf when z =
+// ±0, and +Inf when z = +Inf.
+func Gamma(z *big.Float) *big.Float {
+
+	// Gamma(+Inf) = +Inf, panic on -Inf
+	if z.IsInf() {
+		if z.Sign() < 0 {
+			panic("Gamma: argument is -Inf")
+		}
+		return new(big.Float).SetPrec(z.Prec()).SetInf(false)
+	}
+
+	// Gamma(±0) = ±Inf
+	if z.Sign() == 0 {
+		return new(big.Float).SetPrec(z.Prec()).SetInf(z.Signbit())
+	}
+
+	// panic on the poles
+	if z.IsInt() && z.Sign() < 0 {
+		panic("Gamma: argument is a negative integer")
+	}
+
+	// Gamma(n) = (n-1)! for small positive integers
+	if z.IsInt() && z.Cmp(big.NewFloat(gammaIntMax)) <= 0 {
+		n, _ := z.Uint64()
+		return Factorial(uint(n-1), z.Prec())
+	}
+
+	prec := z.Prec() + 64 // guard digits
+
+	// For z < 0.5 use the reflection formula
+	//   Γ(z) = π / (sin(πz)·Γ(1-z))
+	if z.Cmp(big.NewFloat(0.5)) < 0 {
+		zw := WithGuard(z, 64)
+		w := new(big.Float).SetPrec(prec).Sub(big.NewFloat(1), zw)
+		x := gamma(w, prec)
+		x.Mul(x, sinPi(zw))
+		return x.Quo(pi(prec), x).SetPrec(z.Prec())
+	}
+
+	x := gamma(WithGuard(z, 64), prec)
+	return x.SetPrec(z.Prec())
+}
+
+// Factorial returns a big.Float representation of n!, rounded to prec
+// bits. The product is computed exactly, so the result is exact as
+// long as n! fits in prec bits; once it doesn't, it's correctly
+// rounded to nearest even. For n > 4096 the result is computed as
+// Γ(n+1) instead, and the last bit may be off.
+func Factorial(n uint, prec uint) *big.Float {
+
+	if prec == 0 {
+		panic("Factorial: prec is 0")
+	}
+
+	if n > gammaIntMax {
+		// n+1 must be exact, so use at least 64 bits for z
+		zPrec := prec
+		if zPrec < 64 {
+			zPrec = 64
+		}
+		z := new(big.Float).SetPrec(zPrec).SetUint64(uint64(n) + 1)
+		return Gamma(z).SetPrec(prec)
+	}
+
+	f := new(big.Int).MulRange(1, int64(n))
+	return new(big.Float).SetPrec(prec).SetInt(f)
+}
+
+// Binomial returns a big.Float representation of the binomial
+// coefficient C(n, k) = n!/(k!(n-k)!), rounded to prec bits. It
+// returns 1 when k = 0 or k = n, and 0 when k > n. The coefficient is
+// computed exactly, as the products (n-k+1)···(n-k+i)/i! are integers,
+// so the result is correctly rounded to nearest even. For min(k, n-k)
+// > 4096 it's computed through Beta instead, and the last bit may be
+// off. The function panics if prec is 0.
+func Binomial(n, k uint, prec uint) *big.Float {
+
+	if prec == 0 {
+		panic("Binomial: prec is 0")
+	}
+
+	if k > n {
+		return new(big.Float).SetPrec(prec)
+	}
+
+	// C(n, k) = C(n, n-k)
+	if n-k < k {
+		k = n - k
+	}
+
+	if k > gammaIntMax {
+		// C(n, k) = 1/((n+1)·B(k+1, n-k+1)), where the arguments must
+		// be exact, so use at least 64 bits for them
+		zPrec := prec + 64
+		if zPrec < 128 {
+			zPrec = 128
+		}
+		a := new(big.Float).SetPrec(zPrec).SetUint64(uint64(k) + 1)
+		b := new(big.Float).SetPrec(zPrec).SetUint64(uint64(n-k) + 1)
+		x := Beta(a, b)
+		x.Mul(x, new(big.Float).SetUint64(uint64(n)+1))
+		return x.Quo(big.NewFloat(1), x).SetPrec(prec)
+	}
+
+	c := new(big.Int).Binomial(int64(n), int64(k))
+	return new(big.Float).SetPrec(prec).SetInt(c)
+}
+
+// BernoulliNumber returns a big.Float representation of the Bernoulli
+// number Bₙ, rounded to prec bits. B₁ is -1/2, as in the convention
+// where the Bernoulli numbers are the coefficients of x/(eˣ - 1), and
+// Bₙ is 0 for the other odd n. The numbers are computed exactly, as
+// the ones used by the asymptotic series of LogGamma and Digamma, and
+// cached, so the result is correctly rounded to nearest even. The
+// function panics if prec is 0.
+func BernoulliNumber(n uint, prec uint) *big.Float {
+
+	if prec == 0 {
+		panic("BernoulliNumber: prec is 0")
+	}
+
+	switch {
+	case n == 0:
+		return big.NewFloat(1).SetPrec(prec)
+	case n == 1:
+		return big.NewFloat(-0.5).SetPrec(prec)
+	case n%2 == 1:
+		return new(big.Float).SetPrec(prec)
+	}
+
+	return new(big.Float).SetPrec(prec).SetRat(bernoulli(int(n / 2)))
+}
+
+// LogGamma returns a big.Float representation of the natural
+// logarithm of the absolute value of the Gamma function of z. Unlike
+// Gamma(z), which overflows for large z, the result is finite. Precision
+// is the same as the one of the argument. The function panics if z =
+// -Inf, returns +Inf when z = ±0, when z is a negative integer, and when
+// z = +Inf, and returns 0 when z = 1 or z = 2.
+func LogGamma(z *big.Float) *big.Float {
+
+	// LogGamma(+Inf) = +Inf, panic on -Inf
+	if z.IsInf() {
+		if z.Sign() < 0 {
+			panic("LogGamma: argument is -Inf")
+		}
+		return new(big.Float).SetPrec(z.Prec()).SetInf(false)
+	}
+
+	// LogGamma(±0) = +Inf, and the same on the poles
+	if z.Sign() == 0 || (z.IsInt() && z.Sign() < 0) {
+		return new(big.Float).SetPrec(z.Prec()).SetInf(false)
+	}
+
+	// LogGamma(1) = LogGamma(2) = 0
+	if z.Cmp(big.NewFloat(1)) == 0 || z.Cmp(big.NewFloat(2)) == 0 {
+		return new(big.Float).SetPrec(z.Prec())
+	}
+
+	prec := z.Prec() + 64 // guard digits
+
+	// log|Γ(z)| is computed as a difference of larger terms, so close
+	// to its zeros (1, 2, and one between each pair of consecutive
+	// poles) it loses -log2|log|Γ(z)|| bits. Recompute it with as many
+	// more guard digits.
+	x := logGamma(z, prec)
+	if exp := x.MantExp(nil); exp < 0 {
+		x = logGamma(z, prec+uint(-exp))
+	}
+
+	return x.SetPrec(z.Prec())
+}
+
+// logGamma returns log|Γ(z)| computed with prec bits of precision, for
+// z that is not a pole.
+func logGamma(z *big.Float, prec uint) *big.Float {
+	zw := new(big.Float).SetPrec(prec).Set(z)
+
+	// For z < 0.5 use the reflection formula
+	//   log|Γ(z)| = log(π) - log|sin(πz)| - log Γ(1-z)
+	if z.Cmp(big.NewFloat(0.5)) < 0 {
+		w := new(big.Float).SetPrec(prec).Sub(big.NewFloat(1), zw)
+		s := sinPi(zw)
+		x := Log(pi(prec))
+		x.Sub(x, Log(s.Abs(s)))
+		return x.Sub(x, logGamma(w, prec))
+	}
+
+	// The result has about log2(z+n)+log2(log(z+n)) bits before the
+	// binary point, which are lost to the final difference, so add
+	// guard digits as in gamma.
+	m := big.NewFloat(float64(prec/2 + 10))
+	if zw.Cmp(m) > 0 {
+		m = zw
+	}
+	if exp := m.MantExp(nil); exp > 0 {
+		prec += 2 * uint(exp)
+	}
+
+	// log Γ(z) = log Γ(z+n) - log(z(z+1)...(z+n-1))
+	x, p := gammaShift(zw, prec)
+	lg := logGammaStirling(x)
+	return lg.Sub(lg, Log(p))
+}
+
+// Digamma returns a big.Float representation of the digamma function
+// of z, ψ(z) = Γ'(z)/Γ(z), the derivative of LogGamma. Precision is the
+// same as the one of the argument. The function panics if z is zero,
+// a negative integer or -Inf, and returns +Inf when z = +Inf.
+func Digamma(z *big.Float) *big.Float {
+
+	// Digamma(+Inf) = +Inf, panic on -Inf
+	if z.IsInf() {
+		if z.Sign() < 0 {
+			panic("Digamma: argument is -Inf")
+		}
+		return new(big.Float).SetPrec(z.Prec()).SetInf(false)
+	}
+
+	// panic on the poles
+	if z.Sign() == 0 || (z.IsInt() && z.Sign() < 0) {
+		panic("Digamma: argument is zero or a negative integer")
+	}
+
+	// Digamma(1) = -γ
+	if z.Cmp(big.NewFloat(1)) == 0 {
+		x := EulerGamma(z.Prec())
+		return x.Neg(x)
+	}
+
+	prec := z.Prec() + 64 // guard digits
+
+	// As for LogGamma, ψ(z) is computed as a difference of larger
+	// terms, so close to its zeros (one at 1.46..., and one between
+	// each pair of consecutive poles) it loses -log2|ψ(z)| bits.
+	// Recompute it with as many more guard digits.
+	x := digamma(z, prec)
+	if exp := x.MantExp(nil); exp < 0 {
+		x = digamma(z, prec+uint(-exp))
+	}
+
+	return x.SetPrec(z.Prec())
+}
+
+// digamma returns ψ(z) computed with prec bits of precision, for z
+// that is not a pole.
+func digamma(z *big.Float, prec uint) *big.Float {
+	zw := new(big.Float).SetPrec(prec).Set(z)
+
+	// For z < 0.5 use the reflection formula
+	//   ψ(z) = ψ(1-z) - π·cot(πz)
+	// cot has period π, so only the fractional part f of z is needed
+	// to compute cot(πz) = cot(πf).
+	if z.Cmp(big.NewFloat(0.5)) < 0 {
+		w := new(big.Float).SetPrec(prec).Sub(big.NewFloat(1), zw)
+		x := digamma(w, prec)
+
+		t := new(big.Float).SetPrec(prec).Add(zw, big.NewFloat(0.5))
+		if t.Sign() < 0 {
+			t.Sub(t, big.NewFloat(1))
+		}
+		k, _ := t.Int(nil)
+		f := new(big.Float).SetPrec(prec).SetInt(k)
+		f.Sub(zw, f)
+
+		p := pi(prec)
+		s, c := SinCos(f.Mul(f, p))
+		c.Mul(c, p)
+		return x.Sub(x, c.Quo(c, s))
+	}
+
+	// The asymptotic series converges to prec bits only if z is large
+	// enough, so shift z using
+	//   ψ(z) = ψ(z+n) - (1/z + 1/(z+1) + ... + 1/(z+n-1))
+	// with the same n as gammaShift.
+	lim := big.NewFloat(float64(prec/2 + 10))
+	sum := new(big.Float).SetPrec(prec)
+	t := new(big.Float).SetPrec(prec)
+	one := big.NewFloat(1)
+	for zw.Cmp(lim) < 0 {
+		sum.Add(sum, t.Quo(one, zw))
+		zw.Add(zw, one)
+	}
+
+	x := digammaAsymptotic(zw)
+	return x.Sub(x, sum)
+}
+
+// digammaAsymptotic returns ψ(x), computed with x's precision using
+// the asymptotic series
+//
+//	ψ(x) = log(x) - 1/2x - Σ B₂ₖ/(2k·x²ᵏ)
+//
+// x must be large enough for the series to reach x's precision
+// before its terms start growing; x >= prec/2 is enough.
+func digammaAsymptotic(x *big.Float) *big.Float {
+	prec := x.Prec()
+
+	// log(x) - 1/2x
+	sum := Log(x)
+	t := new(big.Float).SetPrec(prec).Quo(big.NewFloat(0.5), x)
+	sum.Sub(sum, t)
+
+	// Σ B₂ₖ/(2k·x²ᵏ)
+	x2 := new(big.Float).SetPrec(prec).Mul(x, x)
+	xp := new(big.Float).SetPrec(prec).Set(x2) // x²ᵏ
+	term := new(big.Float).SetPrec(prec)
+	for k := 1; ; k++ {
+		term.SetRat(bernoulli(k))
+		term.Quo(term, new(big.Float).SetInt64(int64(2*k)))
+		term.Quo(term, xp)
+		if term.MantExp(nil) < sum.MantExp(nil)-int(prec) {
+			break
+		}
+		sum.Sub(sum, term)
+		xp.Mul(xp, x2)
+	}
+
+	return sum
+}
+
+// Beta returns a big.Float representation of the Beta function
+//
+//	B(a, b) = Γ(a)Γ(b)/Γ(a+b)
+//
+// computed through LogGamma, so that it doesn't overflow when the
+// Gamma functions do. Precision is the larger of the precisions of the
+// arguments. The function panics if a or b is infinite, zero or a
+// negative integer, and returns 0 when a+b is a negative integer.
+func Beta(a, b *big.Float) *big.Float {
+
+	// panic on the poles
+	for _, z := range []*big.Float{a, b} {
+		if z.IsInf() || z.Sign() == 0 || (z.IsInt() && z.Sign() < 0) {
+			panic("Beta: argument is a pole of Gamma")
+		}
+	}
+
+	zPrec := a.Prec()
+	if b.Prec() > zPrec {
+		zPrec = b.Prec()
+	}
+	prec := zPrec + 64 // guard digits
+
+	// Rounding a+b moves log Γ(a+b) by about (a+b)·log(a+b) ulps, so
+	// add as many more guard digits.
+	ab := new(big.Float).Add(a, b)
+	if exp := ab.MantExp(nil); exp > 0 {
+		prec += 2 * uint(exp)
+	}
+
+	// Compute a+b exactly, so that the check for the poles below
+	// is accurate. If the exponents are more than prec apart, a+b
+	// can't be close to an integer unless the larger of the two is
+	// one, and that's been excluded above.
+	d := a.MantExp(nil) - b.MantExp(nil)
+	if d < 0 {
+		d = -d
+	}
+	if d > int(prec) {
+		d = int(prec)
+	}
+	ab.SetPrec(prec+uint(d)).Add(a, b)
+
+	// B(a, b) = 0 when a+b is a pole
+	if ab.IsInt() && ab.Sign() <= 0 {
+		return new(big.Float).SetPrec(zPrec)
+	}
+
+	// The log has about log2|log B(a, b)| bits before the binary point,
+	// which are lost when taking the exponential, so if there are any
+	// recompute it with as many more guard digits.
+	l := logBeta(a, b, ab, prec)
+	if exp := l.MantExp(nil); exp > 0 {
+		l = logBeta(a, b, ab, prec+uint(exp))
+	}
+
+	x := Exp(l)
+	if gammaSign(a)*gammaSign(b)*gammaSign(ab) < 0 {
+		x.Neg(x)
+	}
+
+	return x.SetPrec(zPrec)
+}
+
+// GammaP returns a big.Float representation of the regularized lower
+// incomplete gamma function
+//
+//	P(a, x) = 1/Γ(a)·∫[0, x] tᵃ⁻¹·e⁻ᵗ dt
+//
+// Precision is the larger of the precisions of the arguments. The
+// function panics if a is not positive or it's +Inf, and if x is
+// negative. It returns 0 when x = 0, and 1 when x = +Inf.
+func GammaP(a, x *big.Float) *big.Float {
+	p, _ := gammaInc("GammaP", a, x)
+	return p
+}
+
+// GammaQ returns a big.Float representation of the regularized upper
+// incomplete gamma function Q(a, x) = 1 - P(a, x). Unlike 1 - GammaP,
+// the result is accurate when it's small. Precision is the larger of
+// the precisions of the arguments. The function panics if a is not
+// positive or it's +Inf, and if x is negative. It returns 1 when x =
+// 0, and 0 when x = +Inf.
+func GammaQ(a, x *big.Float) *big.Float {
+	_, q := gammaInc("GammaQ", a, x)
+	return q
+}
+
+// gammaInc returns P(a, x) and Q(a, x), using name in the panic
+// messages.
+func gammaInc(name string, a, x *big.Float) (*big.Float, *big.Float) {
+
+	if a.Sign() <= 0 || a.IsInf() {
+		panic(name + ": a is not positive and finite")
+	}
+	if x.Sign() < 0 {
+		panic(name + ": x is negative")
+	}
+
+	zPrec := a.Prec()
+	if x.Prec() > zPrec {
+		zPrec = x.Prec()
+	}
+
+	// P(a, 0) = 0, P(a, +Inf) = 1
+	if x.Sign() == 0 || x.IsInf() {
+		p := new(big.Float).SetPrec(zPrec)
+		q := big.NewFloat(1).SetPrec(zPrec)
+		if x.IsInf() {
+			p, q = q, p
+		}
+		return p, q
+	}
+
+	prec := zPrec + 64 // guard digits
+
+	// The normalization factor e⁻ˣ·xᵃ/Γ(a) is the exponential of a
+	// difference of terms of magnitude about a·log(x) and a·log(a),
+	// and their bits before the binary point are lost, so add as many
+	// more guard digits.
+	exp := a.MantExp(nil)
+	if e := x.MantExp(nil); e > exp {
+		exp = e
+	}
+	if exp > 0 {
+		prec += 2 * uint(exp)
+	}
+
+	// In the series for P, Q = 1 - P loses the leading bits of P when
+	// P is close to 1, which happens for small a, so compute it again
+	// with as many more guard digits. If more bits than the guard
+	// digits were lost, the exponent of Q is only the one of the
+	// rounding errors, so repeat until the guard digits are enough.
+	p, q, series := gammaIncPrec(a, x, prec)
+	for extra := uint(0); series; {
+		e := -int(prec + extra) // all the bits were lost
+		if q.Sign() != 0 {
+			e = q.MantExp(nil)
+		}
+		if e >= 0 || uint(-e) <= extra {
+			break
+		}
+		extra = uint(-e)
+		p, q, _ = gammaIncPrec(a, x, prec+extra)
+	}
+
+	return p.SetPrec(zPrec), q.SetPrec(zPrec)
+}
+
+// gammaIncPrec returns P(a, x) and Q(a, x) for positive and finite a
+// and x, computed with prec bits of precision, and whether P was
+// computed with its series and Q as 1 - P.
+func gammaIncPrec(a, x *big.Float, prec uint) (*big.Float, *big.Float, bool) {
+
+	aw := new(big.Float).SetPrec(prec).Set(a)
+	xw := new(big.Float).SetPrec(prec).Set(x)
+
+	// f = e⁻ˣ·xᵃ/Γ(a)
+	f := Log(xw)
+	f.Mul(f, aw)
+	f.Sub(f, xw)
+	f.Sub(f, LogGamma(aw))
+	f = Exp(f)
+
+	// Use the series for P when x < a+1, and the continued fraction
+	// for Q otherwise, and compute the other function by subtraction.
+	// Q is not close to 1 for x >= a+1, so P = 1 - Q doesn't cancel;
+	// Q = 1 - P does for small a, and the caller takes care of that.
+	one := big.NewFloat(1)
+	r := new(big.Float).SetPrec(prec)
+	if t := new(big.Float).Add(aw, one); xw.Cmp(t) < 0 {
+		// P(a, x) = f·Σ xⁿ/(a(a+1)...(a+n))
+		term := new(big.Float).SetPrec(prec).Quo(one, aw)
+		r.Set(term)
+		d := new(big.Float).SetPrec(prec).Set(aw)
+		for {
+			d.Add(d, one)
+			term.Mul(term, xw)
+			term.Quo(term, d)
+			r.Add(r, term)
+			if term.MantExp(nil) < r.MantExp(nil)-int(prec) {
+				break
+			}
+		}
+		r.Mul(r, f)
+		q := new(big.Float).SetPrec(prec).Sub(one, r)
+		return r, q, true
+	}
+
+	// Q(a, x) = f·1/(x+1-a - 1·(1-a)/(x+3-a - 2·(2-a)/(x+5-a - ...)))
+	cf := ContinuedFraction(
+		func(n int) *big.Float {
+			if n == 1 {
+				return big.NewFloat(1)
+			}
+			t := new(big.Float).SetPrec(prec).SetInt64(int64(n - 1))
+			t.Sub(t, aw)
+			return t.Mul(t, new(big.Float).SetInt64(int64(1-n)))
+		},
+		func(n int) *big.Float {
+			if n == 0 {
+				return new(big.Float)
+			}
+			t := new(big.Float).SetPrec(prec).SetInt64(int64(2*n - 1))
+			t.Add(t, xw)
+			return t.Sub(t, aw)
+		},
+		prec)
+	r.Mul(cf, f)
+	p := new(big.Float).SetPrec(prec).Sub(one, r)
+	return p, r, false
+}
+
+// logBeta returns log|Γ(a)| + log|Γ(b)| - log|Γ(ab)|, computed with
+// prec bits of precision.
+func logBeta(a, b, ab *big.Float, prec uint) *big.Float {
+	x := LogGamma(new(big.Float).SetPrec(prec).Set(a))
+	x.Add(x, LogGamma(new(big.Float).SetPrec(prec).Set(b)))
+	return x.Sub(x, LogGamma(new(big.Float).SetPrec(prec).Set(ab)))
+}
+
+// gammaSign returns the sign of Γ(z), for z that is not a pole. Γ(z)
+// is positive for z > 0, and for z < 0 it's negative between -1 and 0
+// and alternates sign between each pair of consecutive poles.
+func gammaSign(z *big.Float) int {
+	if z.Sign() > 0 {
+		return 1
+	}
+
+	// Γ(z) < 0 iff ⌊z⌋ is odd, and since z is not an integer ⌊z⌋ is
+	// one less than z truncated
+	k, _ := z.Int(nil)
+	if k.Bit(0) == 0 {
+		return -1
+	}
+	return 1
+}
+
+// gamma returns Γ(z) computed with prec bits of precision, for z >=
+// 0.5.
+func gamma(z *big.Float, prec uint) *big.Float {
+
+	// The Stirling series for log Γ(z) converges to prec bits only if
+	// z is large enough, so shift z using
+	//   Γ(z) = Γ(z+n) / (z(z+1)...(z+n-1))
+	// and compute Γ(z+n) instead.
+	//
+	// log Γ(z+n) has about 2·log2(z+n) bits before the binary point,
+	// and those are lost when taking the exponential, so add as many
+	// guard digits before shifting.
+	m := big.NewFloat(float64(prec/2 + 10))
+	if z.Cmp(m) > 0 {
+		m = z
+	}
+	if exp := m.MantExp(nil); exp > 0 {
+		prec += 2 * uint(exp)
+	}
+
+	x, p := gammaShift(z, prec)
+	lg := Exp(logGammaStirling(x))
+	return lg.Quo(lg, p)
+}
+
+// gammaShift returns x = z+n and p = z(z+1)...(z+n-1), computed with
+// prec bits of precision, with n the smallest non-negative integer
+// such that z+n > prec/2.
+func gammaShift(z *big.Float, prec uint) (*big.Float, *big.Float) {
+	lim := big.NewFloat(float64(prec/2 + 10))
+
+	x := new(big.Float).SetPrec(prec).Set(z)
+	p := big.NewFloat(1).SetPrec(prec)
+	one := big.NewFloat(1)
+	for x.Cmp(lim) < 0 {
+		p.Mul(p, x)
+		x.Add(x, one)
+	}
+
+	return x, p
+}
+
+// logGammaStirling returns log Γ(x), computed with x's precision
+// using the Stirling series
+//
+//	log Γ(x) = (x - 1/2)·log(x) - x + log(2π)/2 + Σ B₂ₖ/(2k(2k-1)x²ᵏ⁻¹)
+//
+// x must be large enough for the series to reach x's precision
+// before its terms start growing; x >= prec/2 is enough.
+func logGammaStirling(x *big.Float) *big.Float {
+	prec := x.Prec()
+
+	// (x - 1/2)·log(x) - x
+	sum := new(big.Float).SetPrec(prec).Sub(x, big.NewFloat(0.5))
+	sum.Mul(sum, Log(x))
+	sum.Sub(sum, x)
+
+	// log(2π)/2
+	t := pi(prec)
+	t.Mul(t, big.NewFloat(2))
+	t = Log(t)
+	sum.Add(sum, t.Mul(t, big.NewFloat(0.5)))
+
+	// Σ B₂ₖ/(2k(2k-1)x²ᵏ⁻¹)
+	x2 := new(big.Float).SetPrec(prec).Mul(x, x)
+	xp := new(big.Float).SetPrec(prec).Set(x) // x²ᵏ⁻¹
+	term := new(big.Float).SetPrec(prec)
+	for k := 1; ; k++ {
+		term.SetRat(bernoulli(k))
+		term.Quo(term, new(big.Float).SetInt64(int64(2*k*(2*k-1))))
+		term.Quo(term, xp)
+		if term.MantExp(nil) < sum.MantExp(nil)-int(prec) {
+			break
+		}
+		sum.Add(sum, term)
+		xp.Mul(xp, x2)
+	}
+
+	return sum
+}
+
+// sinPi returns sin(πz), computed with z's precision. Unlike Sin(π·z),
+// it's accurate even when z is large, since the integer part of z is
+// removed exactly before multiplying by π.
+func sinPi(z *big.Float) *big.Float {
+	prec := z.Prec()
+
+	// z = k + f, with k integer and |f| <= 0.5
+	t := new(big.Float).SetPrec(prec).Add(z, big.NewFloat(0.5))
+	if t.Sign() < 0 {
+		t.Sub(t, big.NewFloat(1))
+	}
+	k, _ := t.Int(nil)
+	kf := new(big.Float).SetPrec(prec).SetInt(k)
+	f := new(big.Float).SetPrec(prec).Sub(z, kf)
+
+	// sin(π(k + f)) = (-1)ᵏ·sin(πf)
+	x := Sin(f.Mul(f, pi(prec)))
+	if k.Bit(0) == 1 {
+		x.Neg(x)
+	}
+	return x
+}
+
+// bernoulliMu guards bernoulliCache.
+var bernoulliMu sync.Mutex
+
+// bernoulliCache[k-1] holds B₂ₖ
+var bernoulliCache []*big.Rat
+
+// bernoulli returns the Bernoulli number B₂ₖ, for k >= 1.
+func bernoulli(k int) *big.Rat {
+
+	bernoulliMu.Lock()
+	defer bernoulliMu.Unlock()
+
+	if k <= len(bernoulliCache) {
+		return bernoulliCache[k-1]
+	}
+
+	// Grow the cache geometrically, so that the quadratic cost of
+	// the computation below is amortized over the calls.
+	n := 2 * len(bernoulliCache)
+	if n < k {
+		n = k
+	}
+	if n < 32 {
+		n = 32
+	}
+
+	// Compute the tangent numbers T₁ ... Tₙ following R. P. Brent
+	// and D. Harvey, Fast computation of Bernoulli, Tangent and Secant
+	// numbers, 2011, Algorithm TangentNumbers, and then use
+	//   B₂ₖ = (-1)ᵏ⁻¹·2k·Tₖ / (4ᵏ(4ᵏ - 1))
+	tn := make([]*big.Int, n+1)
+	tn[1] = big.NewInt(1)
+	for j := 2; j <= n; j++ {
+		tn[j] = new(big.Int).Mul(big.NewInt(int64(j-1)), tn[j-1])
+	}
+	t := new(big.Int)
+	for j := 2; j <= n; j++ {
+		for i := j; i <= n; i++ {
+			t.Mul(big.NewInt(int64(i-j)), tn[i-1])
+			tn[i].Mul(big.NewInt(int64(i-j+2)), tn[i])
+			tn[i].Add(tn[i], t)
+		}
+	}
+
+	cache := make([]*big.Rat, n)
+	for j := 1; j <= n; j++ {
+		num := new(big.Int).Mul(big.NewInt(int64(2*j)), tn[j])
+		if j%2 == 0 {
+			num.Neg(num)
+		}
+		den := new(big.Int).Lsh(big.NewInt(1), uint(2*j)) // 4ʲ
+		den.Mul(den, new(big.Int).Sub(den, big.NewInt(1)))
+		cache[j-1] = new(big.Rat).SetFrac(num, den)
+	}
+	bernoulliCache = cache
+
+	return bernoulliCache[k-1]
+}
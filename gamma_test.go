@@ -0,0 +1,729 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestGamma(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"1", "1"},
+		{"2", "1"},
+		{"5", "24"},
+		{"21", "2432902008176640000"},
+		{"0.5", "1.7724538509055160272981674833411451827975494561223871282138077898529112845910321813749506567385446654162268236242825706662361528657244226025250937096027870684620376986531051228499251730289508262289320953792679628001746390153514797205167001901852340185854469744949126403139217755259062164054193325009063984076137334774751534336679897893658518364087955"},
+		{"1.5", "0.88622692545275801364908374167057259139877472806119356410690389492645564229551609068747532836927233270811341181214128533311807643286221130126254685480139353423101884932655256142496258651447541311446604768963398140008731950767573986025835009509261700929272348724745632015696088776295310820270966625045319920380686673873757671683399489468292591820439773"},
+		{"5.5", "52.342777784553520181149008492418193679490132376114244880064011294093786373078919106229011581810147150572948385154594664987286389315924354980819173611707305615519550788349510659161852766011204087073150941669007026442657308422098385496508802491407692111351480965552888909270502433499417953222539662917392077974843066756688124838007823467210312043947241"},
+		{"-0.5", "-3.5449077018110320545963349666822903655950989122447742564276155797058225691820643627499013134770893308324536472485651413324723057314488452050501874192055741369240753973062102456998503460579016524578641907585359256003492780307029594410334003803704680371708939489898252806278435510518124328108386650018127968152274669549503068673359795787317036728175909"},
+		{"-2.5", "-0.94530872048294188122568932444861076415869304326527313504736415458821935178188383006664035026055715488865430593295070435532594819505302538801338331178815310317975343928165606551996009228210710732209711753560958016009314080818745585094224010143212480991223838639728674150075828028048331541622364400048341248406065785465341516462292788766178764608469091"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Gamma(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Gamma(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func testGammaFloat64(scale float64, nTests int, t *testing.T) {
+	for i := 0; i < nTests; i++ {
+		r := rand.Float64() * scale
+
+		z := big.NewFloat(r).SetPrec(53)
+		x64, acc := bigfloat.Gamma(z).Float64()
+
+		want := math.Gamma(r)
+
+		// math.Gamma is not correctly rounded, so just require a
+		// relative error smaller than 1e-13.
+		if math.Abs(x64-want)/math.Abs(want) > 1e-13 || acc != big.Exact {
+			t.Errorf("Gamma(%g) =\n got %g (%s);\nwant %g (Exact)", z, x64, acc, want)
+		}
+	}
+}
+
+func TestGammaFloat64Small(t *testing.T) {
+	testGammaFloat64(-10, 1e3, t)
+	testGammaFloat64(-1, 1e3, t)
+}
+
+func TestGammaFloat64Medium(t *testing.T) {
+	testGammaFloat64(1, 1e3, t)
+	testGammaFloat64(10, 1e3, t)
+}
+
+func TestGammaFloat64Big(t *testing.T) {
+	testGammaFloat64(100, 1e3, t)
+	testGammaFloat64(170, 1e3, t)
+}
+
+func TestGammaSpecialValues(t *testing.T) {
+	for _, f := range []float64{
+		+0.0,
+		-0.0,
+		math.Inf(+1),
+	} {
+		z := big.NewFloat(f).SetPrec(53)
+		x64, acc := bigfloat.Gamma(z).Float64()
+		want := math.Gamma(f)
+		if x64 != want || acc != big.Exact {
+			t.Errorf("Gamma(%g) =\n got %g (%s);\nwant %g (Exact)", f, x64, acc, want)
+		}
+	}
+}
+
+func TestGammaPoles(t *testing.T) {
+	for _, f := range []float64{-1, -2, -100, math.Inf(-1)} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Gamma(%g) did not panic", f)
+				}
+			}()
+			bigfloat.Gamma(big.NewFloat(f))
+		}()
+	}
+}
+
+func TestFactorial(t *testing.T) {
+	for _, test := range []struct {
+		n    uint
+		want string
+	}{
+		{0, "1"},
+		{1, "1"},
+		{5, "120"},
+		{20, "2432902008176640000"},
+		{30, "265252859812191058636308480000000"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			x := bigfloat.Factorial(test.n, prec)
+
+			if x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, Factorial(%d) =\ngot  %g;\nwant %g", prec, test.n, x, want)
+			}
+		}
+	}
+}
+
+func TestFactorialExact(t *testing.T) {
+	// 20! < 2**62, so it's exact with 64 bits
+	x := bigfloat.Factorial(20, 64)
+	if u, acc := x.Uint64(); u != 2432902008176640000 || acc != big.Exact {
+		t.Errorf("Factorial(20, 64) = %d (%s); want 2432902008176640000 (Exact)", u, acc)
+	}
+}
+
+func TestFactorialBig(t *testing.T) {
+	// 170! is the largest factorial representable as a float64
+	x := bigfloat.Factorial(170, 53)
+	if x.IsInf() {
+		t.Fatalf("Factorial(170, 53) = %g; want finite", x)
+	}
+	want := math.Gamma(171)
+	if x64, _ := x.Float64(); math.Abs(x64-want)/want > 1e-13 {
+		t.Errorf("Factorial(170, 53) = %g; want %g", x64, want)
+	}
+
+	// above gammaIntMax the result is computed using Gamma
+	x = bigfloat.Factorial(5000, 100)
+	want100 := new(big.Float).SetPrec(100).SetInt(new(big.Int).MulRange(1, 5000))
+	if d := new(big.Float).Sub(x, want100); d.Sign() != 0 && d.MantExp(nil) > want100.MantExp(nil)-99 {
+		t.Errorf("Factorial(5000, 100) =\ngot  %g;\nwant %g", x, want100)
+	}
+}
+
+func TestBinomial(t *testing.T) {
+	for _, test := range []struct {
+		n, k uint
+		want string
+	}{
+		{0, 0, "1"},
+		{10, 0, "1"},
+		{10, 10, "1"},
+		{10, 11, "0"},
+		{10, 3, "120"},
+		{52, 5, "2598960"},
+		{52, 47, "2598960"},
+		{100, 50, "100891344545564193334812497256"}, // doesn't fit in an int64
+		{1000, 500, "270288240945436569515614693625975275496152008446548287007392875106625428705522193898612483924502370165362606085021546104802209750050679917549894219699518475423665484263751733356162464079737887344364574161119497604571044985756287880514600994219426752366915856603136862602484428109296905863799821216320"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			x := bigfloat.Binomial(test.n, test.k, prec)
+
+			if x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, Binomial(%d, %d) =\ngot  %g;\nwant %g", prec, test.n, test.k, x, want)
+			}
+		}
+	}
+
+	x := bigfloat.Binomial(52, 5, 64)
+	if u, acc := x.Uint64(); u != 2598960 || acc != big.Exact {
+		t.Errorf("Binomial(52, 5, 64) = %d (%s); want 2598960 (Exact)", u, acc)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Binomial(10, 3, 0) did not panic")
+		}
+	}()
+	bigfloat.Binomial(10, 3, 0)
+}
+
+// For large k Binomial uses Beta; the result must be within a few
+// ulps of the exact value.
+func TestBinomialBig(t *testing.T) {
+	for _, test := range []struct {
+		n, k uint
+	}{
+		{10000, 5000},
+		{20000, 4097},
+		{20000, 15000},
+	} {
+		for _, prec := range []uint{53, 100, 500} {
+			exact := new(big.Int).Binomial(int64(test.n), int64(test.k))
+			want := new(big.Float).SetPrec(prec).SetInt(exact)
+
+			x := bigfloat.Binomial(test.n, test.k, prec)
+			d := new(big.Float).Sub(x, want)
+			if d.Sign() != 0 && d.MantExp(nil) > want.MantExp(nil)-int(prec)+2 {
+				t.Errorf("prec = %d, Binomial(%d, %d) =\ngot  %g;\nwant %g", prec, test.n, test.k, x, want)
+			}
+		}
+	}
+}
+
+func TestBernoulliNumber(t *testing.T) {
+	for _, test := range []struct {
+		n    uint
+		want string
+	}{
+		{0, "1"},
+		{1, "-1/2"},
+		{2, "1/6"},
+		{3, "0"},
+		{4, "-1/30"},
+		{6, "1/42"},
+		{10, "5/66"},
+		{12, "-691/2730"},
+		{20, "-174611/330"},
+		{21, "0"},
+		{30, "8615841276005/14322"},
+		{101, "0"},
+	} {
+		r, _ := new(big.Rat).SetString(test.want)
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec).SetRat(r)
+
+			x := bigfloat.BernoulliNumber(test.n, prec)
+
+			if x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, BernoulliNumber(%d) =\ngot  %g;\nwant %g", prec, test.n, x, want)
+			}
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("BernoulliNumber(2, 0) did not panic")
+		}
+	}()
+	bigfloat.BernoulliNumber(2, 0)
+}
+
+// The Bernoulli numbers satisfy Σ C(m+1, j)·Bⱼ = 0 for j = 0 ... m,
+// for m >= 1.
+func TestBernoulliNumberRecurrence(t *testing.T) {
+	const max = 80
+	b := make([]*big.Rat, max+1)
+	b[0] = big.NewRat(1, 1)
+	for m := 1; m <= max; m++ {
+		s := new(big.Rat)
+		for j := 0; j < m; j++ {
+			c := new(big.Rat).SetInt(new(big.Int).Binomial(int64(m+1), int64(j)))
+			s.Add(s, c.Mul(c, b[j]))
+		}
+		b[m] = s.Quo(s.Neg(s), big.NewRat(int64(m+1), 1))
+	}
+
+	for n := uint(0); n <= max; n++ {
+		want := new(big.Float).SetPrec(1000).SetRat(b[n])
+		if x := bigfloat.BernoulliNumber(n, 1000); x.Cmp(want) != 0 {
+			t.Errorf("BernoulliNumber(%d) =\ngot  %g;\nwant %g", n, x, want)
+		}
+	}
+}
+
+func TestLogGamma(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"1", "0"},
+		{"2", "0"},
+		{"5", "3.1780538303479456196469416012970554088739909609035152140967343621176751591276931136912057358029881514139744721276699229434245649332049114921508141256725052963953454816684956727502948147160359803171126206628894053868629533432687160718511356333497826407808191043059188466692064494679555856114811735045198689388693974873040568327049541686237281454758895"},
+		{"0.5", "0.57236494292470008707171367567652935582364740645765578575681153573606888494241303989181163513774485385100490611434899457952410276396172829363954053940514341263819695713317295145124238667943496889460155981541237839700595801410861368994406328158902491184865665534750180003220274363194011163504821675247975590753311862623417169563494828987570238851928900"},
+		{"1.5", "-0.12078223763524522234551844578164721225185272790259946836386847375732473702728167571405169185867383369099657490622169115416141747179640226339313021222993254929253061369519848373906465194445670457652179029995211955823316246106406788498800187393570922767722629510550529529416389902216412752276673529126067447796768331793646997588369642296426578326525796"},
+		{"3.25", "0.93580193110872535825846751854189693629295922886534565115794352432276710500240624095825924215974552931068623543828234220845804858233111621543415214313619724899302248838657256045712157535858443429681707636408029492334474271622688615473431437670420692524489364895424934476037595854372620615937117135968529713765148321457754619759082183736457455149326912"},
+		{"0.0009765625", "6.9309089024194618895406190646600805357272725481886499649320015816615110059419839952690782894844419715059702254202364383293229155449202518182927552141024314483150779415000342783205928520548299468038649340894954887170837772261067520663611254940111938379622776195344736814472815463900261647687434527313117224906766842804116997830063701158350810872629770"},
+		{"2.0009765625", "0.00041318279306425426425867498633204164488303891988195996296008500197515836906271837439664711441425330386370044762834130181115245264289911616058500876808276988910229818425424753106995381817162920515919428962245785468570715817506507242832277640367575647584535735288786506981584713222559205302603107071253581010050513820003080926516872023128646166825367485"},
+		{"0.9990234375", "0.00056447191185512338425745752778379540322733814671115601515739643336237598766933267729753552357635796698514596983477911506641295562380234357825097732087903105227953189392915504797087875631481515598453253996556199714320627250933467900346525068377531090017199355257045998206715682296609601137549137587268158652130135284733099841371229772474962037970009867"},
+		{"-0.5", "1.2655121234846453964889457971347059238991475408179110398774915452294625069121077554976749621341635413930063871349196803132096229997198588506722112910402193745689245279615443866415494253033266423657249099307768763522450784892812952648761284371137590513745396058005088953585693862860443507928631687962201862930339205704048133671535930027156705603038360"},
+		{"-2.5", "-0.056243716497674050672594530097654284122944102552845625528490660895423530074769544794348016779910783110926982911612376861511345984019076055948865693920611839724962737062277355810703052495592495653041115348890919035811998166317553645080836149834162419725386039061732534401371522196677279491132692667962202047005465620683794701147561445642570177300293838"},
+		{"-10.75", "-15.403074503504817343567196583690988978939744483798114629119907024344027343979305436670857948737612724008749208020367983471209438499778218486651110089907549703738248215371371523854932428883412420898793095416930720592302217461951810252572873295148238150913170374673389218606151930422070826322192018506693554389197457442007517915119186814065331706889697"},
+		{"1000", "5905.2204232091812118260769123614407898489424097154325900233875198883841333644788925921620873288206280785351523444877542507953201517797559730676688291135385999719099389908906956894801619792675939628548440818837912807427081196544399305877639118281123217009278709314893751969282678345957753080325632934807567156083495010329190109053349176181663056848856"},
+		{"10000000000", "220258509288.81058147004192312346012655642727602028874318938226419289983649544364273457275818801426964706812254352222497118751789889985802348116839583660174591715736336599003591319564561643410074796018945597783743073225321233818915657300219792302781968544736451652560093489393703186459715673105447340432544917354833688357514220278162389885644215889714"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+			if !exact(z, test.z) {
+				continue
+			}
+
+			if x := bigfloat.LogGamma(z); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, LogGamma(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+// Γ(1e9) overflows the exponent range of big.Float, but its log is
+// about 2·10¹⁰.
+func TestLogGammaOverflow(t *testing.T) {
+	z := big.NewFloat(1e9).SetPrec(100)
+	if x := bigfloat.Gamma(z); !x.IsInf() {
+		t.Fatalf("Gamma(1e9) = %g; want +Inf", x)
+	}
+
+	want := new(big.Float).SetPrec(100)
+	want.Parse("19723265827.503716770976723589", 10)
+	x := bigfloat.LogGamma(z)
+	if d := new(big.Float).Sub(x, want); x.IsInf() || d.Abs(d).Cmp(big.NewFloat(1e-12)) > 0 {
+		t.Errorf("LogGamma(1e9) = %g; want %g", x, want)
+	}
+}
+
+func testLogGammaFloat64(scale float64, nTests int, t *testing.T) {
+	for i := 0; i < nTests; i++ {
+		r := rand.Float64() * scale
+
+		z := big.NewFloat(r).SetPrec(53)
+		x64, acc := bigfloat.LogGamma(z).Float64()
+
+		want, _ := math.Lgamma(r)
+
+		// math.Lgamma is not correctly rounded, and it loses relative
+		// precision close to its zeros, so require an error smaller
+		// than 1e-13, relative to max(|want|, 1).
+		if math.Abs(x64-want) > 1e-13*math.Max(math.Abs(want), 1) || acc != big.Exact {
+			t.Errorf("LogGamma(%g) =\n got %g (%s);\nwant %g (Exact)", z, x64, acc, want)
+		}
+	}
+}
+
+func TestLogGammaFloat64Small(t *testing.T) {
+	testLogGammaFloat64(-10, 300, t)
+	testLogGammaFloat64(1, 300, t)
+}
+
+func TestLogGammaFloat64Big(t *testing.T) {
+	testLogGammaFloat64(1e3, 300, t)
+	testLogGammaFloat64(1e20, 300, t)
+}
+
+func TestLogGammaSpecialValues(t *testing.T) {
+	for _, f := range []float64{
+		+0.0,
+		-0.0,
+		-1,
+		-100,
+		math.Inf(+1),
+	} {
+		z := big.NewFloat(f).SetPrec(53)
+		x64, acc := bigfloat.LogGamma(z).Float64()
+		want, _ := math.Lgamma(f)
+		if x64 != want || acc != big.Exact {
+			t.Errorf("LogGamma(%g) =\n got %g (%s);\nwant %g (Exact)", f, x64, acc, want)
+		}
+	}
+}
+
+func TestDigamma(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"1", "-0.57721566490153286060651209008240243104215933593992359880576723488486772677766467093694706329174674951463144724980708248096050401448654283622417399764492353625350033374293733773767394279259525824709491600873520394816567085323315177661152862119950150798479374508570574002992135478614669402960432542151905877553526733139925401296742051375413954911168510"},
+		{"2", "0.42278433509846713939348790991759756895784066406007640119423276511513227322233532906305293670825325048536855275019291751903949598551345716377582600235507646374649966625706266226232605720740474175290508399126479605183432914676684822338847137880049849201520625491429425997007864521385330597039567457848094122446473266860074598703257948624586045088831490"},
+		{"10", "2.2517525890667211076474561638858515372118089180283303694482010190833862414763035830313069049622215044536225210041611714872934642394817111320297942563233304320004679202253166305162943111756587100068733379595187643058025831150208164773567253470544667459834602231682625139383326134678215599386496428324491951927187009225689999552865477402141144191422832"},
+		{"0.5", "-1.9635100260214234794409763329987555671931596046604341070471272538716549707170541021486737172845841245986344092909484539483315444860028039502895155009150754601149554753996802081182880200403786051893416162394641998586439118035785149264756589322489697870365596459917199306826546400943551723452342295089999195465368712197405373560047099394340758926807790"},
+		{"0.25", "-4.2274535333762654080895301460966835773672444387082422716552795595189567958298533170685544569520613461317099335681602930055639870265362256231848659566143919807708250975790209038613748809757447561256559485692341856468497553429890206183011156704093048811352668799064572003142484460725661083066793061233772122255409763217052664682838151336886711103230082"},
+		{"1.5", "0.036489973978576520559023667001244432806840395339565892952872746128345029282945897851326282715415875401365590709051546051668455513997196049710484499084924539885044524600319791881711979959621394810658383760535800141356088196421485073524341067751030212963440354008280069317345359905644827654765770491000080453463128780259462643995290060565924107319220983"},
+		{"5.5", "1.6110931485817511237336268416044190359814435699427404961274759207315196324575490724545008858900190500045401938836547206548430586886003706528850876736880991430596476992034943950563151545627959979852615583637104033159592627995960882481275156709256333875666149571828832439205199630802480022579403736656032550566377319548626372471698932351690987104938242"},
+		{"-0.5", "0.036489973978576520559023667001244432806840395339565892952872746128345029282945897851326282715415875401365590709051546051668455513997196049710484499084924539885044524600319791881711979959621394810658383760535800141356088196421485073524341067751030212963440354008280069317345359905644827654765770491000080453463128780259462643995290060565924107319220983"},
+		{"-2.5", "1.1031566406452431872256903336679110994735070620062325596195394127950116959496125645179929493820825420680322573757182127183351221806638627163771511657515912065517111912669864585483786466262880614773250504272024668080227548630881517401910077344176968796301070206749467359840120265723114943214324371576667471201297954469261293106619567272325907739858876"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Digamma(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Digamma(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+// ψ(1) = -γ and ψ(2) = 1 - γ
+func TestDigammaEulerGamma(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 500, 1000, 2000} {
+		g := bigfloat.EulerGamma(prec + 64)
+
+		want := new(big.Float).SetPrec(prec).Neg(g)
+		if x := bigfloat.Digamma(big.NewFloat(1).SetPrec(prec)); x.Cmp(want) != 0 || x.Prec() != prec {
+			t.Errorf("prec = %d, Digamma(1) =\ngot  %g;\nwant %g", prec, x, want)
+		}
+
+		want.Sub(big.NewFloat(1), g)
+		if x := bigfloat.Digamma(big.NewFloat(2).SetPrec(prec)); x.Cmp(want) != 0 || x.Prec() != prec {
+			t.Errorf("prec = %d, Digamma(2) =\ngot  %g;\nwant %g", prec, x, want)
+		}
+	}
+}
+
+// ψ(z+1) = ψ(z) + 1/z
+func TestDigammaRecurrence(t *testing.T) {
+	rnd := rand.New(rand.NewSource(71))
+	for i := 0; i < 100; i++ {
+		r := (rnd.Float64() - 0.5) * 40
+		z := big.NewFloat(r).SetPrec(53)
+		z1 := new(big.Float).Add(z, big.NewFloat(1))
+
+		x, _ := bigfloat.Digamma(z).Float64()
+		x1, _ := bigfloat.Digamma(z1).Float64()
+		if want := x + 1/r; math.Abs(x1-want) > 1e-12*math.Max(math.Abs(x1), math.Abs(1/r)) {
+			t.Errorf("Digamma(%g) = %g; Digamma(%g) + 1/%g = %g", r+1, x1, r, r, want)
+		}
+	}
+}
+
+func TestDigammaSpecialValues(t *testing.T) {
+	if x := bigfloat.Digamma(big.NewFloat(math.Inf(+1))); !x.IsInf() || x.Sign() < 0 {
+		t.Errorf("Digamma(+Inf) = %g; want +Inf", x)
+	}
+
+	for _, f := range []float64{0, math.Copysign(0, -1), -1, -2, -100, math.Inf(-1)} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Digamma(%g) did not panic", f)
+				}
+			}()
+			bigfloat.Digamma(big.NewFloat(f))
+		}()
+	}
+}
+
+func TestGammaPQ(t *testing.T) {
+	for _, test := range []struct {
+		a, x string
+		p, q string
+	}{
+		{"2", "1",
+			"0.26424111765711535680895245967707826510837773793646433098432639660507700851020039328570545130816071250674534944631200958350604841441974198274669282101180243381561126524532376990272201774970876731002456004263104808412050539490021500909352126758407037897070495877541155382167014686679926985084543258893429252322378639042477608634021309100529852136280157",
+			"0.73575888234288464319104754032292173489162226206353566901567360339492299148979960671429454869183928749325465055368799041649395158558025801725330717898819756618438873475467623009727798225029123268997543995736895191587949460509978499090647873241592962102929504122458844617832985313320073014915456741106570747677621360957522391365978690899470147863719843"},
+		{"3", "10", "",
+			"0.0027693957155115759436710824491935872245130034208604631248033496447109647184724391808040252773028147216241913604708477380696571461519157437190681842102742886212204454622374492021810446296088179465410795850088854382862754644553720599127922773772505712641259786419014357765715284966188928436436482088604345176117226053404365816236561300770575613041619657"},
+		{"2.5", "3",
+			"0.69378108158672159912060970890335968975924981984473716932516821139439729199787952194729134858320939002926736284875479303885506680889260617081816324809733703871714812896313615356477050566309249080907846036424793817225376919427668663120046514626173213521312297386892991472340837315706959650153560365333524285070109277105694368431849678304601721995840868",
+			""},
+		{"2.5", "10", "",
+			"0.0012497305630313754118510652527945958477336641192184404741643446914024910684334875047906673970543265330056724765728051114250775514698229557792566574171879982261699521745407172165617794708585023841098152630375512976805829463265772117968467979246335985988907476017189543427053618881171001362805269287566381138162017237075949957003094124012189972204138519"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			a := new(big.Float).SetPrec(prec)
+			a.Parse(test.a, 10)
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			if test.p != "" {
+				want := new(big.Float).SetPrec(prec)
+				want.Parse(test.p, 10)
+				if p := bigfloat.GammaP(a, x); p.Cmp(want) != 0 || p.Prec() != prec {
+					t.Errorf("prec = %d, GammaP(%v, %v) =\ngot  %g;\nwant %g", prec, test.a, test.x, p, want)
+				}
+			}
+			if test.q != "" {
+				want := new(big.Float).SetPrec(prec)
+				want.Parse(test.q, 10)
+				if q := bigfloat.GammaQ(a, x); q.Cmp(want) != 0 || q.Prec() != prec {
+					t.Errorf("prec = %d, GammaQ(%v, %v) =\ngot  %g;\nwant %g", prec, test.a, test.x, q, want)
+				}
+			}
+		}
+	}
+}
+
+// e1 returns the exponential integral E1(x) = -γ - log(x) - Σ
+// (-x)ᵏ/(k·k!), for small positive x, with prec bits of precision.
+func e1(x *big.Float, prec uint) *big.Float {
+	p := prec + 64
+	x = new(big.Float).SetPrec(p).Set(x)
+	s := bigfloat.EulerGamma(p)
+	s.Add(s, bigfloat.Log(x))
+	term := big.NewFloat(1).SetPrec(p) // (-x)ᵏ/k!
+	for k := int64(1); ; k++ {
+		term.Mul(term, x)
+		term.Quo(term, big.NewFloat(float64(-k)))
+		t := new(big.Float).Quo(term, big.NewFloat(float64(k)))
+		if t.Sign() == 0 || t.MantExp(nil) < s.MantExp(nil)-int(p) {
+			break
+		}
+		s.Add(s, t)
+	}
+	return s.Neg(s).SetPrec(prec)
+}
+
+// For small a, Q(a, x) = a·E1(x)·(1 + O(a)) is tiny, while P is close
+// to 1 and 1 - P cancels.
+func TestGammaQSmallA(t *testing.T) {
+	for _, test := range []struct {
+		a       string
+		maxPrec uint // the O(a) term is below 2**-maxPrec
+	}{
+		{"1e-30", 64},
+		{"1e-300", 900},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900} {
+			if prec > test.maxPrec {
+				break
+			}
+			a := new(big.Float).SetPrec(prec)
+			a.Parse(test.a, 10)
+			for _, f := range []float64{0.1, 0.5, 1.5} {
+				x := big.NewFloat(f).SetPrec(prec)
+				want := e1(x, prec)
+				want.Mul(want, a)
+
+				tol := new(big.Float).SetMantExp(big.NewFloat(1), -int(prec)+2)
+				if q := bigfloat.GammaQ(a, x); q.Prec() != prec || relErr(q, want).Cmp(tol) > 0 {
+					t.Errorf("prec = %d, GammaQ(%v, %v) =\ngot  %g;\nwant %g", prec, test.a, f, q, want)
+				}
+			}
+		}
+	}
+}
+
+// P(a, x) + Q(a, x) = 1, on both sides of x = a+1
+func TestGammaPQSum(t *testing.T) {
+	rnd := rand.New(rand.NewSource(74))
+	for i := 0; i < 100; i++ {
+		a := big.NewFloat(rnd.Float64() * 50).SetPrec(200)
+		x := big.NewFloat(rnd.Float64() * 60).SetPrec(200)
+		if a.Sign() == 0 {
+			continue
+		}
+
+		s := new(big.Float).Add(bigfloat.GammaP(a, x), bigfloat.GammaQ(a, x))
+		s.Sub(s, big.NewFloat(1))
+		if s.Sign() != 0 && s.MantExp(nil) > -198 {
+			t.Errorf("GammaP(%g, %g) + GammaQ(%g, %g) = 1 + %g", a, x, a, x, s)
+		}
+	}
+}
+
+// P(1/2, x) = erf(√x)
+func TestGammaPErf(t *testing.T) {
+	for _, f := range []float64{0.25, 1, 2, 4, 9} {
+		x := big.NewFloat(f).SetPrec(100)
+		p := bigfloat.GammaP(big.NewFloat(0.5).SetPrec(100), x)
+		want := bigfloat.Erf(bigfloat.Sqrt(x))
+
+		d := new(big.Float).Sub(p, want)
+		if d.Sign() != 0 && d.MantExp(nil) > want.MantExp(nil)-99 {
+			t.Errorf("GammaP(0.5, %g) =\ngot  %g;\nwant %g", f, p, want)
+		}
+	}
+}
+
+func TestGammaPQSpecialValues(t *testing.T) {
+	a := big.NewFloat(2.5)
+	for _, test := range []struct {
+		x    float64
+		p, q float64
+	}{
+		{0, 0, 1},
+		{math.Inf(+1), 1, 0},
+	} {
+		x := big.NewFloat(test.x)
+		if p := bigfloat.GammaP(a, x); p.Cmp(big.NewFloat(test.p)) != 0 || p.Prec() != 53 {
+			t.Errorf("GammaP(2.5, %g) = %g; want %g", test.x, p, test.p)
+		}
+		if q := bigfloat.GammaQ(a, x); q.Cmp(big.NewFloat(test.q)) != 0 || q.Prec() != 53 {
+			t.Errorf("GammaQ(2.5, %g) = %g; want %g", test.x, q, test.q)
+		}
+	}
+
+	for _, test := range []struct {
+		a, x float64
+	}{
+		{-1, 1},
+		{0, 1},
+		{math.Inf(+1), 1},
+		{1, -1},
+		{1, math.Inf(-1)},
+	} {
+		for name, f := range map[string]func(a, x *big.Float) *big.Float{
+			"GammaP": bigfloat.GammaP,
+			"GammaQ": bigfloat.GammaQ,
+		} {
+			func() {
+				defer func() {
+					if r := recover(); r == nil {
+						t.Errorf("%s(%g, %g) did not panic", name, test.a, test.x)
+					}
+				}()
+				f(big.NewFloat(test.a), big.NewFloat(test.x))
+			}()
+		}
+	}
+}
+
+func TestBeta(t *testing.T) {
+	for _, test := range []struct {
+		a, b string
+		want string
+	}{
+		{"1", "1", "1"},
+		{"2", "3", "0.083333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333"},
+		{"0.5", "0.5", "3.1415926535897932384626433832795028841971693993751058209749445923078164062862089986280348253421170679821480865132823066470938446095505822317253594081284811174502841027019385211055596446229489549303819644288109756659334461284756482337867831652712019091456485669234603486104543266482133936072602491412737245870066063155881748815209209628292540917153644"},
+		{"-0.5", "1.5", "-3.1415926535897932384626433832795028841971693993751058209749445923078164062862089986280348253421170679821480865132823066470938446095505822317253594081284811174502841027019385211055596446229489549303819644288109756659334461284756482337867831652712019091456485669234603486104543266482133936072602491412737245870066063155881748815209209628292540917153644"},
+		{"2.5", "1.25", "0.27242156408229816212621710024842799103197454040848032860434511208319902203328979223171901641157704866972838988797747720058274876897599331892998643478184268471923548022492539760383376414879439713071391073352791915817450398509657556075899943373613391047537937527494327398416008655881231290698724459369316284992352919028109500601365354611238806321134819"},
+		{"-2.25", "0.125", "3.6008664307113674204585651899097135015717623460681428429612501086874771756097682142548977482841091374767514505249427318967518319268282908458241483383122089950244960450965692201293434759273333613994629606746084412333968110878755397435226673953725427857228216742485377915568500175623590241838384863513920355814413024907912345744324236187996989049326131"},
+		{"1000", "1000", "9.7649020396977825460216173922639091614409146143974833679967700770730186200717071511275868309424062504268512770565825689823658707127272169819940729427072017895794583112264475006830208504129154511193417050207998837543213077010439520353934134164277297686271601318053464013411407371984656467207319720751550796677864054656223855063133740450986383930360267e-604"},
+		{"0.0009765625", "3", "1022.5017071980383054196574176576319203894820793010272708876429906319560999416729159970955492863859824542608530038448261495792117511219036055660703019914532966705948172219643133473794474401552214643669130688378626099584568320060945851039769548500755871394731516861288671451868252211072623171326881643633420228785011129759906676665595352878858217571927"},
+		{"0.5", "-1.5", "0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			a := new(big.Float).SetPrec(prec)
+			a.Parse(test.a, 10)
+			b := new(big.Float).SetPrec(prec)
+			b.Parse(test.b, 10)
+
+			if x := bigfloat.Beta(a, b); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Beta(%v, %v) =\ngot  %g;\nwant %g", prec, test.a, test.b, x, want)
+			}
+		}
+	}
+}
+
+func TestBetaSymmetry(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		a := big.NewFloat(rand.Float64()*20 - 10).SetPrec(500)
+		b := big.NewFloat(rand.Float64() * 10).SetPrec(500)
+		if a.IsInt() || b.IsInt() {
+			continue
+		}
+		if x, y := bigfloat.Beta(a, b), bigfloat.Beta(b, a); x.Cmp(y) != 0 {
+			t.Errorf("Beta(%g, %g) =\n%g\nbut Beta(%g, %g) =\n%g", a, b, x, b, a, y)
+		}
+	}
+}
+
+func TestBetaFloat64(t *testing.T) {
+	for i := 0; i < 300; i++ {
+		a, b := rand.Float64()*20, rand.Float64()*20
+		x64, acc := bigfloat.Beta(big.NewFloat(a), big.NewFloat(b)).Float64()
+
+		la, _ := math.Lgamma(a)
+		lb, _ := math.Lgamma(b)
+		lab, _ := math.Lgamma(a + b)
+		want := math.Exp(la + lb - lab)
+
+		// math.Lgamma is not correctly rounded, and the error on the
+		// sum is amplified by the exponential, so just require a
+		// relative error smaller than 1e-12.
+		if math.Abs(x64-want) > 1e-12*want || acc != big.Exact {
+			t.Errorf("Beta(%g, %g) =\n got %g (%s);\nwant %g (Exact)", a, b, x64, acc, want)
+		}
+	}
+}
+
+func TestBetaPoles(t *testing.T) {
+	for _, f := range [][2]float64{{0, 1}, {1, -1}, {-2, 0.5}, {math.Inf(+1), 1}, {1, math.Inf(-1)}} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Beta(%g, %g) did not panic", f[0], f[1])
+				}
+			}()
+			bigfloat.Beta(big.NewFloat(f[0]), big.NewFloat(f[1]))
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkGamma(b *testing.B) {
+	z := big.NewFloat(2.5).SetPrec(1e4)
+	_ = bigfloat.Gamma(z) // fill caches before benchmarking
+
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		z = big.NewFloat(2.5).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Gamma(z)
+			}
+		})
+	}
+}
+
+func BenchmarkLogGamma(b *testing.B) {
+	z := big.NewFloat(2.5).SetPrec(1e4)
+	_ = bigfloat.LogGamma(z) // fill caches before benchmarking
+
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		z = big.NewFloat(2.5).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.LogGamma(z)
+			}
+		})
+	}
+}
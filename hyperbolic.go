@@ -0,0 +1,221 @@
+package bigfloat
+
+import "math/big"
+
+// Sinh returns a big.Float representation of the hyperbolic sine of
+// z. Precision is the same as the one of the argument. The function
+// returns ±0 when z = ±0, and ±Inf when z = ±Inf.
+func Sinh(z *big.Float) *big.Float {
+
+	// Sinh(±0) = ±0, Sinh(±Inf) = ±Inf
+	if z.Sign() == 0 || z.IsInf() {
+		return new(big.Float).Copy(z)
+	}
+
+	prec, tiny := hyperbolicPrec(z)
+
+	// sinh(z) = z + z³/6 + ..., so if z² is below the precision the
+	// result rounds to z
+	if tiny {
+		return new(big.Float).Copy(z)
+	}
+
+	// sinh(z) = (eᶻ - e⁻ᶻ)/2
+	x := Exp(new(big.Float).SetPrec(prec).Set(z))
+	t := new(big.Float).SetPrec(prec).Quo(big.NewFloat(1), x)
+	x.Sub(x, t)
+	x.SetMantExp(x, -1)
+
+	return x.SetPrec(z.Prec())
+}
+
+// Cosh returns a big.Float representation of the hyperbolic cosine
+// of z. Precision is the same as the one of the argument. The function
+// returns 1 when z = ±0, and +Inf when z = ±Inf.
+func Cosh(z *big.Float) *big.Float {
+
+	// Cosh(±0) = 1
+	if z.Sign() == 0 {
+		return big.NewFloat(1).SetPrec(z.Prec())
+	}
+
+	// Cosh(±Inf) = +Inf
+	if z.IsInf() {
+		return new(big.Float).SetPrec(z.Prec()).SetInf(false)
+	}
+
+	prec := z.Prec() + 64 // guard digits
+
+	// cosh(z) = (eᶻ + e⁻ᶻ)/2
+	x := Exp(WithGuard(z, 64))
+	t := new(big.Float).SetPrec(prec).Quo(big.NewFloat(1), x)
+	x.Add(x, t)
+	x.SetMantExp(x, -1)
+
+	return x.SetPrec(z.Prec())
+}
+
+// Tanh returns a big.Float representation of the hyperbolic tangent
+// of z. Precision is the same as the one of the argument. The function
+// returns ±0 when z = ±0, and ±1 when z = ±Inf.
+func Tanh(z *big.Float) *big.Float {
+
+	// Tanh(±0) = ±0
+	if z.Sign() == 0 {
+		return new(big.Float).Copy(z)
+	}
+
+	// Tanh(±Inf) = ±1
+	if z.IsInf() {
+		return big.NewFloat(float64(z.Sign())).SetPrec(infPrec(z))
+	}
+
+	prec, tiny := hyperbolicPrec(z)
+
+	// tanh(z) = z - z³/3 + ..., so if z² is below the precision the
+	// result rounds to z
+	if tiny {
+		return new(big.Float).Copy(z)
+	}
+
+	// Compute tanh(|z|) as
+	//   1 - 2/(e²ᶻ + 1)
+	// which doesn't overflow for large |z|, since e²ᶻ going to +Inf
+	// just makes the result 1.
+	x := new(big.Float).SetPrec(prec).Abs(z)
+	x.SetMantExp(x, 1)
+	x = Exp(x)
+	x.Add(x, big.NewFloat(1))
+	x.Quo(big.NewFloat(2), x)
+	x.Sub(big.NewFloat(1), x)
+
+	if z.Sign() < 0 {
+		x.Neg(x)
+	}
+
+	return x.SetPrec(z.Prec())
+}
+
+// hyperbolicPrec returns the working precision for Sinh, Tanh, Asinh
+// and Atanh at z, and whether |z| is so small that the result rounds
+// to z. All of them compute a difference of terms close to 1 (or the
+// log of a value close to 1) when z is close to 0, losing about
+// -log2|z| bits, so the working precision includes as many more guard
+// digits.
+func hyperbolicPrec(z *big.Float) (uint, bool) {
+	prec := z.Prec() + 64
+	if exp := z.MantExp(nil); exp < 0 {
+		if 2*(-exp) > int(z.Prec())+2 {
+			return 0, true
+		}
+		prec += uint(-exp)
+	}
+	return prec, false
+}
+
+// Asinh returns a big.Float representation of the inverse hyperbolic
+// sine of z. Precision is the same as the one of the argument. The
+// function returns ±0 when z = ±0, and ±Inf when z = ±Inf.
+func Asinh(z *big.Float) *big.Float {
+
+	// Asinh(±0) = ±0, Asinh(±Inf) = ±Inf
+	if z.Sign() == 0 || z.IsInf() {
+		return new(big.Float).Copy(z)
+	}
+
+	prec, tiny := hyperbolicPrec(z)
+
+	// asinh(z) = z - z³/6 + ..., so if z² is below the precision the
+	// result rounds to z
+	if tiny {
+		return new(big.Float).Copy(z)
+	}
+
+	// Compute asinh(|z|) as log(|z| + √(z² + 1)). For z < 0 the same
+	// formula would compute the log of a difference of two close
+	// values, so use asinh(z) = -asinh(-z) instead.
+	x := new(big.Float).SetPrec(prec).Abs(z)
+	t := new(big.Float).SetPrec(prec).Mul(x, x)
+	t.Add(t, big.NewFloat(1))
+	x = Log(x.Add(x, Sqrt(t)))
+
+	if z.Sign() < 0 {
+		x.Neg(x)
+	}
+
+	return x.SetPrec(z.Prec())
+}
+
+// Acosh returns a big.Float representation of the inverse hyperbolic
+// cosine of z. Precision is the same as the one of the argument. The
+// function panics if z < 1, returns 0 when z = 1, and +Inf when z =
+// +Inf.
+func Acosh(z *big.Float) *big.Float {
+
+	// panic on z < 1
+	if z.Cmp(big.NewFloat(1)) < 0 {
+		panic("Acosh: argument is less than 1")
+	}
+
+	// Acosh(+Inf) = +Inf
+	if z.IsInf() {
+		return new(big.Float).Copy(z)
+	}
+
+	prec := z.Prec() + 64 // guard digits
+
+	// When z is close to 1, acosh(z) ≈ √(2(z - 1)) is close to 0 and
+	// the rounding error on the argument of the log is about half the
+	// bits of z - 1, so add as many more guard digits.
+	one := big.NewFloat(1)
+	d := new(big.Float).SetPrec(prec).Sub(z, one) // exact
+	if d.Sign() == 0 {
+		return new(big.Float).SetPrec(z.Prec())
+	}
+	if exp := d.MantExp(nil); exp < 0 {
+		prec += uint(-exp/2) + 1
+		d.SetPrec(prec)
+	}
+
+	// acosh(z) = log(z + √(z² - 1)), and z² - 1 = (z - 1)(z + 1)
+	// doesn't suffer from cancellation.
+	x := new(big.Float).SetPrec(prec).Set(z)
+	t := new(big.Float).SetPrec(prec).Add(x, one)
+	t.Mul(t, d)
+	x = Log(x.Add(x, Sqrt(t)))
+
+	return x.SetPrec(z.Prec())
+}
+
+// Atanh returns a big.Float representation of the inverse hyperbolic
+// tangent of z. Precision is the same as the one of the argument. The
+// function panics if |z| >= 1, and returns ±0 when z = ±0.
+func Atanh(z *big.Float) *big.Float {
+
+	// panic on |z| >= 1
+	if z.IsInf() || new(big.Float).Abs(z).Cmp(big.NewFloat(1)) >= 0 {
+		panic("Atanh: argument is outside (-1, 1)")
+	}
+
+	// Atanh(±0) = ±0
+	if z.Sign() == 0 {
+		return new(big.Float).Copy(z)
+	}
+
+	prec, tiny := hyperbolicPrec(z)
+
+	// atanh(z) = z + z³/3 + ..., so if z² is below the precision the
+	// result rounds to z
+	if tiny {
+		return new(big.Float).Copy(z)
+	}
+
+	// atanh(z) = log((1 + z)/(1 - z))/2
+	one := big.NewFloat(1)
+	x := new(big.Float).SetPrec(prec).Add(one, z)
+	t := new(big.Float).SetPrec(prec).Sub(one, z)
+	x = Log(x.Quo(x, t))
+	x.SetMantExp(x, -1)
+
+	return x.SetPrec(z.Prec())
+}
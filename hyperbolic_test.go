@@ -0,0 +1,270 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestHyperbolic(t *testing.T) {
+	for _, test := range []struct {
+		z                string
+		sinh, cosh, tanh string
+	}{
+		{"0.5", "0.52109530549374736162242562641149155910592898261148052794609357645280225089023359231706445427418859348822142398113413591406667944482833131324989581477119118611092070629077798672371628290579434482624016674283266361699843366907205777867483016080234486126292751638874047823711657060729268000873056363488002065089188317594310817850127061333849617847281357", "1.1276259652063807852262251614026720125478471180986674836289857351878587703039820163157120657821780495146452137751736610906044875303912778465910756377188686108185019528076259279962321817536949000706287385935858021038426329877877423102501510509099425139520446791232311307969745450125071898312300790202117339237344213071320865797575120121014357772398765", "0.46211715726000975850231848364367254873028928033011303855273181583808090614040927877494906415196249058434893298628154913288226546186959789595714461161587856332913270416677693919737256793077027003730144860859926240958178361189289914670380276922133568178284773332218994126478801307934128773807420020009592957567759843435133251472271279960243337570736045"},
+		{"-1", "-1.1752011936438014568823818505956008151557179813340958702295654130133075673043238956071174520896233918404195333275795323567852189019194572821368403528832484238229689806253026878572974193778037894530156457975748559863812033933000211943571349392767479287838086397780915943822887094379183712322502306432683489821868659007368597138765536487737915436208492", "1.5430806348152437784779056207570616826015291123658637047374022147107690630492236989642647264355430355870468586044235275650321946947095862907634939423773472069151633480026408029059364105029494057980033657762593319443209506958499136898103743054847127392984561603903858174714536360045187363068275143488012027205749727055244716707064471032711422829394484", "-0.76159415595576488811945828260479359041276859725793655159681050012195324457663848345894752167367671442190275970155407753236830911476248541329700666961132112539651013760808777643934099260420667955311747580113059006625778319752451237997591796119707757354591410814335043351567518059703276048802963895774140411055528274345747412887011673202243366614182043"},
+		{"3", "10.017874927409901898974593619465828060178104123182863464405653251046392605180887090525221458008192178813603143600527660465473184546308666196554566680925330185697983421488413480041522671170058086893428953724019433911707144587673842198870329331066666207487302539392241907420296990442738497893454799966978336799647877773738751515809924560810246879970791", "10.067661995777765841953936035115889836809803715371286679973280978652453272911086640679275702244825523340004472251421342416337827933044840493678055103551920318247693678577664371770706376714325853364723580985333189069758393836881855534644778819051738547446535958450935768650616782419753289455941237855815380887483376286858801910830901470138417040245468", "0.99505475368673045133188018525548847509781385470028249182387881513066470278255917671939596002231175799699924447177995985233648136892406332446960921491311502198738138013025870272038394593004772603503752665020104530819162720839996948380217251503067381498328278616706798728837843522884263989507311821975910819600126443366434827377347041711919784157832693"},
+		{"-20", "-242582597.70489513795397660405149136535934930439451313898890176235843715158523253926840791343409399808396143076668997966071790476220083145546428863094616682508958291193461424340543087986998647635825942221008608214741235725855342338128033846999022934172654952780277842488729460797537354634242931266784773841944022037553952675934499531205468651210148143", "242582597.70489514001513022649004919332528968455033411536470903795754084455747720089757193721865335136387235867227134669710579555435199218979962699761035853891843060567122766080626325844543533102278016498889227861579359855530541867468051769663879987440362722518257544237579249088479753958751199562023620281515213962162659298065518281878661579541504216", "-0.99999999999999999150329148941682204543855819119098725713047456422766385013396305493020379483116286723884401319225479610330434833745185496603886706495752590110273736459185586512033780048231947928548879745109044764072521967724592549251580209939074878059727810260917809729859940239495986128671819259194208485713426533719153175288052945264104145883223091"},
+		{"0.001", "0.0010000001666666750000001984127011684303601491103097005882430816321364728309679678174513907005998062015150241995007245148347478178939109808605649634558740428052652848065062463722829658158669436923093775380750903475635581262765417918269615908776058120886874651750594122156778047132394043571558745382704931546175179434258495808359582785170725224129223252", "1.0000005000000416666680555555803571431327160514703917596402715928282232754204803305952668353035534547415531946725943748272289308782257128601117659420758942691182909136915174065370947119144169247939780323322421339892637325422478004351806890552027295655948519366893135233417336193943650926350000358855978688586750067491666801785972832955397709059306272", "0.00099999966666679999994603176790122570466929679228540438865851367539373950419934604876471485854384663264629227352131199011269375535360084488471930897272470431013698598319397355933741357602848460415627528835595322292342762521835834787174340035730777283592763009791126286512724901356648488483805711926237240147729034916952171312568399186579596936408962528"},
+		{"1e-20", "1.0000000000000000000000000000000000000000166666666666666666666666666666666666666667500000000000000000000000000000000000000001984126984126984126984126984126984126984129739858906525573192239858906525573192239861411736411736411736411736411736411736411738017640795418573196350974128751906529684308226801613044999288385531771775158018544262211651042183255e-20", "1.0000000000000000000000000000000000000000500000000000000000000000000000000000000004166666666666666666666666666666666666666680555555555555555555555555555555555555555580357142857142857142857142857142857142857170414462081128747795414462081128747795414482957885735663513441291219068996846774624563873075777837682599587361492123396885301651985886443558401", "9.9999999999999999999999999999999999999996666666666666666666666666666666666666666799999999999999999999999999999999999999994603174603174603174603174603174603174603393298059964726631393298059964726631393289196729196729196729196729196729196729197088409532853977298421742866187310631755061641176773451905727038002170277302552435417594543644963813031039922e-21"},
+		{"100", "13440585709080677242063127757900067936805559.386870961207595804307640143517454782457079416948230042751226020641116268718757112060419472232864394216395552666592435087734469229096585426859533471456844622453109870517297712769903400890190436961610692948270375586840585391727101404388633524388100901319369915128536434659289022786440774548282944802120216006", "13440585709080677242063127757900067936805559.386870961207595804307640143517454782457079454148989802959585650238074307349940485649342396000684065422534319299497194045891650800284371849674499490813021045563807872997154133295259403552047319800686267336461535815289276889312956507205245265996873272020714997304291692156165403265368054077683564598346693056", "0.99999999999999999999999999999999999999999999999999999999999999999999999999999999999999723220694652652493870263708604183062919390483532104558121214929377512793909801402438240586576513934611709025613712339683816688346395871914579327155893603077386338146679043508102645813643774749132419429551032841200610831982899463207463025792500957177473260011789306"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+			if !exact(z, test.z) {
+				continue
+			}
+
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.sinh, 10)
+			if x := bigfloat.Sinh(z); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Sinh(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+
+			want.Parse(test.cosh, 10)
+			if x := bigfloat.Cosh(z); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Cosh(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+
+			want.Parse(test.tanh, 10)
+			if x := bigfloat.Tanh(z); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Tanh(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestHyperbolicIdentity(t *testing.T) {
+	for _, f := range []float64{0.1, 0.5, 1, 2.5, -3, 10} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			z := big.NewFloat(f).SetPrec(prec)
+			s, c := bigfloat.Sinh(z), bigfloat.Cosh(z)
+
+			// cosh² - sinh² = 1, up to the rounding errors on the
+			// squares, which are about ulp(cosh²)
+			w := uint(2 * prec)
+			c2 := new(big.Float).SetPrec(w).Mul(c, c)
+			s2 := new(big.Float).SetPrec(w).Mul(s, s)
+			d := new(big.Float).SetPrec(w).Sub(c2, s2)
+			d.Sub(d, big.NewFloat(1))
+			if d.Sign() != 0 && d.MantExp(nil) > c2.MantExp(nil)-int(prec)+2 {
+				t.Errorf("prec = %d, Cosh(%g)² - Sinh(%g)² - 1 = %g", prec, f, f, d)
+			}
+		}
+	}
+}
+
+func testHyperbolicFloat64(scale float64, nTests int, t *testing.T) {
+	for i := 0; i < nTests; i++ {
+		r := rand.Float64() * scale
+		z := big.NewFloat(r).SetPrec(53)
+
+		for _, test := range []struct {
+			name string
+			x    *big.Float
+			want float64
+		}{
+			{"Sinh", bigfloat.Sinh(z), math.Sinh(r)},
+			{"Cosh", bigfloat.Cosh(z), math.Cosh(r)},
+			{"Tanh", bigfloat.Tanh(z), math.Tanh(r)},
+		} {
+			// The Go math functions are not correctly rounded, so
+			// just require a relative error smaller than 1e-14.
+			x64, _ := test.x.Float64()
+			if math.Abs(x64-test.want) > 1e-14*math.Abs(test.want) {
+				t.Errorf("%s(%g) = %g; want %g", test.name, r, x64, test.want)
+			}
+		}
+	}
+}
+
+func TestHyperbolicFloat64Small(t *testing.T) {
+	testHyperbolicFloat64(-1e-5, 300, t)
+	testHyperbolicFloat64(-1, 300, t)
+}
+
+func TestHyperbolicFloat64Big(t *testing.T) {
+	testHyperbolicFloat64(10, 300, t)
+	testHyperbolicFloat64(-700, 300, t)
+}
+
+func TestHyperbolicSpecialValues(t *testing.T) {
+	for _, z := range []*big.Float{
+		big.NewFloat(+0.0),
+		big.NewFloat(math.Copysign(0, -1)),
+		big.NewFloat(math.Inf(+1)),
+		big.NewFloat(math.Inf(-1)),
+		new(big.Float).SetInf(false), // prec = 0
+		new(big.Float).SetInf(true),
+	} {
+		f, _ := z.Float64()
+		for _, test := range []struct {
+			name string
+			x    *big.Float
+			want float64
+		}{
+			{"Sinh", bigfloat.Sinh(z), math.Sinh(f)},
+			{"Cosh", bigfloat.Cosh(z), math.Cosh(f)},
+			{"Tanh", bigfloat.Tanh(z), math.Tanh(f)},
+		} {
+			x64, acc := test.x.Float64()
+			if x64 != test.want || math.Signbit(x64) != math.Signbit(test.want) || acc != big.Exact {
+				t.Errorf("%s(%g) =\n got %g (%s);\nwant %g (Exact)", test.name, f, x64, acc, test.want)
+			}
+		}
+	}
+}
+
+func TestInverseHyperbolic(t *testing.T) {
+	for _, test := range []struct {
+		z                   string
+		asinh, acosh, atanh string
+	}{
+		{"0.5", "0.48121182505960344749775891342436842313518433438566051966101816884016386760822177441200942912272347499723183995829365641127256832372673762275305924186440975418241700721183715022382393746918727524327919301879707900356172679694454575230534543418876528553256490207399693496618755630102123996367930820635997798850998015682579785264932866665111624171380827", "", "0.54930614433405484569762261846126285232374527891137472586734716681874714660930448343680787740686604439398501453297893287118400211296525991052640093538363870530158138459169068358968684942218047995187128515839795576057279595887533567352747008338779011110158512647344878034505326075282143406901815868664928889118349582739606590907451001505191181506112433"},
+		{"-0.75", "-0.69314718055994530941723212145817656807550013436025525412068000949339362196969471560586332699641868754200148102057068573368552023575813055703267075163507596193072757082837143519030703862389167347112335011536449795523912047517268157493206515552473413952588295045300709532636664265410423915781495204374043038550080194417064167151864471283996817178454696", "", "-0.97295507452765665255267637172158986481854236479093059422969507496878993137603463389382924929393576349653084710292557045586187612883889342157447904758195038795391223405213739169112967450423368720625248685242677588391778874312007551387090443433553757060674046939870915540512591158424650703665319664385596705607034384620013028846792623980267514287681285"},
+		{"1e-10", "9.9999999999999999999833333333333333333334083333333333333333328869047619047619047649429563492063492063268341901154401154402889677596708846708832744002958846708846824364717808105905531399959658495321167059433547843540923703631151200958427899676788188398239586706588494592806889913802403408056889032711237671237365435659972307333013998843255926315911056e-11", "", "1.0000000000000000000033333333333333333333533333333333333333334761904761904761904773015873015873015873106782106782106782107551337551337551337558004218004218004218063041533629768923887097261183948490450043199559298630506060890096383029483042944204822927181252662478603357227667363621093082953039971434799111504229272419979825725426419232118000659050066e-10"},
+		{"-0.999", "-0.88066630343220946143627835780710932715830483638995204269061162739845107249126668273902617461239178867744900549391972796996219010016821475876113946392480594709285235320373762018193402973658310171644755584462469703520246874894818792252564822556879208675528400610912625435354867704648279624331867567055304499802417300204921695706167789720654452019211239", "", "-3.8002011672502000317759672678090044622636756952304998909216320098234108819060521355939569962751521183616783424814362073413023397107333854811241026847463374312433172803495512454369576402308793788194156082060947260370700825149890817889106197185158867738616387496561641867478256816951532344454162153526531283994735192186629710587087581741213314222385965"},
+		{"2", "1.4436354751788103424932767402731052694055530031569815589830545065204916028246653232360282873681704249916955198748809692338177049711802128682591777255932292625472510216355114506714718124075618257298375790563912370106851803908336372569160363025662958565976947062219908048985626689030637198910379246190799339655299404704773935579479859999533487251414248", "1.3169578969248167086250463473079684440269819714675164797684722569204601854164439760742190134501017835564654365656049793198098168621063715327267633457099206769058311287762569581704704373368637119409556504467967320008259374753779128904267720926333444215608442411897668706630346965128936149937499537698028627808731599409811428097663442379476682307349962", ""},
+		{"-100", "-5.2983423656105887573688256891129063021423835351562182383226152437778181026338831832876956050426813410315662041964051910239242153360079384888463527153100296798958935127316386252572714752101456444435802343845907348458735712872067259821561749467669285673764734163042979196299703069234441528613218807278567154496192285593107006974430193990460513032283067", "", ""},
+		{"1.0000000000009094947017729282379150390625", "0.88137358702018613510368605301786473789786031631898141255246771081577528054893730497762852985483020099869080945122655863853801222961108484097495556317209066055311814192434249125856408238639629712720573250952831191212646422930685046977425861121902566524185325269526116104543770402279033882328970785132653077188588603557133812661021818763335489832482438", "0.0000013486991523485067976218947732603534909053916260088125402054741589992902250660653791601470333631604022214396510952849673468008509106595204469216391224399035972959978378006298319722308902088981112584722886760288198513453708889627692408157634379637232156422093929804074122417243075986268220716193789877115900159318977796548522133777852712351019088514791", ""},
+		{"1e20", "46.744849040440858989777061215145460720097554906935714774787238028844845815516744319388307428788384654381357161866295658401928112870747599023691751466944043084922201652704048385399638268481888977372326789874099135941149377075830460662229796223770141994222301149273643529638386274775268661112495218390604369430901571225100571604846893231603930390399749", "46.744849040440858989777061215145460720097504906935714774787238028844845815516744319388307428788384654381357161866295658401823946204080932357025084800277376418255534986037381718732971601815222310705660122715244969274482710409163793995563129557103475327555634482606976862971719608108599001979310694581080559907092047415576762081037369422080120866590225", ""},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+			if !exact(z, test.z) {
+				continue
+			}
+
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.asinh, 10)
+			if x := bigfloat.Asinh(z); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Asinh(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+
+			if test.acosh != "" {
+				want.Parse(test.acosh, 10)
+				if x := bigfloat.Acosh(z); x.Cmp(want) != 0 {
+					t.Errorf("prec = %d, Acosh(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+				}
+			}
+
+			if test.atanh != "" {
+				want.Parse(test.atanh, 10)
+				if x := bigfloat.Atanh(z); x.Cmp(want) != 0 {
+					t.Errorf("prec = %d, Atanh(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+				}
+			}
+		}
+	}
+}
+
+func TestAsinhRoundTrip(t *testing.T) {
+	for _, f := range []float64{1e-10, 0.5, -1, 3, -25} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			z := big.NewFloat(f).SetPrec(prec)
+			x := bigfloat.Asinh(bigfloat.Sinh(z))
+
+			// Sinh(z) is rounded, and Asinh amplifies its error by
+			// at most 1/cosh(asinh(z)) ≤ 1, so allow a couple of ulps
+			d := new(big.Float).Sub(x, z)
+			if d.Sign() != 0 && d.MantExp(nil) > z.MantExp(nil)-int(prec)+2 {
+				t.Errorf("prec = %d, Asinh(Sinh(%g)) = %g", prec, f, x)
+			}
+		}
+	}
+}
+
+func TestInverseHyperbolicFloat64(t *testing.T) {
+	for i := 0; i < 300; i++ {
+		r := 200*rand.Float64() - 100
+		x64, _ := bigfloat.Asinh(big.NewFloat(r)).Float64()
+		if want := math.Asinh(r); math.Abs(x64-want) > 1e-14*math.Abs(want) {
+			t.Errorf("Asinh(%g) = %g; want %g", r, x64, want)
+		}
+
+		r = 1 + 100*rand.Float64()
+		x64, _ = bigfloat.Acosh(big.NewFloat(r)).Float64()
+		if want := math.Acosh(r); math.Abs(x64-want) > 1e-14*math.Abs(want) {
+			t.Errorf("Acosh(%g) = %g; want %g", r, x64, want)
+		}
+
+		r = 2*rand.Float64() - 1
+		x64, _ = bigfloat.Atanh(big.NewFloat(r)).Float64()
+		if want := math.Atanh(r); math.Abs(x64-want) > 1e-14*math.Abs(want) {
+			t.Errorf("Atanh(%g) = %g; want %g", r, x64, want)
+		}
+	}
+}
+
+func TestInverseHyperbolicSpecialValues(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		x    *big.Float
+		want float64
+	}{
+		{"Asinh(+0)", bigfloat.Asinh(big.NewFloat(+0.0)), math.Asinh(+0.0)},
+		{"Asinh(+Inf)", bigfloat.Asinh(big.NewFloat(math.Inf(+1))), math.Asinh(math.Inf(+1))},
+		{"Asinh(-Inf)", bigfloat.Asinh(big.NewFloat(math.Inf(-1))), math.Asinh(math.Inf(-1))},
+		{"Acosh(1)", bigfloat.Acosh(big.NewFloat(1)), math.Acosh(1)},
+		{"Acosh(+Inf)", bigfloat.Acosh(big.NewFloat(math.Inf(+1))), math.Acosh(math.Inf(+1))},
+		{"Atanh(+0)", bigfloat.Atanh(big.NewFloat(+0.0)), math.Atanh(+0.0)},
+	} {
+		x64, acc := test.x.Float64()
+		if x64 != test.want || acc != big.Exact {
+			t.Errorf("%s =\n got %g (%s);\nwant %g (Exact)", test.name, x64, acc, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		name string
+		f    func(*big.Float) *big.Float
+		z    float64
+	}{
+		{"Acosh", bigfloat.Acosh, 0.5},
+		{"Acosh", bigfloat.Acosh, math.Inf(-1)},
+		{"Atanh", bigfloat.Atanh, 1},
+		{"Atanh", bigfloat.Atanh, -1.5},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s(%g) did not panic", test.name, test.z)
+				}
+			}()
+			test.f(big.NewFloat(test.z))
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkSinh(b *testing.B) {
+	z := big.NewFloat(1.5).SetPrec(1e5)
+	_ = bigfloat.Sinh(z) // fill pi cache before benchmarking
+
+	for _, prec := range []uint{1e2, 1e3, 1e4, 1e5} {
+		z = big.NewFloat(1.5).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Sinh(z)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package bigfloat
+
+import "math/big"
+
+// Hypot returns a big.Float representation of √(p² + q²), computed
+// without overflowing or underflowing the big.Float exponent range in
+// the intermediate steps. Precision is the larger of the precisions of
+// the arguments. The function returns +Inf if p or q is ±Inf, and 0
+// when p = q = 0.
+func Hypot(p, q *big.Float) *big.Float {
+
+	prec := p.Prec()
+	if q.Prec() > prec {
+		prec = q.Prec()
+	}
+
+	// Hypot(±Inf, q) = Hypot(p, ±Inf) = +Inf
+	if p.IsInf() || q.IsInf() {
+		return new(big.Float).SetPrec(prec).SetInf(false)
+	}
+
+	// a = max(|p|, |q|), b = min(|p|, |q|)
+	a := new(big.Float).SetPrec(prec + 64).Abs(p)
+	b := new(big.Float).SetPrec(prec + 64).Abs(q)
+	if a.Cmp(b) < 0 {
+		a, b = b, a
+	}
+
+	// Hypot(0, 0) = 0
+	if a.Sign() == 0 {
+		return new(big.Float).SetPrec(prec)
+	}
+
+	// Compute √(a² + b²) as a·√(1 + (b/a)²), so that the intermediate
+	// values stay close to 1.
+	b.Quo(b, a)
+	b.Mul(b, b)
+	b.Add(b, big.NewFloat(1))
+
+	x := Sqrt(b)
+	return x.Mul(x, a).SetPrec(prec)
+}
@@ -0,0 +1,93 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestHypot(t *testing.T) {
+	for _, test := range []struct {
+		p, q string
+		want string
+	}{
+		{"3", "4", "5"},
+		{"-5", "12", "13"},
+		{"8", "-15", "17"},
+		{"0", "-2.5", "2.5"},
+		{"1p-500", "0", "1p-500"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			p := new(big.Float).SetPrec(prec)
+			p.Parse(test.p, 10)
+			q := new(big.Float).SetPrec(prec)
+			q.Parse(test.q, 10)
+
+			x := bigfloat.Hypot(p, q)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Hypot(%v, %v) =\ngot  %g;\nwant %g", prec, test.p, test.q, x, want)
+			}
+		}
+	}
+}
+
+func TestHypotSqrt2(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 500, 1000} {
+		one := big.NewFloat(1).SetPrec(prec)
+		two := big.NewFloat(2).SetPrec(prec)
+
+		x := bigfloat.Hypot(one, one)
+		want := bigfloat.Sqrt(two)
+
+		if x.Cmp(want) != 0 {
+			t.Errorf("prec = %d, Hypot(1, 1) =\ngot  %g;\nwant %g", prec, x, want)
+		}
+	}
+}
+
+// p² and q² are outside the big.Float exponent range, but the result
+// is not.
+func TestHypotExponentLimits(t *testing.T) {
+	for _, exp := range []int{big.MaxExp - 2, big.MinExp + 2} {
+		for _, prec := range []uint{24, 53, 100, 500} {
+			p := new(big.Float).SetMantExp(big.NewFloat(1).SetPrec(prec), exp)
+			q := new(big.Float).SetMantExp(big.NewFloat(1).SetPrec(prec), exp)
+
+			x := bigfloat.Hypot(p, q)
+
+			// want = √2·2**exp
+			want := bigfloat.Sqrt(big.NewFloat(2).SetPrec(prec))
+			want.SetMantExp(want, exp)
+
+			if x.IsInf() || x.Sign() == 0 || x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Hypot(2**%d, 2**%d) =\ngot  %g;\nwant %g", prec, exp, exp, x, want)
+			}
+		}
+	}
+}
+
+func TestHypotSpecialValues(t *testing.T) {
+	for _, f := range []struct {
+		p, q float64
+	}{
+		{0, 0},
+		{math.Copysign(0, -1), 0},
+		{math.Inf(+1), 2},
+		{2, math.Inf(-1)},
+		{math.Inf(-1), math.Inf(+1)},
+	} {
+		p, q := big.NewFloat(f.p), big.NewFloat(f.q)
+		x := bigfloat.Hypot(p, q)
+		x64, acc := x.Float64()
+		want := math.Hypot(f.p, f.q)
+		if x64 != want || x.Signbit() || acc != big.Exact {
+			t.Errorf("Hypot(%g, %g) =\n got %g (%s);\nwant %g (Exact)", f.p, f.q, x64, acc, want)
+		}
+	}
+}
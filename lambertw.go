@@ -0,0 +1,170 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// LambertW returns a big.Float representation of the principal branch
+// W₀ of the Lambert W function, the solution w >= -1 of
+//
+//	w·eʷ = z
+//
+// Precision is the same as the one of the argument. The function
+// panics if z < -1/e, returns ±0 when z = ±0, and +Inf when z = +Inf.
+// Since -1/e is not representable, a z that is within the rounding
+// error of -1/e at its precision is taken to be the branch point, and
+// the result is -1.
+func LambertW(z *big.Float) *big.Float {
+
+	// LambertW(±0) = ±0, LambertW(+Inf) = +Inf
+	if z.Sign() == 0 || (z.IsInf() && z.Sign() > 0) {
+		return new(big.Float).Copy(z)
+	}
+
+	// W(z) = z - z² + ..., so if z² is below the precision the result
+	// rounds to z
+	exp := z.MantExp(nil)
+	if 2*(-exp) > int(z.Prec())+2 {
+		return new(big.Float).Copy(z)
+	}
+
+	prec := z.Prec() + 64 // guard digits
+
+	// p = 1 + e·z is 0 at the branch point, and the distance of the
+	// root from -1 is about √(2p). Computing p loses -log2|p| bits.
+	p := lambertP(z, prec)
+	if p.Sign() != 0 {
+		if exp := p.MantExp(nil); exp < 0 {
+			p = lambertP(z, prec+uint(-exp))
+		}
+	}
+
+	// Rounding -1/e to z's precision moves p by less than 2**-prec,
+	// so panic on z < -1/e only if p is below that, and otherwise
+	// take z = -1/e.
+	branch := p.Sign() == 0 || p.MantExp(nil) <= -int(z.Prec())
+	if p.Sign() < 0 && !branch {
+		panic("LambertW: argument is less than -1/e")
+	}
+	if branch {
+		return big.NewFloat(-1).SetPrec(z.Prec())
+	}
+
+	// Close to the branch point w+1 is about √(2p), and f'(w) = (w+1)·eʷ
+	// is just as small, so Newton's method converges on the bits of
+	// w+1 rather than those of w. Iterate on v = w+1 instead, and
+	// evaluate f/f' with as many more bits as v has leading zeros,
+	// since that's how many are lost when subtracting w·eʷ and z.
+	q := new(big.Float).SetPrec(prec).Mul(p, big.NewFloat(2))
+	q = Sqrt(q)
+	s := 0
+	if exp := q.MantExp(nil); exp < 0 {
+		s = -exp
+	}
+
+	guess, bits := lambertGuess(z, q, prec)
+	one := big.NewFloat(1)
+
+	// f(w)/f'(w) = (w·eʷ - z)/((w+1)·eʷ) = (w - z·e⁻ʷ)/(w+1)
+	if s == 0 {
+		f := func(t *big.Float) *big.Float {
+			x := Exp(new(big.Float).Neg(t))
+			x.Mul(x, z)
+			x.Sub(t, x)
+			return x.Quo(x, new(big.Float).SetPrec(t.Prec()).Add(t, one))
+		}
+		guess.SetPrec(uint(bits))
+		return newton(f, guess, prec).SetPrec(z.Prec())
+	}
+
+	f := func(v *big.Float) *big.Float {
+		w := new(big.Float).SetPrec(v.Prec()+uint(s)).Sub(v, one)
+		x := Exp(new(big.Float).Neg(w))
+		x.Mul(x, z)
+		x.Sub(w, x)
+		return x.Quo(x, v)
+	}
+	v := guess.Add(guess, one)
+	if bits -= s; bits < 1 {
+		bits = 1
+	}
+	v.SetPrec(uint(bits))
+	v = newton(f, v, prec)
+
+	return v.Sub(v, one).SetPrec(z.Prec())
+}
+
+// lambertP returns 1 + e·z computed with prec bits of precision.
+func lambertP(z *big.Float, prec uint) *big.Float {
+	p := E(prec)
+	p.Mul(p, z)
+	return p.Add(p, big.NewFloat(1))
+}
+
+// lambertGuess returns an initial approximation of W₀(z), and the
+// number of bits of it that are correct. q is √(2(1 + e·z)).
+func lambertGuess(z, q *big.Float, prec uint) (*big.Float, int) {
+
+	// Close to the branch point use the series
+	//   W(z) = -1 + q - q²/3 + 11q³/72 + ...
+	// computed with high precision, since q may be too small for a
+	// float64.
+	if qexp := q.MantExp(nil); qexp < -20 {
+		x := new(big.Float).SetPrec(prec).Mul(q, q)
+		x.Mul(x, q)
+		x.Mul(x, big.NewFloat(11))
+		x.Quo(x, big.NewFloat(72)) // 11q³/72
+		t := new(big.Float).SetPrec(prec).Mul(q, q)
+		t.Quo(t, big.NewFloat(3))
+		x.Sub(x, t) // - q²/3
+		x.Add(x, q) // + q
+		x.Sub(x, big.NewFloat(1))
+		return x, 4*(-qexp) - 4
+	}
+
+	// Close to 0 use W(z) = z - z² + O(z³)
+	if exp := z.MantExp(nil); exp < -20 {
+		x := new(big.Float).SetPrec(prec).Mul(z, z)
+		x.Sub(z, x)
+		return x, 2*(-exp) - 2
+	}
+
+	zf, _ := z.Float64()
+	if math.IsInf(zf, 0) || zf > 1e300 {
+		// For large z, w = log(z) - log(w) converges to W(z), gaining
+		// about log2(w) bits each step.
+		lf, _ := Log(new(big.Float).SetPrec(64).Set(z)).Float64()
+		w := lf - math.Log(lf)
+		for i := 0; i < 10; i++ {
+			w = lf - math.Log(w)
+		}
+		return big.NewFloat(w), 32
+	}
+
+	// Otherwise start from a float64 approximation refined with
+	// Halley's method.
+	var w float64
+	switch {
+	case zf < -0.25:
+		qf, _ := q.Float64()
+		w = -1 + qf - qf*qf/3
+	case zf < 3:
+		w = math.Log1p(zf)
+	default:
+		l1 := math.Log(zf)
+		l2 := math.Log(l1)
+		w = l1 - l2 + l2/l1
+	}
+	for i := 0; i < 20; i++ {
+		ew := math.Exp(w)
+		f := w*ew - zf
+		d := ew*(w+1) - (w+2)*f/(2*w+2)
+		if d == 0 || f == 0 {
+			break
+		}
+		w -= f / d
+	}
+
+	return big.NewFloat(w), 32
+}
@@ -0,0 +1,153 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestLambertW(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"1", "0.56714329040978387299996866221035554975381578718651250813513107922304579308668456669321944696175229455763802497286678978545235846594007299560851643928999461431157149295980359437669847463560613422684613569895704539776248557078658773370635663330123843045563542978608509015429081920856055752374819658465950807273089050157336183159607066710803928391836015"},
+		{"2", "0.85260550201372549134647241469531746689845330015140350877210739465251506567426304489657737835024948473345039726918041198347616688519535988261989843649983439403303248497431193270283830088831331612490457275446692022202920766397773166483118711837190406102742210132371635434516212082843150072502671907310481195668574559879759734744115445716196999388993542"},
+		{"10", "1.7455280027406993830743012648753899115352881290809413313222060485555572599415517049895235107788830754000707718090453748743714536793069690265992752975486704609440709500470626488527183781047491618535141276474320695369314065379984599762642413277500769923711118271920986563623615665633655465449701043839053090670162673912182296897444726569537443634940857"},
+		{"0.5", "0.35173371124919582602490930092995106517146421551711180404664384610996061072033871089683230383219156927376930669850886190803585316997788643233590840773468223380572220447018856662209044898396617730718628398334968255637029909849508023092913106917302677517880206623536491536819497695390221318699938400962983659843973925990733189490402115826421796066451297"},
+		{"-0.25", "-0.35740295618138890306881110405590475331659055507601204362762044858967140259614579628961685134444118514972510003129829014363079137615153915679879519089416183922405311669076159806886582644984474335477565998189259225194441270708746581164752453594256723176777251814270218652834918878204005374522495385663211531867815424702132760136406363751632006987382406"},
+		{"-0.3671875", "-0.93988639805243454196954641321072348096296895907155583760162585948215014081187269016119516957672404583594778022545225233115677486091163709830905079590623016530244183522685423577765944079681832050661899814979620771062916707061807310211389261604628307470855231715376868091069535840668936791803680205451650028150851786901047716639862824217891898359770236"},
+		{"0.0009765625", "0.00097561022024675304998187487109890989177176540629521592175448503205586561077842192443103343077775973822432543191248469499382085562180482028699137690481345552369550320443733050803796953570069849224486062006737400297056631893526096700422886884112080210644466163426965813776501744914926297483783199008078293447522605136889188976840312344315486996568499720"},
+		{"10000000000", "20.028685413304950781234306071814887297491649690237633733012877327959525463636281111687174208974193211671349299398826774536255605478647082985940771489206720815578253253543069276698154154561380104540753165299154319943066327746723664623993001390934043870599571267066439084296796975714579603222820611758460567733483076477957116060208478067085967993920899"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+			if !exact(z, test.z) {
+				continue
+			}
+
+			if x := bigfloat.LambertW(z); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, LambertW(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestLambertWE(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		want := big.NewFloat(1).SetPrec(prec)
+		if x := bigfloat.LambertW(bigfloat.E(prec)); x.Cmp(want) != 0 {
+			t.Errorf("prec = %d, LambertW(e) =\ngot  %g;\nwant %g", prec, x, want)
+		}
+	}
+}
+
+// -1/e rounded to nearest is the branch point, and the result is -1.
+func TestLambertWBranchPoint(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		z := bigfloat.E(prec + 64)
+		z.Quo(big.NewFloat(-1), z).SetPrec(prec)
+
+		want := big.NewFloat(-1).SetPrec(prec)
+		if x := bigfloat.LambertW(z); x.Cmp(want) != 0 {
+			t.Errorf("prec = %d, LambertW(-1/e) =\ngot  %g;\nwant %g", prec, x, want)
+		}
+	}
+}
+
+// Just above the branch point W(z) is close to -1, and w+1 must keep
+// full relative precision.
+func TestLambertWNearBranchPoint(t *testing.T) {
+	for _, prec := range []uint{53, 100, 200, 500, 1000} {
+		for _, d := range []int{10, 40, int(prec / 2)} {
+			// z = -1/e + 2**-d, then check that w·eʷ = z
+			z := bigfloat.E(prec + 64)
+			z.Quo(big.NewFloat(-1), z)
+			z.Add(z, new(big.Float).SetMantExp(big.NewFloat(1), -d)).SetPrec(prec)
+
+			w := bigfloat.LambertW(z)
+			if w.Cmp(big.NewFloat(-1)) <= 0 || w.Sign() >= 0 {
+				t.Fatalf("prec = %d, LambertW(-1/e + 2**-%d) = %g; want in (-1, 0)", prec, d, w)
+			}
+
+			x := new(big.Float).SetPrec(2 * prec).Set(w)
+			x = bigfloat.Exp(x)
+			x.Mul(x, w)
+			x.Sub(x, z)
+			if x.Sign() != 0 && x.MantExp(nil) > -int(prec)+2 {
+				t.Errorf("prec = %d, LambertW(-1/e + 2**-%d) = %g: w·eʷ - z = %g", prec, d, w, x)
+			}
+		}
+	}
+}
+
+func TestLambertWPanics(t *testing.T) {
+	for _, f := range []float64{-0.5, -0.368, -1, math.Inf(-1)} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("LambertW(%g) did not panic", f)
+				}
+			}()
+			bigfloat.LambertW(big.NewFloat(f))
+		}()
+	}
+}
+
+func testLambertWFloat64(scale float64, nTests int, t *testing.T) {
+	for i := 0; i < nTests; i++ {
+		r := rand.Float64()*scale - 1/math.E
+		z := big.NewFloat(r).SetPrec(53)
+
+		// There's no Lambert W function in the Go math package, so
+		// check that w·eʷ = z instead.
+		w, _ := bigfloat.LambertW(z).Float64()
+		if x := w * math.Exp(w); math.Abs(x-r) > 1e-14*(math.Abs(r)+1) {
+			t.Errorf("LambertW(%g) = %g, but w·eʷ = %g", r, w, x)
+		}
+	}
+}
+
+func TestLambertWFloat64Small(t *testing.T) {
+	testLambertWFloat64(1, 300, t)
+}
+
+func TestLambertWFloat64Big(t *testing.T) {
+	testLambertWFloat64(1e6, 300, t)
+}
+
+func TestLambertWSpecialValues(t *testing.T) {
+	for _, f := range []float64{
+		+0.0,
+		-0.0,
+		math.Inf(+1),
+	} {
+		z := big.NewFloat(f).SetPrec(53)
+		x64, acc := bigfloat.LambertW(z).Float64()
+		if x64 != f || math.Signbit(x64) != math.Signbit(f) || acc != big.Exact {
+			t.Errorf("LambertW(%g) =\n got %g (%s);\nwant %g (Exact)", f, x64, acc, f)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkLambertW(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		z := big.NewFloat(2).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.LambertW(z)
+			}
+		})
+	}
+}
@@ -1,42 +1,65 @@
 package bigfloat
 
 import (
+	"context"
 	"math"
 	"math/big"
 )
 
 // Log returns a big.Float representation of the natural logarithm of
 // z. Precision is the same as the one of the argument. The function
-// panics if z is negative, returns -Inf when z = 0, and +Inf when z =
-// +Inf
+// panics if z is negative (including -Inf), returns -Inf when z = ±0,
+// +0 when z = 1, and +Inf when z = +Inf.
 func Log(z *big.Float) *big.Float {
+	x, err := LogContext(context.Background(), z)
+	if err != nil {
+		panic("Log: argument is negative")
+	}
+	return x
+}
+
+// LogContext is like Log, but it returns ErrNegative instead of
+// panicking when z is negative, and it stops the computation and
+// returns ctx.Err() if ctx is done before the result is ready. The
+// context is checked at the start of every iteration.
+func LogContext(ctx context.Context, z *big.Float) (*big.Float, error) {
 
-	// panic on negative z
+	// error on negative z
 	if z.Sign() == -1 {
-		panic("Log: argument is negative")
+		return nil, ErrNegative
 	}
 
-	// Log(0) = -Inf
+	// Log(±0) = -Inf
 	if z.Sign() == 0 {
-		return big.NewFloat(math.Inf(-1)).SetPrec(z.Prec())
+		return big.NewFloat(math.Inf(-1)).SetPrec(z.Prec()), nil
+	}
+
+	// Log(+Inf) = +Inf
+	if z.IsInf() {
+		return big.NewFloat(math.Inf(+1)).SetPrec(z.Prec()), nil
+	}
+
+	// Log(1) = +0, exactly: don't let the AGM iteration below leave a
+	// tiny residue
+	if z.Cmp(big.NewFloat(1)) == 0 {
+		return new(big.Float).SetPrec(z.Prec()), nil
 	}
 
 	prec := z.Prec() + 64 // guard digits
 
+	// When z is close to 1, log(z) is close to 0 and the AGM formula
+	// below loses about -log2|z-1| bits to cancellation. Compensate
+	// by adding that many more guard digits.
+	if d := new(big.Float).Sub(z, big.NewFloat(1)); d.Sign() != 0 {
+		if exp := d.MantExp(nil); exp < 0 {
+			prec += uint(-exp)
+		}
+	}
+
 	one := big.NewFloat(1).SetPrec(prec)
 	two := big.NewFloat(2).SetPrec(prec)
 	four := big.NewFloat(4).SetPrec(prec)
 
-	// Log(1) = 0
-	if z.Cmp(one) == 0 {
-		return big.NewFloat(0).SetPrec(z.Prec())
-	}
-
-	// Log(+Inf) = +Inf
-	if z.IsInf() {
-		return big.NewFloat(math.Inf(+1)).SetPrec(z.Prec())
-	}
-
 	x := new(big.Float).SetPrec(prec)
 
 	// if 0 < z < 1 we compute log(z) as -log(1/z)
@@ -69,7 +92,10 @@ func Log(z *big.Float) *big.Float {
 	//     x >= 2**(prec/2),
 	// where prec is the desired precision (in bits)
 	pi := pi(prec)
-	agm := agm(one, x.Quo(four, x)) // agm = AGM(1, 4/x)
+	agm, err := agmContext(ctx, one, x.Quo(four, x)) // agm = AGM(1, 4/x)
+	if err != nil {
+		return nil, err
+	}
 
 	x.Quo(pi, x.Mul(two, agm)) // reuse x, we don't need it
 
@@ -81,5 +107,135 @@ func Log(z *big.Float) *big.Float {
 	// reuse lim to reduce allocations.
 	x.Mul(x, lim.SetMantExp(one, -k))
 
-	return x.SetPrec(z.Prec())
+	return x.SetPrec(z.Prec()), nil
+}
+
+// Log1p returns a big.Float representation of the natural logarithm
+// of 1 + z. Precision is the same as the one of the argument. Unlike
+// Log(1 + z), the result is accurate when z is close to 0. The
+// function panics if z < -1, returns -Inf when z = -1, ±0 when z =
+// ±0, and +Inf when z = +Inf.
+func Log1p(z *big.Float) *big.Float {
+
+	// panic on z < -1
+	if z.Cmp(big.NewFloat(-1)) < 0 {
+		panic("Log1p: argument is less than -1")
+	}
+
+	// Log1p(±0) = ±0, Log1p(+Inf) = +Inf
+	if z.Sign() == 0 || z.IsInf() {
+		return new(big.Float).Copy(z)
+	}
+
+	// For |z| >= 0.5 there's no cancellation in log(1 + z), and 1 + z
+	// is exact for -1 <= z <= -0.5.
+	exp := z.MantExp(nil)
+	if exp > -1 {
+		x := new(big.Float).SetPrec(z.Prec()+64).Add(z, big.NewFloat(1))
+		return Log(x).SetPrec(z.Prec())
+	}
+
+	// log1p(z) = z - z²/2 + ..., so if z² is below the precision the
+	// result rounds to z
+	if 2*(-exp) > int(z.Prec())+2 {
+		return new(big.Float).Copy(z)
+	}
+
+	// Shrink z using
+	//   log1p(x) = 2·log1p(x / (1 + √(1 + x)))
+	// until |x| < 2**-steps, and keep track of the number of halvings.
+	// Each step loses a bit, so add as many guard digits.
+	steps := reductionSteps(z.Prec())
+	prec := z.Prec() + 64 + uint(steps)
+
+	one := big.NewFloat(1)
+	x := new(big.Float).SetPrec(prec).Set(z)
+	t := new(big.Float).SetPrec(prec)
+	k := 0
+	for x.MantExp(nil) > -steps {
+		t.Add(x, one)
+		t = Sqrt(t)
+		t.Add(t, one)
+		x.Quo(x, t)
+		k++
+	}
+
+	// log1p(x) = 2·atanh(u) = 2·Σ u²ⁿ⁺¹/(2n+1), with u = x/(2 + x)
+	u := new(big.Float).SetPrec(prec).Add(x, big.NewFloat(2))
+	u.Quo(x, u)
+	u2 := new(big.Float).SetPrec(prec).Mul(u, u)
+	pow := new(big.Float).SetPrec(prec).Set(u)
+	term := new(big.Float).SetPrec(prec)
+	sum := new(big.Float).SetPrec(prec).Set(u)
+	for n := int64(1); ; n++ {
+		pow.Mul(pow, u2)
+		term.Quo(pow, new(big.Float).SetInt64(2*n+1))
+		if term.Sign() == 0 || term.MantExp(nil) < sum.MantExp(nil)-int(prec) {
+			break
+		}
+		sum.Add(sum, term)
+	}
+	sum.SetMantExp(sum, k+1) // scale back multiplying by 2**(k+1)
+
+	return sum.SetPrec(z.Prec())
+}
+
+// LogBase returns a big.Float representation of the logarithm of z
+// in the given base. Precision is the same as the one of the first
+// argument. The function panics if base <= 0 or base = 1, and
+// otherwise follows Log for the special values of z.
+func LogBase(z, base *big.Float) *big.Float {
+
+	if base.Sign() <= 0 {
+		panic("LogBase: base is not positive")
+	}
+	if base.Cmp(big.NewFloat(1)) == 0 {
+		panic("LogBase: base is 1")
+	}
+
+	// compute both logs with guard digits, so that the result of the
+	// division is correctly rounded
+	prec := z.Prec() + 64
+	x := Log(WithGuard(z, 64))
+	y := Log(new(big.Float).Copy(base).SetPrec(prec))
+
+	return x.Quo(x, y).SetPrec(z.Prec())
+}
+
+// Log2 returns a big.Float representation of the base-2 logarithm of
+// z. Precision is the same as the one of the argument. The function
+// follows Log for the special values of z.
+func Log2(z *big.Float) *big.Float {
+	prec := z.Prec() + 64 // guard digits
+	x := Log(WithGuard(z, 64))
+	return x.Quo(x, ln2(prec)).SetPrec(z.Prec())
+}
+
+// Log10 returns a big.Float representation of the base-10 logarithm
+// of z. Precision is the same as the one of the argument. The
+// function follows Log for the special values of z.
+func Log10(z *big.Float) *big.Float {
+	prec := z.Prec() + 64 // guard digits
+	x := Log(WithGuard(z, 64))
+	return x.Quo(x, ln10(prec)).SetPrec(z.Prec())
+}
+
+// ln2 returns log(2) to prec bits of precision
+func ln2(prec uint) *big.Float {
+	return ln2Cache.get(prec)
+}
+
+// computeLn2 computes log(2) to prec bits of precision
+func computeLn2(prec uint) *big.Float {
+	return Log(big.NewFloat(2).SetPrec(prec))
+}
+
+// ln10 returns log(10) to prec bits of precision
+func ln10(prec uint) *big.Float {
+	return ln10Cache.get(prec)
+}
+
+// computeLn10 computes log(10) to prec bits of precision
+func computeLn10(prec uint) *big.Float {
+	return Log(big.NewFloat(10).SetPrec(prec))
 }
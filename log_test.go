@@ -1,6 +1,7 @@
 package bigfloat_test
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"math/big"
@@ -45,6 +46,252 @@ func TestLog(t *testing.T) {
 	}
 }
 
+// Log(1+ε) with small ε used to lose about -log2(ε) bits to
+// cancellation. The 1 + 2**-e inputs are exactly representable only
+// when prec > e, so skip the lower precisions.
+func TestLogNearOne(t *testing.T) {
+	for _, test := range []struct {
+		e    int
+		want string
+	}{
+		{10, "0.00097608597305545889596082490801718667261183433378453623775859827440037212402587916395162759415915721790828523309876030934343926530395286819453731101641094088130006496793432111354748800225841711252776135377194868999313468379512875538784883763982331377239724234848513688620072728324181886243209877680511717443184029949474774144524573279588709225074621810"},
+		{50, "8.8817841970012483790845272396698709387977149462979706971413566604140022904848703463683542229413657059802237799709510325803515802853285479694937009406687955214636656819842321293183883524738708338204166612610299101691967770966403452402279213562725081996826964218598668840407756487958186398962356669908377333348911850063055491127345732489497461454689833e-16"},
+		{100, "7.8886090522101180541172856528247507890931337802366580156759008808848183064911571150241011028163381636091569065580887397838325642734182135347206049649339076394575570846659557027285501220039168764415587291717345723787384038266602216373345599804880685243036969189365428888675306224851882828164261009034465866636971980361058771761772137140803094163474612e-31"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			if prec <= uint(test.e) {
+				continue
+			}
+
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec).SetInt64(1)
+			z.Add(z, new(big.Float).SetMantExp(big.NewFloat(1), -test.e))
+
+			x := bigfloat.Log(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Log(1 + 2**-%d) =\ngot %g;\n want %g", prec, test.e, x, want)
+			}
+		}
+	}
+}
+
+func TestLogBase(t *testing.T) {
+	for _, test := range []struct {
+		z, base string
+		want    string
+	}{
+		{"1000", "10", "3"},
+		{"8", "2", "3"},
+		{"0.125", "2", "-3"},
+		{"1e100", "10", "100"},
+		{"1024", "0.5", "-10"},
+
+		{"10", "2", "3.3219280948873623478703194294893901758648313930245806120547563958159347766086252158501397433593701550996573717102502518268240969842635268882753027729986553938519513526575055686430176091900248916669414333740119031241873751097158664675401791896558067358307796884327258832749925224489023835599764173941379280097727566863554779014867450578458847802710423"},
+		{"2", "10", "0.30102999566398119521373889472449302676818988146210854131042746112710818927442450948692725211818617204068447719143099537909476788113352350599969233370469557506450296425419340266181973431160294350118390289817858261715443953186192904635388469952023931084961246254040026331259462147884584731828267268398232619654279350763131754835092713896494691778576892"},
+		{"100", "3", "4.1918065485787692085931350440428025012150360135958602338470906772683549551438812574335316046179624739997028046267039861647001057142583165372987918512383098312361823926004293970378025031184883015924059904826368021209225522037331082981216523342010876364772208027433031642156360613370629478037018787280270616635342226778699486690571552661746337268872421"},
+		{"81", "0.25", "-3.1699250014423123629074778878956330175196288153849621209115053090821964555887171250445609498361764841819613249501183346874351048821218496442841679012433965989873151844771704688831650726054953706139561033751991089474533669249224728497700095163621353922632809614261646466562524890497341267796029674468471567324956780237954012932625268446726683642540212"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+			base := new(big.Float).SetPrec(prec)
+			base.Parse(test.base, 10)
+			if !exact(z, test.z) || !exact(base, test.base) {
+				continue
+			}
+
+			x := bigfloat.LogBase(z, base)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, LogBase(%v, %v) =\ngot %g;\n want %g", prec, test.z, test.base, x, want)
+			}
+		}
+	}
+}
+
+func TestLog2(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"1", "0"},
+		{"2", "1"},
+		{"1024", "10"},
+		{"0.125", "-3"},
+		{"1p1000", "1000"},
+		{"1p-1000", "-1000"},
+
+		{"3", "1.5849625007211561814537389439478165087598144076924810604557526545410982277943585625222804749180882420909806624750591673437175524410609248221420839506216982994936575922385852344415825363027476853069780516875995544737266834624612364248850047581810676961316404807130823233281262445248670633898014837234235783662478390118977006466312634223363341821270106"},
+		{"10", "3.3219280948873623478703194294893901758648313930245806120547563958159347766086252158501397433593701550996573717102502518268240969842635268882753027729986553938519513526575055686430176091900248916669414333740119031241873751097158664675401791896558067358307796884327258832749925224489023835599764173941379280097727566863554779014867450578458847802710423"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Log2(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Log2(%v) =\ngot %g;\n want %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestLog10(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"1", "0"},
+		{"10", "1"},
+		{"100", "2"},
+		{"1e10", "10"},
+		{"1e50", "50"},
+		{"1e100", "100"},
+
+		{"2", "0.30102999566398119521373889472449302676818988146210854131042746112710818927442450948692725211818617204068447719143099537909476788113352350599969233370469557506450296425419340266181973431160294350118390289817858261715443953186192904635388469952023931084961246254040026331259462147884584731828267268398232619654279350763131754835092713896494691778576892"},
+		{"0.5", "-0.30102999566398119521373889472449302676818988146210854131042746112710818927442450948692725211818617204068447719143099537909476788113352350599969233370469557506450296425419340266181973431160294350118390289817858261715443953186192904635388469952023931084961246254040026331259462147884584731828267268398232619654279350763131754835092713896494691778576892"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+			if !exact(z, test.z) {
+				continue
+			}
+
+			x := bigfloat.Log10(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Log10(%v) =\ngot %g;\n want %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestLogBasePanics(t *testing.T) {
+	for _, base := range []float64{0, -2, 1} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("LogBase(2, %g) did not panic", base)
+				}
+			}()
+			bigfloat.LogBase(big.NewFloat(2), big.NewFloat(base))
+		}()
+	}
+}
+
+func TestLogContext(t *testing.T) {
+	for _, f := range []float64{0.5, 3, 100} {
+		z := big.NewFloat(f).SetPrec(1000)
+
+		x, err := bigfloat.LogContext(context.Background(), z)
+		if want := bigfloat.Log(z); err != nil || x.Cmp(want) != 0 {
+			t.Errorf("LogContext(%g) = %g, %v; want %g, nil", f, x, err, want)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if x, err := bigfloat.LogContext(ctx, z); err != context.Canceled {
+			t.Errorf("LogContext(cancelled, %g) = %g, %v; want nil, %v", f, x, err, context.Canceled)
+		}
+
+		ctx = &countCtx{context.Background(), 1}
+		if x, err := bigfloat.LogContext(ctx, z); err != context.Canceled {
+			t.Errorf("LogContext(cancelled after 1 iteration, %g) = %g, %v; want nil, %v", f, x, err, context.Canceled)
+		}
+	}
+
+	if _, err := bigfloat.LogContext(context.Background(), big.NewFloat(-1)); err != bigfloat.ErrNegative {
+		t.Errorf("LogContext(-1) returned error %v; want %v", err, bigfloat.ErrNegative)
+	}
+}
+
+func TestLog1p(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"1e-30", "9.9999999999999999999999999999950000000000000000000000000000033333333333333333333333333333308333333333333333333333333333353333333333333333333333333333316666666666666666666666666666680952380952380952380952380952368452380952380952380952380952392063492063492063492063492063482063492063492063492063492063501154401154401154401154401154392821067821067821068e-31"},
+		{"-1e-10", "-1.0000000000500000000033333333335833333333533333333350000000001428571428696428571439682539683539682539773448773457106782107551337551408979908986575646576271575646634470105063895908014081388168125474577027326543426069178499562535055472322148283310162032558819540045480924105591384045113506973467325192152868857986657427333583079183773283589405071630949e-10"},
+		{"0.3", "0.26236426446749105203549598688095439720416645613143414038571760969589205764705769916357745835089119042729040805047647299812262902690378025715898074787351606230078987215200736326076600635315679581434608943096212632876076247003433161083158970056139750010596225791318949394576986708921120180009657799292373764910523583848223555181136540892840884475379163"},
+		{"-0.7", "-1.2039728043259359926227462177618385029536109308060235242986335673300783164587435133623814502758662095539977549763283828910415212391522869356134854983315042788797444391421733136644261959818722386246509349921694662114832542456155509406432797199709634452712339903274675943588277934337486114813680828488065239318078087140162694465590485266704979927132734"},
+		{"2", "1.0986122886681096913952452369225257046474905578227494517346943336374942932186089668736157548137320887879700290659578657423680042259305198210528018707672774106031627691833813671793736988443609599037425703167959115211455919177506713470549401667755802222031702529468975606901065215056428681380363173732985777823669916547921318181490200301038236301222487"},
+		{"-0.999", "-6.9077552789821370520539743640530926228033044658863189280999837029027178290320574407079916152687948950259033521268587459002285763952484202699988621072963450684487216249766640425313996844786995955851805159268961331978865384900986668630946596602396310024232127298230954651468029448181744388582132006663153051425244011064252037941242056703229648685065660"},
+		{"1e-5", "0.0000099999500003333308333533331666680952255953492053492154400321075513304085470745220810293725332223728973481165127709069906083790110517296942414473689837980293884881738329901735534424349746524750966235781794459462381282693740395970343762404844216849096305813263399499466547309741434393700661639092398243701448741615163770535003531076900358472332361983520"},
+		{"0.375", "0.31845373111853461581024721359059959559520645085665141285652768065039275800415256625746472701337872878871128282642594132119900845132139572425729013589588017925945919419556079101853955206626322625568461345959379312967980473511485797536494153649348086903538317917287285419147626718202564634485299909627563651300206504472128348217275875761921617067611764"},
+		{"1e10", "23.025850930040456840174914546843975409344323219621065093666612176342392777725905753490924431959475046901741332487263640735166322471903580942175534918649111222723393727583441724567620904681729586415277050804717007917014213345580559099158775973331876050795037270961688408338460407297412229626014728810377143318522457229588731521206946251459906880161686"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+			if !exact(z, test.z) {
+				continue
+			}
+
+			x := bigfloat.Log1p(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Log1p(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestLog1pTiny(t *testing.T) {
+	// log(1 + z) = z - z²/2 + ... rounds to z at 200 bits, while
+	// computing 1 + z first rounds it to 1.
+	z := new(big.Float).SetPrec(200)
+	z.Parse("1e-70", 10)
+
+	if x := bigfloat.Log1p(z); x.Cmp(z) != 0 {
+		t.Errorf("Log1p(1e-70) = %g; want %g", x, z)
+	}
+
+	x := new(big.Float).SetPrec(200).Add(z, big.NewFloat(1))
+	if x = bigfloat.Log(x); x.Sign() != 0 {
+		t.Errorf("Log(1 + 1e-70) = %g is unexpectedly accurate", x)
+	}
+}
+
+func TestLog1pSpecialValues(t *testing.T) {
+	for _, f := range []float64{
+		+0.0,
+		-0.0,
+		-1.0,
+		math.Inf(+1),
+	} {
+		z := big.NewFloat(f).SetPrec(53)
+		x64, acc := bigfloat.Log1p(z).Float64()
+		want := math.Log1p(f)
+		if x64 != want || math.Signbit(x64) != math.Signbit(want) || acc != big.Exact {
+			t.Errorf("Log1p(%g) =\n got %g (%s);\nwant %g (Exact)", f, x64, acc, want)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Log1p(-2) did not panic")
+		}
+	}()
+	bigfloat.Log1p(big.NewFloat(-2))
+}
+
 func testLogFloat64(scale float64, nTests int, t *testing.T) {
 	for i := 0; i < nTests; i++ {
 		r := rand.Float64() * scale
@@ -96,6 +343,55 @@ func TestLogSpecialValues(t *testing.T) {
 	}
 }
 
+// Every special input of Log and Exp, and its exact result, with
+// the precision of the argument.
+func TestLogExpSpecialValues(t *testing.T) {
+	inf, ninf := math.Inf(+1), math.Inf(-1)
+	negZero := math.Copysign(0, -1)
+	for _, test := range []struct {
+		name string
+		f    func(*big.Float) *big.Float
+		z    float64
+		want float64
+	}{
+		{"Log", bigfloat.Log, 1, +0.0},
+		{"Log", bigfloat.Log, +0.0, ninf},
+		{"Log", bigfloat.Log, negZero, ninf},
+		{"Log", bigfloat.Log, inf, inf},
+		{"Exp", bigfloat.Exp, +0.0, 1},
+		{"Exp", bigfloat.Exp, negZero, 1},
+		{"Exp", bigfloat.Exp, inf, inf},
+		{"Exp", bigfloat.Exp, ninf, +0.0},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 500, 1000} {
+			for _, mode := range []big.RoundingMode{big.ToNearestEven, big.ToZero, big.ToPositiveInf} {
+				z := big.NewFloat(test.z).SetPrec(prec).SetMode(mode)
+				x := test.f(z)
+				x64, acc := x.Float64()
+				if x64 != test.want || math.Signbit(x64) != math.Signbit(test.want) || acc != big.Exact || x.Prec() != prec {
+					t.Errorf("prec = %d, %s(%g) = %g (%s, prec = %d); want %g (Exact, prec = %d)", prec, test.name, test.z, x64, acc, x.Prec(), test.want, prec)
+				}
+			}
+		}
+	}
+
+	// Log is not defined for negative arguments
+	for _, f := range []float64{-1, -1e-300, ninf} {
+		z := big.NewFloat(f)
+		if _, err := bigfloat.LogContext(context.Background(), z); err != bigfloat.ErrNegative {
+			t.Errorf("LogContext(%g) returned error %v; want ErrNegative", f, err)
+		}
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Log(%g) did not panic", f)
+				}
+			}()
+			bigfloat.Log(z)
+		}()
+	}
+}
+
 // ---------- Benchmarks ----------
 
 func BenchmarkLog(b *testing.B) {
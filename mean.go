@@ -0,0 +1,177 @@
+package bigfloat
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// GeometricMean returns a big.Float representation of the geometric
+// mean of vals, (x₁·x₂···xₙ)^(1/n). Precision is the largest of the
+// precisions of the elements. The mean is computed from the logarithms
+// of the elements, so the product is never formed and can't overflow.
+// The function panics if vals is empty or if any of its elements is
+// not positive, and returns +Inf if any of the elements is +Inf.
+func GeometricMean(vals []*big.Float) *big.Float {
+
+	if len(vals) == 0 {
+		panic("GeometricMean: empty slice")
+	}
+
+	var prec uint
+	inf := false
+	for i, x := range vals {
+		if x.Sign() <= 0 {
+			panic(fmt.Sprintf("GeometricMean: argument %d is not positive", i))
+		}
+		if x.IsInf() {
+			inf = true
+		}
+		if x.Prec() > prec {
+			prec = x.Prec()
+		}
+	}
+
+	if inf {
+		return new(big.Float).SetPrec(prec).SetInf(false)
+	}
+
+	// each of the n logarithms carries a rounding error, so add
+	// log2(n) more guard digits
+	n := int64(len(vals))
+	p := prec + 64 + uint(bits.Len64(uint64(n))) // guard digits
+
+	// With xᵢ = mᵢ·2**eᵢ, the mean of the logarithms is
+	//   (e·log(2) + Σ log(mᵢ))/n,    where e = Σ eᵢ
+	// Summing the exponents separately keeps the logarithms small, so
+	// that they don't need more guard digits for large exponents.
+	var e int64
+	s := new(big.Float).SetPrec(p)
+	for _, x := range vals {
+		mant := new(big.Float)
+		e += int64(x.MantExp(mant))
+		s.Add(s, Log(mant.SetPrec(p)))
+	}
+
+	// split e = q·n + r with 0 <= r < n, so that 2**q can be re-attached
+	// exactly and the argument of Exp stays small
+	q, r := e/n, e%n
+	if r < 0 {
+		q, r = q-1, r+n
+	}
+	t := ln2(p)
+	t.Mul(t, new(big.Float).SetInt64(r))
+	t.Add(t, s)
+	t.Quo(t, new(big.Float).SetInt64(n))
+
+	x := Exp(t)
+	return x.SetMantExp(x, int(q)).SetPrec(prec)
+}
+
+// HarmonicMean returns a big.Float representation of the harmonic
+// mean of vals, n/(1/x₁ + 1/x₂ + ... + 1/xₙ). Precision is the largest
+// of the precisions of the elements. The reciprocals are added as in
+// Sum, with 64 more guard digits. The function panics if vals is empty
+// or if any of its elements is not positive, and returns +Inf if all
+// the elements are +Inf.
+func HarmonicMean(vals []*big.Float) *big.Float {
+
+	if len(vals) == 0 {
+		panic("HarmonicMean: empty slice")
+	}
+
+	for i, x := range vals {
+		if x.Sign() <= 0 {
+			panic(fmt.Sprintf("HarmonicMean: argument %d is not positive", i))
+		}
+	}
+
+	prec, _, _ := scanSlice(vals)
+	p := prec + 64 // guard digits
+
+	one := big.NewFloat(1)
+	recips := make([]*big.Float, len(vals))
+	for i, x := range vals {
+		recips[i] = new(big.Float).SetPrec(p).Quo(one, x) // 0 for +Inf
+	}
+
+	s := compensatedSum(recips, p)
+	n := new(big.Float).SetInt64(int64(len(vals)))
+	return s.Quo(n, s).SetPrec(prec)
+}
+
+// Mean returns a big.Float representation of the arithmetic mean of
+// vals, (x₁ + x₂ + ... + xₙ)/n. Precision is the largest of the
+// precisions of the elements. The sum is computed as in Sum, with 64
+// more guard digits, so the mean is accurate even when the elements
+// cancel each other. The function panics if vals is empty or if it
+// contains both +Inf and -Inf.
+func Mean(vals []*big.Float) *big.Float {
+
+	if len(vals) == 0 {
+		panic("Mean: empty slice")
+	}
+
+	prec, posInf, negInf := scanSlice(vals)
+	if posInf && negInf {
+		panic("Mean: sum of +Inf and -Inf")
+	}
+	if posInf || negInf {
+		return new(big.Float).SetPrec(prec).SetInf(negInf)
+	}
+
+	return mean(vals, prec+64).SetPrec(prec)
+}
+
+// mean returns the mean of the finite elements of vals, rounded to
+// prec bits.
+func mean(vals []*big.Float, prec uint) *big.Float {
+	s := compensatedSum(vals, prec)
+	return s.Quo(s, new(big.Float).SetInt64(int64(len(vals))))
+}
+
+// Variance returns a big.Float representation of the variance of
+// vals. If sample is false, it's the population variance
+//
+//	σ² = Σ (xᵢ - μ)²/n
+//
+// where μ is the mean of vals, and if sample is true, it's the
+// sample variance, with n-1 in place of n. Precision is the largest of
+// the precisions of the elements. The variance is computed in two
+// passes, first the mean and then the sum of the squared deviations
+// from it, which doesn't suffer from the cancellation of the formula
+// Σ xᵢ²/n - μ², even when the elements are large and close to each
+// other. The function panics if vals is empty, if sample is true and
+// vals has only one element, or if any of the elements is infinite.
+func Variance(vals []*big.Float, sample bool) *big.Float {
+
+	if len(vals) == 0 {
+		panic("Variance: empty slice")
+	}
+	if sample && len(vals) < 2 {
+		panic("Variance: sample variance of a single value")
+	}
+
+	for i, x := range vals {
+		if x.IsInf() {
+			panic(fmt.Sprintf("Variance: argument %d is infinite", i))
+		}
+	}
+
+	prec, _, _ := scanSlice(vals)
+	p := prec + 64 // guard digits
+
+	m := mean(vals, p)
+	sq := make([]*big.Float, len(vals))
+	for i, x := range vals {
+		d := new(big.Float).SetPrec(p).Sub(x, m)
+		sq[i] = d.Mul(d, d)
+	}
+
+	n := int64(len(vals))
+	if sample {
+		n--
+	}
+	s := compensatedSum(sq, p)
+	return s.Quo(s, new(big.Float).SetInt64(n)).SetPrec(prec)
+}
@@ -0,0 +1,311 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestGeometricMean(t *testing.T) {
+	for _, test := range []struct {
+		vals []float64
+		want string
+	}{
+		{[]float64{1, 2, 3, 4, 5}, "2.6051710846973518923257669239286163281273071623632802106933001835526198586424398331493038146888064480798846993659501931717334650144118480435933026208496479913900224686787537969117261879223910771016606630648282529129553771045192934489263194213529348271133489568016999559578663091858589995966543488107028909864893968313146075390105079334052820425474901"},
+		{[]float64{2, 7, 1000, 3}, "14.315691227432644220206105196583648380006737046704022123512145100301707302216114666468380692605437091687352213349372611626314169118390042380997030658177288498257304751215337473035443629121808244073917035322769993280485431903754791076408950336729527407190863891855573359820345785017358141427643569405920986298396336709827780520010306475390335572378824"},
+		{[]float64{2, 8}, "4"},
+		{[]float64{1, 2, 4, 8, 16}, "4"},
+		{[]float64{0.125, 8}, "1"},
+		{[]float64{math.Pi}, "3.141592653589793115997963468544185161590576171875"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			var vals []*big.Float
+			for _, f := range test.vals {
+				vals = append(vals, big.NewFloat(f).SetPrec(prec))
+			}
+			x := bigfloat.GeometricMean(vals)
+
+			if x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, GeometricMean(%v) =\ngot  %g (prec = %d);\nwant %g", prec, test.vals, x, x.Prec(), want)
+			}
+		}
+	}
+}
+
+// The mean must agree with the n-th root of the product, to within
+// the rounding errors of the two.
+func TestGeometricMeanAgreesWithRoot(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, prec := range []uint{53, 100, 500, 1000} {
+		for i := 0; i < 50; i++ {
+			n := rnd.Intn(6) + 1
+			prod := big.NewFloat(1).SetPrec(2*prec + 64)
+			var vals []*big.Float
+			for j := 0; j < n; j++ {
+				x := big.NewFloat(rnd.Float64()*100 + 0.01).SetPrec(prec)
+				prod.Mul(prod, x)
+				vals = append(vals, x)
+			}
+
+			x := bigfloat.GeometricMean(vals)
+			want := bigfloat.Root(prod, n).SetPrec(prec)
+
+			d := new(big.Float).Sub(x, want)
+			if d.Sign() != 0 && d.MantExp(nil) > want.MantExp(nil)-int(prec)+1 {
+				t.Errorf("prec = %d, GeometricMean(%v) =\ngot  %g;\nwant %g", prec, vals, x, want)
+			}
+		}
+	}
+}
+
+// The product of the elements of the slice overflows the big.Float
+// exponent range, but their mean doesn't.
+func TestGeometricMeanLargeExponents(t *testing.T) {
+	for _, prec := range []uint{53, 100, 1000} {
+		x := big.NewFloat(3).SetPrec(prec)
+		x.SetMantExp(x, 1<<30)
+		y := big.NewFloat(3).SetPrec(prec)
+		y.SetMantExp(y, -(1 << 30))
+
+		if m := bigfloat.GeometricMean([]*big.Float{x, x, x}); m.Cmp(x) != 0 {
+			t.Errorf("prec = %d, GeometricMean(x, x, x) = %g; want %g", prec, m, x)
+		}
+		if m := bigfloat.GeometricMean([]*big.Float{x, y}); m.Cmp(big.NewFloat(3)) != 0 {
+			t.Errorf("prec = %d, GeometricMean(3·2**(2**30), 3·2**-(2**30)) = %g; want 3", prec, m)
+		}
+	}
+}
+
+func TestGeometricMeanPrec(t *testing.T) {
+	vals := []*big.Float{
+		big.NewFloat(2).SetPrec(53),
+		big.NewFloat(3).SetPrec(500),
+		big.NewFloat(5).SetPrec(100),
+	}
+	if x := bigfloat.GeometricMean(vals); x.Prec() != 500 {
+		t.Errorf("GeometricMean returned precision %d; want 500", x.Prec())
+	}
+
+	vals = append(vals, big.NewFloat(math.Inf(+1)))
+	if x := bigfloat.GeometricMean(vals); !x.IsInf() || x.Sign() < 0 || x.Prec() != 500 {
+		t.Errorf("GeometricMean(..., +Inf) = %g (prec = %d); want +Inf (prec = 500)", x, x.Prec())
+	}
+}
+
+func TestGeometricMeanPanics(t *testing.T) {
+	for _, vals := range [][]*big.Float{
+		nil,
+		{big.NewFloat(1), big.NewFloat(0)},
+		{big.NewFloat(-2), big.NewFloat(-2)},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("GeometricMean(%v) did not panic", vals)
+				}
+			}()
+			bigfloat.GeometricMean(vals)
+		}()
+	}
+}
+
+func TestMean(t *testing.T) {
+	for _, test := range []struct {
+		vals []float64
+		num  int64 // the mean is num/den
+		den  int64
+	}{
+		{[]float64{1, 2, 3, 4}, 5, 2},
+		{[]float64{1, 2, 4}, 7, 3},
+		{[]float64{2, 4, 4, 4, 5, 5, 7, 9}, 5, 1},
+		{[]float64{-1, 1}, 0, 1},
+		{[]float64{1e300, 1, -1e300}, 1, 3},
+		{[]float64{0.1}, 1, 10},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			var vals []*big.Float
+			exact := new(big.Rat)
+			for _, f := range test.vals {
+				vals = append(vals, big.NewFloat(f).SetPrec(prec))
+				exact.Add(exact, new(big.Rat).SetFloat64(f))
+			}
+			exact.Quo(exact, big.NewRat(int64(len(vals)), 1))
+			want := new(big.Float).SetPrec(prec).SetRat(exact)
+
+			if x := bigfloat.Mean(vals); x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, Mean(%v) =\ngot  %g (prec = %d);\nwant %g", prec, test.vals, x, x.Prec(), want)
+			}
+		}
+	}
+}
+
+func TestVariance(t *testing.T) {
+	for _, test := range []struct {
+		vals       []float64
+		pop, sampl string
+	}{
+		{[]float64{2, 4, 4, 4, 5, 5, 7, 9}, "4", "4.5714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714285714286"},
+		{[]float64{1, 2, 3, 4}, "1.25", "1.6666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666667"},
+		{[]float64{3, 3, 3}, "0", "0"},
+		{[]float64{-1, 1}, "1", "2"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			var vals []*big.Float
+			for _, f := range test.vals {
+				vals = append(vals, big.NewFloat(f).SetPrec(prec))
+			}
+
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.pop, 10)
+			if x := bigfloat.Variance(vals, false); x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, Variance(%v, false) =\ngot  %g (prec = %d);\nwant %g", prec, test.vals, x, x.Prec(), want)
+			}
+			want.Parse(test.sampl, 10)
+			if x := bigfloat.Variance(vals, true); x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, Variance(%v, true) =\ngot  %g (prec = %d);\nwant %g", prec, test.vals, x, x.Prec(), want)
+			}
+		}
+	}
+}
+
+// 2**(prec-8) + 4, 7, 13, 16 have the variance of 4, 7, 13, 16, but
+// Σ xᵢ²/n - μ² loses it to cancellation.
+func TestVarianceLargeValues(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		offset := big.NewFloat(1).SetPrec(prec)
+		offset.SetMantExp(offset, int(prec)-8)
+
+		var vals []*big.Float
+		for _, k := range []float64{4, 7, 13, 16} {
+			vals = append(vals, new(big.Float).SetPrec(prec).Add(offset, big.NewFloat(k)))
+		}
+
+		sum := new(big.Float).SetPrec(prec)
+		sumSq := new(big.Float).SetPrec(prec)
+		for _, x := range vals {
+			sum.Add(sum, x)
+			sumSq.Add(sumSq, new(big.Float).SetPrec(prec).Mul(x, x))
+		}
+		m := sum.Quo(sum, big.NewFloat(4))
+		naive := sumSq.Quo(sumSq, big.NewFloat(4))
+		naive.Sub(naive, m.Mul(m, m))
+		if naive.Cmp(big.NewFloat(22.5)) == 0 {
+			t.Fatalf("prec = %d, naive variance = %g; want it to differ from 22.5", prec, naive)
+		}
+
+		if x := bigfloat.Variance(vals, false); x.Cmp(big.NewFloat(22.5)) != 0 {
+			t.Errorf("prec = %d, Variance(false) = %g; want 22.5", prec, x)
+		}
+		if x := bigfloat.Variance(vals, true); x.Cmp(big.NewFloat(30)) != 0 {
+			t.Errorf("prec = %d, Variance(true) = %g; want 30", prec, x)
+		}
+	}
+}
+
+func TestHarmonicMean(t *testing.T) {
+	for _, vals64 := range [][]float64{
+		{1, 2, 4},
+		{40, 60},
+		{3, 3, 3},
+		{1, 1e-300},
+		{0.5, math.Inf(+1)},
+		{0.1, 0.2, 0.3, 1e10},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			var vals []*big.Float
+			recips := new(big.Rat) // Σ 1/xᵢ, with 1/+Inf = 0
+			for _, f := range vals64 {
+				vals = append(vals, big.NewFloat(f).SetPrec(prec))
+				if !math.IsInf(f, 0) {
+					r := new(big.Rat).SetFloat64(f)
+					recips.Add(recips, r.Inv(r))
+				}
+			}
+			exact := big.NewRat(int64(len(vals)), 1)
+			want := new(big.Float).SetPrec(prec).SetRat(exact.Quo(exact, recips))
+
+			if x := bigfloat.HarmonicMean(vals); x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, HarmonicMean(%v) =\ngot  %g (prec = %d);\nwant %g", prec, vals64, x, x.Prec(), want)
+			}
+		}
+	}
+}
+
+// HarmonicMean <= GeometricMean <= Mean, with equality only when all
+// the elements are equal.
+func TestMeanInequality(t *testing.T) {
+	rnd := rand.New(rand.NewSource(7))
+	for _, prec := range []uint{53, 100, 500, 1000} {
+		for i := 0; i < 50; i++ {
+			var vals []*big.Float
+			for j := 0; j < rnd.Intn(10)+2; j++ {
+				vals = append(vals, big.NewFloat(rnd.Float64()*100+0.01).SetPrec(prec))
+			}
+
+			h, g, a := bigfloat.HarmonicMean(vals), bigfloat.GeometricMean(vals), bigfloat.Mean(vals)
+			if h.Cmp(g) >= 0 || g.Cmp(a) >= 0 {
+				t.Errorf("prec = %d, vals = %v:\nHarmonicMean  %g\nGeometricMean %g\nMean          %g", prec, vals, h, g, a)
+			}
+		}
+	}
+}
+
+func TestMeansSpecialValues(t *testing.T) {
+	inf := big.NewFloat(math.Inf(+1))
+	if x := bigfloat.Mean([]*big.Float{big.NewFloat(1), inf}); !x.IsInf() || x.Sign() < 0 {
+		t.Errorf("Mean(1, +Inf) = %g; want +Inf", x)
+	}
+	if x := bigfloat.HarmonicMean([]*big.Float{inf, inf}); !x.IsInf() {
+		t.Errorf("HarmonicMean(+Inf, +Inf) = %g; want +Inf", x)
+	}
+	if x := bigfloat.Variance([]*big.Float{big.NewFloat(5)}, false); x.Sign() != 0 {
+		t.Errorf("Variance(5, false) = %g; want 0", x)
+	}
+
+	for _, test := range []struct {
+		name string
+		f    func()
+	}{
+		{"Mean(nil)", func() { bigfloat.Mean(nil) }},
+		{"Mean(+Inf, -Inf)", func() { bigfloat.Mean([]*big.Float{inf, new(big.Float).Neg(inf)}) }},
+		{"HarmonicMean(nil)", func() { bigfloat.HarmonicMean(nil) }},
+		{"HarmonicMean(1, 0)", func() { bigfloat.HarmonicMean([]*big.Float{big.NewFloat(1), big.NewFloat(0)}) }},
+		{"HarmonicMean(1, -1)", func() { bigfloat.HarmonicMean([]*big.Float{big.NewFloat(1), big.NewFloat(-1)}) }},
+		{"Variance(nil, false)", func() { bigfloat.Variance(nil, false) }},
+		{"Variance(1, true)", func() { bigfloat.Variance([]*big.Float{big.NewFloat(1)}, true) }},
+		{"Variance(1, +Inf)", func() { bigfloat.Variance([]*big.Float{big.NewFloat(1), inf}, false) }},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s did not panic", test.name)
+				}
+			}()
+			test.f()
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkGeometricMean(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		vals := make([]*big.Float, 16)
+		for i := range vals {
+			vals[i] = big.NewFloat(float64(i + 2)).SetPrec(prec)
+		}
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.GeometricMean(vals)
+			}
+		})
+	}
+}
@@ -1,10 +1,68 @@
 package bigfloat
 
-import "math/big"
+import (
+	"context"
+	"math"
+	"math/big"
+)
+
+// AGM returns a big.Float representation of the arithmetic-geometric
+// mean of a and b, the common limit of the sequences
+//
+//	aₙ₊₁ = (aₙ + bₙ)/2,    bₙ₊₁ = √(aₙbₙ)
+//
+// Precision is the larger of the precisions of the arguments. The
+// function panics if a or b is negative, returns 0 if a or b is 0,
+// and +Inf if a or b is +Inf (and the other is not 0).
+func AGM(a, b *big.Float) *big.Float {
+
+	if a.Sign() < 0 || b.Sign() < 0 {
+		panic("AGM: argument is negative")
+	}
+
+	prec := a.Prec()
+	if b.Prec() > prec {
+		prec = b.Prec()
+	}
+
+	// AGM(0, b) = AGM(a, 0) = 0
+	if a.Sign() == 0 || b.Sign() == 0 {
+		return new(big.Float).SetPrec(prec)
+	}
+
+	// AGM(+Inf, b) = AGM(a, +Inf) = +Inf
+	if a.IsInf() || b.IsInf() {
+		return new(big.Float).SetPrec(prec).SetInf(false)
+	}
+
+	// agm stops when a and b agree to its precision after the binary
+	// point, so scale both arguments below 1, using
+	//   AGM(a·2**k, b·2**k) = AGM(a, b)·2**k
+	// which is exact. Then either of them is within the error bound
+	// of the limit; give agm guard digits so that it can be rounded.
+	k := a.MantExp(nil)
+	if exp := b.MantExp(nil); exp > k {
+		k = exp
+	}
+	a2 := new(big.Float).SetPrec(prec + 64).Set(a)
+	b2 := new(big.Float).SetPrec(prec + 64).Set(b)
+	a2.SetMantExp(a2, -k)
+	b2.SetMantExp(b2, -k)
+
+	x := agm(a2, b2)
+	return x.SetMantExp(x, k).SetPrec(prec)
+}
 
 // agm returns the arithmetic-geometric mean of a and b.
 // a and b must have the same precision.
 func agm(a, b *big.Float) *big.Float {
+	x, _ := agmContext(context.Background(), a, b)
+	return x
+}
+
+// agmContext is like agm, but it checks ctx before every iteration and
+// returns ctx.Err() if ctx is done.
+func agmContext(ctx context.Context, a, b *big.Float) (*big.Float, error) {
 
 	if a.Prec() != b.Prec() {
 		panic("agm: different precisions")
@@ -29,16 +87,18 @@ func agm(a, b *big.Float) *big.Float {
 	t := new(big.Float)
 
 	for t.Sub(a2, b2).Cmp(lim) != -1 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		t.Copy(a2)
 		a2.Add(a2, b2).Mul(a2, half)
 		b2 = Sqrt(b2.Mul(b2, t))
 	}
 
-	return a2.SetPrec(prec)
+	return a2.SetPrec(prec), nil
 }
 
-var piCache *big.Float
-var piCachePrec uint
+// enablePiCache can be set to false to make pi compute π every time.
 var enablePiCache bool = true
 
 func init() {
@@ -46,7 +106,7 @@ func init() {
 		return
 	}
 
-	piCache, _, _ = new(big.Float).SetPrec(1024).Parse("3."+
+	x, _, _ := new(big.Float).SetPrec(1024).Parse("3."+
 		"14159265358979323846264338327950288419716939937510"+
 		"58209749445923078164062862089986280348253421170679"+
 		"82148086513282306647093844609550582231725359408128"+
@@ -55,15 +115,28 @@ func init() {
 		"45648566923460348610454326648213393607260249141273"+
 		"72458700660631558817488152092096282925409171536444", 10)
 
-	piCachePrec = 1024
+	piCache.store(x, 1024)
+}
+
+// Pi returns a big.Float representation of π rounded to prec bits.
+// The most precise value of π computed so far is cached, so requests
+// for an equal or lower precision are served by rounding the cached
+// value without taking any lock. Pi is safe for concurrent use by
+// multiple goroutines.
+func Pi(prec uint) *big.Float {
+	return pi(prec)
 }
 
 // pi returns pi to prec bits of precision
 func pi(prec uint) *big.Float {
-
-	if prec <= piCachePrec && enablePiCache {
-		return new(big.Float).Copy(piCache).SetPrec(prec)
+	if !enablePiCache {
+		return computePi(prec)
 	}
+	return piCache.get(prec)
+}
+
+// computePi computes pi to prec bits of precision
+func computePi(prec uint) *big.Float {
 
 	// Following R. P. Brent, Multiple-precision zero-finding
 	// methods and the complexity of elementary function evaluation,
@@ -97,32 +170,287 @@ func pi(prec uint) *big.Float {
 	}
 
 	a.Mul(a, a).Quo(a, t) // π = a² / t
-	a.SetPrec(prec)
+	return a.SetPrec(prec)
+}
+
+// E returns a big.Float representation of e rounded to prec bits.
+// As for Pi, the most precise value of e computed so far is cached.
+// E is safe for concurrent use by multiple goroutines.
+func E(prec uint) *big.Float {
+	return eCache.get(prec)
+}
+
+// computeE computes e to prec bits of precision
+func computeE(prec uint) *big.Float {
+
+	// e = Σ 1/k!
+	//
+	// Stop when 1/k! < 2**(-prec-64).
+	x := big.NewFloat(1).SetPrec(prec + 64)
+	term := big.NewFloat(1).SetPrec(prec + 64)
+	lim := new(big.Float).SetMantExp(big.NewFloat(1), -int(prec+64))
+	for k := int64(1); term.Cmp(lim) >= 0; k++ {
+		term.Quo(term, new(big.Float).SetInt64(k))
+		x.Add(x, term)
+	}
+
+	return x.SetPrec(prec)
+}
+
+// EulerGamma returns a big.Float representation of the
+// Euler–Mascheroni constant γ = 0.5772..., rounded to prec bits. This
+// is not the gamma function, which is Gamma. As for Pi, the most
+// precise value of γ computed so far is cached. EulerGamma is safe for
+// concurrent use by multiple goroutines.
+func EulerGamma(prec uint) *big.Float {
+	return eulerGammaCache.get(prec)
+}
+
+// computeEulerGamma computes γ to prec bits of precision
+func computeEulerGamma(prec uint) *big.Float {
+
+	// Following R. P. Brent and E. M. McMillan, Some new algorithms
+	// for high-precision computation of Euler's constant, Mathematics
+	// of Computation 34, 1980, algorithm B1:
+	//
+	//   γ = U/V - e^(-4n)·O(π)
+	//
+	// where, with A₀ = -log(n) and B₀ = 1,
+	//
+	//   Bₖ = Bₖ₋₁·n²/k²,    Aₖ = (Aₖ₋₁·n²/k + Bₖ)/k,
+	//   U = Σ Aₖ,    V = Σ Bₖ
+	//
+	// π·e^(-4n) < 2**(-prec-64) for n = (prec+64)·log(2)/4 + 1.
+	p := prec + 64
+	n := int64(float64(p)*math.Ln2/4) + 1
+	n2 := new(big.Float).SetInt64(n * n)
+
+	a := Log(new(big.Float).SetPrec(p).SetInt64(n))
+	a.Neg(a)
+	b := big.NewFloat(1).SetPrec(p)
+	u := new(big.Float).Copy(a)
+	v := big.NewFloat(1).SetPrec(p)
+
+	// The terms grow until k ≈ n and then decrease, all the Bₖ are
+	// positive, and so are the Aₖ after the first few; stop when
+	// they are both below the precision.
+	k2 := new(big.Float)
+	for k := int64(1); ; k++ {
+		kf := new(big.Float).SetInt64(k)
+		b.Mul(b, n2).Quo(b, k2.SetInt64(k*k))
+		a.Mul(a, n2).Quo(a, kf).Add(a, b).Quo(a, kf)
+		u.Add(u, a)
+		v.Add(v, b)
+		if k > n && b.MantExp(nil) < v.MantExp(nil)-int(p) && a.MantExp(nil) < u.MantExp(nil)-int(p) {
+			break
+		}
+	}
+
+	return u.Quo(u, v).SetPrec(prec)
+}
 
-	if enablePiCache {
-		piCache.Copy(a)
-		piCachePrec = prec
+// Catalan returns a big.Float representation of Catalan's constant
+// G = 0.9159..., rounded to prec bits. As for Pi, the most precise
+// value of G computed so far is cached. Catalan is safe for concurrent
+// use by multiple goroutines.
+func Catalan(prec uint) *big.Float {
+	return catalanCache.get(prec)
+}
+
+// computeCatalan computes G to prec bits of precision
+func computeCatalan(prec uint) *big.Float {
+
+	// Ramanujan's formula
+	//
+	//   G = π/8·log(2+√3) + 3/8·Σ (k!)²/((2k)!·(2k+1)²)
+	//
+	// The ratio between consecutive terms of the series is about 1/4,
+	// so it gains 2 bits per term.
+	p := prec + 64
+	term := big.NewFloat(1).SetPrec(p) // (k!)²/(2k)!
+	sum := big.NewFloat(1).SetPrec(p)
+	t := new(big.Float).SetPrec(p)
+	for k := int64(1); ; k++ {
+		term.Mul(term, t.SetInt64(k))
+		term.Quo(term, t.SetInt64(2*(2*k-1)))
+		t.Quo(term, t.SetInt64((2*k+1)*(2*k+1)))
+		sum.Add(sum, t)
+		if t.MantExp(nil) < -int(p) {
+			break
+		}
 	}
+	sum.Mul(sum, big.NewFloat(3))
+
+	x := Sqrt(new(big.Float).SetPrec(p).SetInt64(3))
+	x = Log(x.Add(x, big.NewFloat(2)))
+	x.Mul(x, pi(p))
+	x.Add(x, sum)
+	x.SetMantExp(x, -3) // divide by 8
+
+	return x.SetPrec(prec)
+}
+
+// Phi returns a big.Float representation of the golden ratio
+// φ = (1+√5)/2 = 1.6180..., rounded to prec bits. As for Pi, the most
+// precise value of φ computed so far is cached. Phi is safe for
+// concurrent use by multiple goroutines.
+func Phi(prec uint) *big.Float {
+	return phiCache.get(prec)
+}
+
+// computePhi computes φ to prec bits of precision
+func computePhi(prec uint) *big.Float {
+	x := Sqrt(new(big.Float).SetPrec(prec + 64).SetInt64(5))
+	x.Add(x, big.NewFloat(1))
+	x.SetMantExp(x, -1) // divide by 2
+
+	return x.SetPrec(prec)
+}
+
+// Sqrt2 returns a big.Float representation of √2 rounded to prec bits.
+// As for Pi, the most precise value of √2 computed so far is cached.
+// Sqrt2 is safe for concurrent use by multiple goroutines.
+func Sqrt2(prec uint) *big.Float {
+	return sqrt2Cache.get(prec)
+}
+
+// computeSqrt2 computes √2 to prec bits of precision
+func computeSqrt2(prec uint) *big.Float {
+	return Sqrt(new(big.Float).SetPrec(prec + 64).SetInt64(2)).SetPrec(prec)
+}
 
-	return a
+// Sqrt3 returns a big.Float representation of √3 rounded to prec bits.
+// As for Pi, the most precise value of √3 computed so far is cached.
+// Sqrt3 is safe for concurrent use by multiple goroutines.
+func Sqrt3(prec uint) *big.Float {
+	return sqrt3Cache.get(prec)
+}
+
+// computeSqrt3 computes √3 to prec bits of precision
+func computeSqrt3(prec uint) *big.Float {
+	return Sqrt(new(big.Float).SetPrec(prec + 64).SetInt64(3)).SetPrec(prec)
+}
+
+// SqrtPi returns a big.Float representation of √π rounded to prec
+// bits. As for Pi, the most precise value of √π computed so far is
+// cached. SqrtPi is safe for concurrent use by multiple goroutines.
+func SqrtPi(prec uint) *big.Float {
+	return sqrtPiCache.get(prec)
+}
+
+// computeSqrtPi computes √π to prec bits of precision
+func computeSqrtPi(prec uint) *big.Float {
+	return Sqrt(pi(prec + 64)).SetPrec(prec)
 }
 
 // returns an approximate (to precision dPrec) solution to
-//    f(t) = 0
+//
+//	f(t) = 0
+//
 // using the Newton Method.
 // fOverDf needs to be a fuction returning f(t)/f'(t).
 // t must not be changed by fOverDf.
 // guess is the initial guess (and it's not preserved).
 func newton(fOverDf func(z *big.Float) *big.Float, guess *big.Float, dPrec uint) *big.Float {
+	x, _, _ := newtonContext(context.Background(), fOverDf, guess, dPrec, 0)
+	return x
+}
+
+// newtonContext is like newton, but it checks ctx before every
+// iteration and returns ctx.Err() if ctx is done, together with the
+// approximation computed so far, rounded to the number of bits that are
+// correct. If maxIter > 0, it returns ErrMaxIter and the approximation
+// in the same way when maxIter iterations are not enough. It also
+// returns the number of iterations done.
+func newtonContext(ctx context.Context, fOverDf func(z *big.Float) *big.Float, guess *big.Float, dPrec uint, maxIter int) (*big.Float, int, error) {
 
 	prec, guard := guess.Prec(), uint(64)
 	guess.SetPrec(prec + guard)
 
-	for prec < 2*dPrec {
+	n := 0
+	for ; prec < 2*dPrec; n++ {
+		err := ctx.Err()
+		if err == nil && maxIter > 0 && n == maxIter {
+			err = ErrMaxIter
+		}
+		if err != nil {
+			// the last step was computed with prec/2 + guard bits, so
+			// it can't be more accurate than that; keep half of the
+			// guard bits as a margin for the rounding errors
+			if p := prec/2 + guard/2; n > 0 && p < prec {
+				prec = p
+			}
+			if prec > dPrec {
+				prec = dPrec
+			}
+			return guess.SetPrec(prec), n, err
+		}
 		guess.Sub(guess, fOverDf(guess))
 		prec *= 2
 		guess.SetPrec(prec + guard)
 	}
 
-	return guess.SetPrec(dPrec)
+	return guess.SetPrec(dPrec), n, nil
+}
+
+// returns an approximate (to precision dPrec) solution to
+//
+//	f(t) = 0
+//
+// using an iteration with cubic convergence, like Halley's Method.
+// step needs to be a function returning the correction term, so
+// that t - step(t) has three times the correct digits of t.
+// t must not be changed by step.
+// guess is the initial guess (and it's not preserved).
+func halley(step func(z *big.Float) *big.Float, guess *big.Float, dPrec uint) *big.Float {
+	x, _, _ := halleyContext(context.Background(), step, guess, dPrec, 0)
+	return x
+}
+
+// halleyContext is like halley, but it checks ctx before every
+// iteration and returns ctx.Err() if ctx is done, together with the
+// approximation computed so far, rounded to the number of bits that are
+// correct. If maxIter > 0, it returns ErrMaxIter and the approximation
+// in the same way when maxIter iterations are not enough. It also
+// returns the number of iterations done.
+func halleyContext(ctx context.Context, step func(z *big.Float) *big.Float, guess *big.Float, dPrec uint, maxIter int) (*big.Float, int, error) {
+
+	prec, guard := guess.Prec(), uint(64)
+	guess.SetPrec(prec + guard)
+
+	n := 0
+	for ; prec < 3*dPrec; n++ {
+		err := ctx.Err()
+		if err == nil && maxIter > 0 && n == maxIter {
+			err = ErrMaxIter
+		}
+		if err != nil {
+			// the last step was computed with prec/3 + guard bits, so
+			// it can't be more accurate than that; keep half of the
+			// guard bits as a margin for the rounding errors
+			if p := prec/3 + guard/2; n > 0 && p < prec {
+				prec = p
+			}
+			if prec > dPrec {
+				prec = dPrec
+			}
+			return guess.SetPrec(prec), n, err
+		}
+		guess.Sub(guess, step(guess))
+		prec *= 3
+		guess.SetPrec(prec + guard)
+	}
+
+	return guess.SetPrec(dPrec), n, nil
+}
+
+// infPrec returns the precision of a finite result computed from the
+// infinity z: z's precision, or 53 (as in big.NewFloat) if it's 0, as
+// it is for new(big.Float).SetInf(...), since rounding the result to
+// 0 bits would turn it into ±0.
+func infPrec(z *big.Float) uint {
+	if z.Prec() == 0 {
+		return 53
+	}
+	return z.Prec()
 }
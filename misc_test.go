@@ -1,8 +1,11 @@
 package bigfloat
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"math/big"
+	"sync"
 	"testing"
 )
 
@@ -39,6 +42,81 @@ func TestAgm(t *testing.T) {
 	}
 }
 
+func TestAGM(t *testing.T) {
+	for _, test := range []struct {
+		a, b string
+		want string
+	}{
+		{"1", "2", "1.4567910310469068691864323832650819749738639432213055907941723832679264545802509002574737128184484443281894018160367999355762430743401245116912132499522793768970211976726893728266666782707432902072384564600963133367494416649516400826932239086263376738382410254887262645136590660408875885100466728130947439789355129117201754471869564160356411130706061"},
+		{"24", "6", "13.458171481725615420766813156974399243053838854439659855512942208324428825373997433446690354565170131102756266481834614435163364136978471604482234495269382328446262516513846397256418388307922196457513228110216446002386291726475996281102059333467322236073607611234689128242651446297961315249581927708145906585535650975764107209769705479591027515640463"},
+		{"0.0009765625", "1e10", "501153495.44717730808976830509322967394335881548291591592020577109334818724617813357758462974842151438010867644099634386671579380035194128295436408853432720892886452230569612432347175538749997859785518704488786567593931035960779066198475749402834324799298430941313789074626794756808173349964423453931094297699990633380403199866996242138460622220839942"},
+		{"9.094947017729282379150390625E-13", "2.7284841053187847137451171875E-12", "1.6949495904963410269880924088504530983823614013334497232976366167152498697572420709664382198886666718317493976139111873713998231604844358520636344321203959356840115520030107654826775610937883848447587792041523729640217402992954621124522485819381492083531040318957823617751332279063712594750343498987232722959719181479671127968894027313970040979492238e-12"},
+		{"5", "5", "5"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			a := new(big.Float).SetPrec(prec)
+			a.Parse(test.a, 10)
+			b := new(big.Float).SetPrec(prec)
+			b.Parse(test.b, 10)
+
+			x := AGM(a, b)
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, AGM(%v, %v) =\ngot  %g;\nwant %g", prec, test.a, test.b, x, want)
+			}
+
+			// the AGM is symmetric
+			if y := AGM(b, a); y.Cmp(x) != 0 {
+				t.Errorf("prec = %d, AGM(%v, %v) = %g != AGM(%v, %v) = %g", prec, test.b, test.a, y, test.a, test.b, x)
+			}
+		}
+	}
+}
+
+// AGM(1, √2) = 1/G, where G is Gauss's constant
+func TestAGMGauss(t *testing.T) {
+	const agm1Sqrt2 = "1.1981402347355922074399224922803238782272126632156515582636749529464052141439156708358855564897933893759072250972437622759288775397035280307656021697649672783870543041718873877355629063532093911521660691088669156563562802268663215657539261152893587579334170597533955003347518874409307723655932911884955997991302620832983787095780615871362797286444462"
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		want := new(big.Float).SetPrec(prec)
+		want.Parse(agm1Sqrt2, 10)
+
+		// compute √2 with guard digits, so that the only rounding
+		// is in the result
+		sqrt2 := Sqrt(big.NewFloat(2).SetPrec(prec + 64))
+		x := AGM(big.NewFloat(1).SetPrec(prec), sqrt2).SetPrec(prec)
+
+		if x.Cmp(want) != 0 {
+			t.Errorf("prec = %d, AGM(1, √2) =\ngot  %g;\nwant %g", prec, x, want)
+		}
+	}
+}
+
+func TestAGMSpecialValues(t *testing.T) {
+	inf := math.Inf(+1)
+	for _, test := range []struct {
+		a, b, want float64
+	}{
+		{0, 2, 0},
+		{2, 0, 0},
+		{inf, 2, inf},
+		{2, inf, inf},
+	} {
+		x64, acc := AGM(big.NewFloat(test.a), big.NewFloat(test.b)).Float64()
+		if x64 != test.want || acc != big.Exact {
+			t.Errorf("AGM(%g, %g) = %g (%s); want %g (Exact)", test.a, test.b, x64, acc, test.want)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("AGM(-1, 2) did not panic")
+		}
+	}()
+	AGM(big.NewFloat(-1), big.NewFloat(2))
+}
+
 func TestPi(t *testing.T) {
 	enablePiCache = false
 	piStr := "3.1415926535897932384626433832795028841971693993751058209749445923078164062862089986280348253421170679821480865132823066470938446095505822317253594081284811174502841027019385211055596446229489549303819644288109756659334461284756482337867831652712019091456485669234603486104543266482133936072602491412737245870066063155881748815209209628292540917153644"
@@ -56,6 +134,230 @@ func TestPi(t *testing.T) {
 	enablePiCache = true
 }
 
+func TestPiExported(t *testing.T) {
+	piStr := "3.14159265358979323846264338327950288419716939937510582097494459"
+	for _, prec := range []uint{24, 53, 64, 100, 200} {
+		want := new(big.Float).SetPrec(prec)
+		want.Parse(piStr, 10)
+
+		if z := Pi(prec); z.Cmp(want) != 0 || z.Prec() != prec {
+			t.Errorf("Pi(%d) =\ngot  %g;\nwant %g", prec, z, want)
+		}
+	}
+}
+
+func TestPiCache(t *testing.T) {
+	Pi(2000)
+	saved := piCache.load()
+	if saved.prec < 2000 {
+		t.Fatalf("Pi(2000) did not extend the cache, cache prec = %d", saved.prec)
+	}
+
+	// Replace the cached value with a fake one: if lower precision
+	// requests are served from the cache, they'll return it.
+	piCache.store(big.NewFloat(3), saved.prec)
+	defer piCache.v.Store(saved)
+
+	if z := Pi(100); z.Cmp(big.NewFloat(3)) != 0 {
+		t.Errorf("Pi(100) = %g was not served from the cache", z)
+	}
+}
+
+func TestPiConcurrent(t *testing.T) {
+	piStr := "3.14159265358979323846264338327950288419716939937510582097494459"
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// some of the goroutines have to extend the cache
+			prec := uint(200 + 200*i)
+			z := Pi(prec).SetPrec(200)
+			want := new(big.Float).SetPrec(200)
+			want.Parse(piStr, 10)
+			if z.Cmp(want) != 0 {
+				t.Errorf("Pi(%d) = %g; want %g", prec, z, want)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestE(t *testing.T) {
+	eStr := "2.7182818284590452353602874713526624977572470936999595749669676277240766303535475945713821785251664274274663919320030599218174135966290435729003342952605956307381323286279434907632338298807531952510190115738341879307021540891499348841675092447614606680822648001684774118537423454424371075390777449920695517027618386062613313845830007520449338265602976"
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		want := new(big.Float).SetPrec(prec)
+		want.Parse(eStr, 10)
+
+		if z := E(prec); z.Cmp(want) != 0 || z.Prec() != prec {
+			t.Errorf("E(%d) =\ngot  %g;\nwant %g", prec, z, want)
+		}
+	}
+}
+
+func TestECache(t *testing.T) {
+	E(2000)
+	saved := eCache.load()
+	if saved.prec < 2000 {
+		t.Fatalf("E(2000) did not extend the cache, cache prec = %d", saved.prec)
+	}
+
+	// Replace the cached value with a fake one: if lower precision
+	// requests are served from the cache, they'll return it.
+	eCache.store(big.NewFloat(3), saved.prec)
+	defer eCache.v.Store(saved)
+
+	if z := E(100); z.Cmp(big.NewFloat(3)) != 0 {
+		t.Errorf("E(100) = %g was not served from the cache", z)
+	}
+}
+
+func TestEulerGamma(t *testing.T) {
+	gammaStr := "0.5772156649015328606065120900824024310421593359399235988057672348848677267776646709369470632917467495146314472498070824809605040144865428362241739976449235362535003337429373377376739427925952582470949160087352039481656708532331517766115286211995015079847937450857057400299213547861466940296043254215190587755352673313992540129674205137541395491116851028079842"
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		want := new(big.Float).SetPrec(prec)
+		want.Parse(gammaStr, 10)
+
+		if z := EulerGamma(prec); z.Cmp(want) != 0 || z.Prec() != prec {
+			t.Errorf("EulerGamma(%d) =\ngot  %g;\nwant %g", prec, z, want)
+		}
+		if z := computeEulerGamma(prec); z.Cmp(want) != 0 {
+			t.Errorf("computeEulerGamma(%d) =\ngot  %g;\nwant %g", prec, z, want)
+		}
+	}
+}
+
+func TestCatalan(t *testing.T) {
+	catalanStr := "0.9159655941772190150546035149323841107741493742816721342664981196217630197762547694793565129261151062485744226191961995790358988033258590594315947374811584069953320287733194605190387274781640878659090247064841521630002287276409423882599577415088163974702524820115607076448838078733704899008647751132259971343407485407553230768565335768095835260219382323950800"
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		want := new(big.Float).SetPrec(prec)
+		want.Parse(catalanStr, 10)
+
+		if z := Catalan(prec); z.Cmp(want) != 0 || z.Prec() != prec {
+			t.Errorf("Catalan(%d) =\ngot  %g;\nwant %g", prec, z, want)
+		}
+		if z := computeCatalan(prec); z.Cmp(want) != 0 {
+			t.Errorf("computeCatalan(%d) =\ngot  %g;\nwant %g", prec, z, want)
+		}
+	}
+}
+
+func TestCatalanCache(t *testing.T) {
+	Catalan(1000)
+	prec := catalanCache.load().prec + 1000
+	want := computeCatalan(prec)
+	if z := Catalan(prec); z.Cmp(want) != 0 || catalanCache.load().prec != prec {
+		t.Fatalf("Catalan(%d) did not extend the cache, cache prec = %d", prec, catalanCache.load().prec)
+	}
+
+	// lower precision requests are rounded from the extended value
+	for _, p := range []uint{53, 1000, prec - 1} {
+		if z := Catalan(p); z.Cmp(new(big.Float).Copy(want).SetPrec(p)) != 0 {
+			t.Errorf("Catalan(%d) = %g after the cache was extended", p, z)
+		}
+	}
+}
+
+func TestPhi(t *testing.T) {
+	phiStr := "1.6180339887498948482045868343656381177203091798057628621354486227052604628189024497072072041893911374847540880753868917521266338622235369317931800607667263544333890865959395829056383226613199282902678806752087668925017116962070322210432162695486262963136144381497587012203408058879544547492461856953648644492410443207713449470495658467885098743394422"
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		want := new(big.Float).SetPrec(prec)
+		want.Parse(phiStr, 10)
+
+		z := Phi(prec)
+		if z.Cmp(want) != 0 || z.Prec() != prec {
+			t.Errorf("Phi(%d) =\ngot  %g;\nwant %g", prec, z, want)
+		}
+		if x := computePhi(prec); x.Cmp(want) != 0 {
+			t.Errorf("computePhi(%d) =\ngot  %g;\nwant %g", prec, x, want)
+		}
+
+		// φ² = φ + 1, up to the rounding errors of the two sides
+		sq := new(big.Float).Mul(z, z)
+		diff := new(big.Float).Add(z, big.NewFloat(1))
+		diff.Sub(sq, diff)
+		if diff.Sign() != 0 && diff.MantExp(nil) > 4-int(prec) {
+			t.Errorf("prec = %d, Phi² - (Phi + 1) = %g", prec, diff)
+		}
+	}
+}
+
+func TestSqrtConstants(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		f    func(uint) *big.Float
+		sq   func(uint) *big.Float // the square of the constant
+		want string
+	}{
+		{"Sqrt2", Sqrt2, func(uint) *big.Float { return big.NewFloat(2) },
+			"1.4142135623730950488016887242096980785696718753769480731766797379907324784621070388503875343276415727350138462309122970249248360558507372126441214970999358314132226659275055927557999505011527820605714701095599716059702745345968620147285174186408891986095523292304843087143214508397626036279952514079896872533965463318088296406206152583523950547457503"},
+		{"Sqrt3", Sqrt3, func(uint) *big.Float { return big.NewFloat(3) },
+			"1.7320508075688772935274463415058723669428052538103806280558069794519330169088000370811461867572485756756261414154067030299699450949989524788116555120943736485280932319023055820679748201010846749232650153123432669033228866506722546689218379712270471316603678615880190499865373798593894676503475065760507566183481296061009476021871903250831458295239598"},
+		{"SqrtPi", SqrtPi, Pi,
+			"1.7724538509055160272981674833411451827975494561223871282138077898529112845910321813749506567385446654162268236242825706662361528657244226025250937096027870684620376986531051228499251730289508262289320953792679628001746390153514797205167001901852340185854469744949126403139217755259062164054193325009063984076137334774751534336679897893658518364087955"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := test.f(prec)
+			if z.Cmp(want) != 0 || z.Prec() != prec {
+				t.Errorf("%s(%d) =\ngot  %g;\nwant %g", test.name, prec, z, want)
+			}
+
+			// z² is the square to within a couple of ulps
+			diff := new(big.Float).SetPrec(2*prec).Mul(z, z)
+			diff.Sub(diff, test.sq(2*prec))
+			if diff.Sign() != 0 && diff.MantExp(nil) > 3-int(prec) {
+				t.Errorf("prec = %d, %s² - square = %g", prec, test.name, diff)
+			}
+		}
+	}
+}
+
+// For odd k, the root of 1 + 2k·2**(-prec) is just below the
+// midpoint 1 + k·2**(-prec), so it must be rounded down to
+// 1 + (k-1)·2**(-prec). With only the guard digits, z·(1/√z) rounds to
+// the midpoint, and then to the even float, which is the one above
+// when k = 3 mod 4.
+func TestSqrtInverseMidpoints(t *testing.T) {
+	for _, prec := range []uint{100, 500, 1000, 3000, 10000} {
+		for _, k := range []int64{1, 3, 5, 7, 9, 11, 1e6 + 3} {
+			z := big.NewFloat(1).SetPrec(prec)
+			d := new(big.Float).SetInt64(2 * k)
+			z.Add(z, d.SetMantExp(d, -int(prec)))
+
+			want := big.NewFloat(1).SetPrec(prec)
+			d.SetInt64(k - 1)
+			want.Add(want, d.SetMantExp(d, -int(prec)))
+
+			x, _, _ := sqrtInverse(context.Background(), new(big.Float), z, big.NewFloat(1), 0)
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, sqrtInverse(1 + %d·2**-%d) =\ngot  %s;\nwant %s", prec, 2*k, prec, x.Text('p', 0), want.Text('p', 0))
+			}
+		}
+	}
+}
+
+func TestSqrtHalley(t *testing.T) {
+	defer func(old uint) { sqrtHalleyThreshold = old }(sqrtHalleyThreshold)
+
+	for _, z := range []float64{0.5, 2, 3, 5, 1e10, 1e-10} {
+		for _, prec := range []uint{200, 500, 1000, 5000, 10000} {
+			x := big.NewFloat(z).SetPrec(prec)
+
+			sqrtHalleyThreshold = 0
+			h := Sqrt(x)
+			sqrtHalleyThreshold = ^uint(0)
+			n := Sqrt(x)
+
+			if h.Cmp(n) != 0 {
+				t.Errorf("prec = %d, Sqrt(%g) =\nHalley %g;\nNewton %g", prec, z, h, n)
+			}
+		}
+	}
+}
+
 // ---------- Benchmarks ----------
 
 func BenchmarkAgm(b *testing.B) {
@@ -82,3 +384,27 @@ func BenchmarkPi(b *testing.B) {
 		})
 	}
 }
+
+// At 1e4 bits, starting from a 53 bits guess, Newton's method needs 9
+// iterations to reach 2·(1e4+32) bits, and Halley's method needs 6 to
+// reach 3·(1e4+32).
+func BenchmarkSqrtHalley(b *testing.B) {
+	defer func(old uint) { sqrtHalleyThreshold = old }(sqrtHalleyThreshold)
+
+	z := big.NewFloat(2).SetPrec(1e4)
+	for _, test := range []struct {
+		name      string
+		threshold uint
+	}{
+		{"Newton", ^uint(0)},
+		{"Halley", 0},
+	} {
+		sqrtHalleyThreshold = test.threshold
+		b.Run(test.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				Sqrt(z)
+			}
+		})
+	}
+}
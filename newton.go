@@ -0,0 +1,40 @@
+package bigfloat
+
+import "math/big"
+
+// Newton returns an approximate solution, to prec bits of precision,
+// of the equation
+//
+//	f(t) = 0
+//
+// using Newton's method. dfInv must return 1/f'(t). Neither f nor
+// dfInv may modify their argument.
+//
+// The method converges quadratically, so the number of correct bits
+// roughly doubles at each step, but only if guess is close enough to
+// the root. The precision of guess is taken as the number of bits
+// that are already correct: a guess obtained from a float64 should
+// have precision 53, not prec. Newton then iterates until the
+// approximation has prec bits, working with 64 guard bits above the
+// current precision. guess is not modified. If guess is not close
+// to the root the result is unspecified: Newton does not check for
+// convergence.
+func Newton(f, dfInv func(t *big.Float) *big.Float, guess *big.Float, prec uint) *big.Float {
+	fOverDf := func(t *big.Float) *big.Float {
+		x := f(t)
+		return x.Mul(x, dfInv(t))
+	}
+	return newton(fOverDf, new(big.Float).Copy(guess), prec)
+}
+
+// NewtonDeriv is like Newton, but df must return f'(t) instead of its
+// reciprocal, and NewtonDeriv divides f(t) by it with the working
+// precision. It's convenient when f' is cheap to compute but 1/f'
+// isn't. Neither f nor df may modify their argument.
+func NewtonDeriv(f, df func(t *big.Float) *big.Float, guess *big.Float, prec uint) *big.Float {
+	fOverDf := func(t *big.Float) *big.Float {
+		x := new(big.Float).SetPrec(t.Prec())
+		return x.Quo(f(t), df(t))
+	}
+	return newton(fOverDf, new(big.Float).Copy(guess), prec)
+}
@@ -0,0 +1,89 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestNewton(t *testing.T) {
+	// t² - 2 = 0
+	f := func(t *big.Float) *big.Float {
+		x := new(big.Float).Mul(t, t)
+		return x.Sub(x, big.NewFloat(2))
+	}
+	dfInv := func(t *big.Float) *big.Float {
+		x := new(big.Float).Add(t, t)
+		return x.Quo(big.NewFloat(1), x)
+	}
+
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		guess := big.NewFloat(1.4).SetPrec(4)
+		x := bigfloat.Newton(f, dfInv, guess, prec)
+		want := bigfloat.Sqrt(big.NewFloat(2).SetPrec(prec))
+		if x.Cmp(want) != 0 || x.Prec() != prec {
+			t.Errorf("prec = %d, Newton(t² - 2) =\ngot  %g;\nwant %g", prec, x, want)
+		}
+		if guess.Prec() != 4 || guess.Cmp(big.NewFloat(1.4).SetPrec(4)) != 0 {
+			t.Errorf("prec = %d, Newton modified guess to %g (prec = %d)", prec, guess, guess.Prec())
+		}
+	}
+}
+
+func TestNewtonDeriv(t *testing.T) {
+	// t² - 2 = 0
+	f := func(t *big.Float) *big.Float {
+		x := new(big.Float).Mul(t, t)
+		return x.Sub(x, big.NewFloat(2))
+	}
+	df := func(t *big.Float) *big.Float {
+		return new(big.Float).Add(t, t)
+	}
+	dfInv := func(t *big.Float) *big.Float {
+		x := new(big.Float).Add(t, t)
+		return x.Quo(big.NewFloat(1), x)
+	}
+
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		guess := big.NewFloat(1.4).SetPrec(4)
+		x := bigfloat.NewtonDeriv(f, df, guess, prec)
+		want := bigfloat.Sqrt(big.NewFloat(2).SetPrec(prec))
+		if x.Cmp(want) != 0 || x.Prec() != prec {
+			t.Errorf("prec = %d, NewtonDeriv(t² - 2) =\ngot  %g;\nwant %g", prec, x, want)
+		}
+		if y := bigfloat.Newton(f, dfInv, guess, prec); x.Cmp(y) != 0 {
+			t.Errorf("prec = %d, NewtonDeriv(t² - 2) = %g, Newton(t² - 2) = %g", prec, x, y)
+		}
+		if guess.Prec() != 4 || guess.Cmp(big.NewFloat(1.4).SetPrec(4)) != 0 {
+			t.Errorf("prec = %d, NewtonDeriv modified guess to %g (prec = %d)", prec, guess, guess.Prec())
+		}
+	}
+}
+
+// This example finds the real root of t³ - 2, the cube root of 2,
+// to 1000 bits of precision.
+func ExampleNewton() {
+	// f(t) = t³ - 2
+	f := func(t *big.Float) *big.Float {
+		x := new(big.Float).Mul(t, t)
+		x.Mul(x, t)
+		return x.Sub(x, big.NewFloat(2))
+	}
+
+	// 1/f'(t) = 1/(3t²)
+	dfInv := func(t *big.Float) *big.Float {
+		x := new(big.Float).Mul(t, t)
+		x.Mul(x, big.NewFloat(3))
+		return x.Quo(big.NewFloat(1), x)
+	}
+
+	// math.Cbrt gives the first 53 correct bits
+	guess := big.NewFloat(math.Cbrt(2)).SetPrec(53)
+
+	x := bigfloat.Newton(f, dfInv, guess, 1000)
+	fmt.Printf("%.50f\n", x)
+	// Output: 1.25992104989487316476721060727822835057025146470151
+}
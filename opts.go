@@ -0,0 +1,163 @@
+package bigfloat
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+)
+
+// ErrMaxIter is returned by SqrtOpts when the iteration limit is
+// reached before the result has converged.
+var ErrMaxIter = errors.New("bigfloat: iteration limit reached")
+
+// SqrtOptions configures the iteration used by SqrtOpts. The zero
+// value gives the same result as Sqrt.
+type SqrtOptions struct {
+	// MaxIter is the maximum number of iterations of the solver. If
+	// it's <= 0, there's no limit.
+	MaxIter int
+
+	// Tolerance is the maximum relative error of the result. If it's
+	// > 0 and larger than the rounding error at z's precision, the root
+	// is only computed, and returned, with the precision needed to
+	// meet it. If it's <= 0, the result is correctly rounded to z's
+	// precision.
+	Tolerance float64
+}
+
+// SqrtOpts is like SqrtErr, but the iteration is configured by opts.
+// If the limit on the number of iterations is reached before the
+// result has converged, SqrtOpts returns the approximation computed so
+// far, rounded to the bits that are correct, and ErrMaxIter. Otherwise
+// the result is correctly rounded to z's precision, or to the lower
+// precision needed to meet the tolerance, using z's rounding mode.
+func SqrtOpts(z *big.Float, opts SqrtOptions) (*big.Float, error) {
+
+	// error on negative z
+	if z.Sign() == -1 {
+		return nil, ErrNegative
+	}
+
+	// The rounding error of a correctly rounded result is less than
+	// 2**-prec, so prec = ⌈-log2(tol)⌉ bits are enough.
+	prec := z.Prec()
+	if tol := opts.Tolerance; tol > 0 {
+		p := uint(1)
+		if tol < 0.5 {
+			p = uint(math.Ceil(-math.Log2(tol)))
+		}
+		if p < prec {
+			prec = p
+		}
+	}
+
+	// √±0 = ±0, √+Inf = +Inf
+	if z.Sign() == 0 || z.IsInf() {
+		return SqrtPrec(z, prec, z.Mode()), nil
+	}
+
+	// Computing the root of z rounded to a couple more bits than the
+	// result has keeps it within one ulp, and sqrtRound then rounds it
+	// correctly using the original z.
+	t := z
+	if prec < z.Prec() {
+		t = new(big.Float).SetPrec(prec + 2).Set(z)
+	}
+
+	x, _, err := sqrtContext(context.Background(), new(big.Float), t, nil, opts.MaxIter)
+	if err != nil {
+		return x, err
+	}
+
+	return sqrtRound(x.SetPrec(prec), z, z.Mode()), nil
+}
+
+// An Option configures the precision and the rounding of the result
+// of SqrtWith, LogWith and ExpWith. The options are applied in order,
+// so when one is given more than once the last one is used.
+type Option func(*options)
+
+// options holds the configuration set by a list of Options.
+type options struct {
+	prec  uint
+	mode  big.RoundingMode
+	guard uint
+}
+
+// WithPrec sets the precision of the result to prec bits. The default,
+// also used when prec is 0, is the precision of the argument.
+func WithPrec(prec uint) Option {
+	return func(o *options) { o.prec = prec }
+}
+
+// WithMode sets the rounding mode used for the result. The default is
+// the rounding mode of the argument.
+func WithMode(mode big.RoundingMode) Option {
+	return func(o *options) { o.mode = mode }
+}
+
+// WithGuardBits makes the function compute its result with guard more
+// bits than the result has, and then round it with the rounding mode.
+// Without guard bits the result of a function that isn't correctly
+// rounded is only rounded again, so a directed rounding mode is only
+// meaningful with some of them. The default is 0.
+func WithGuardBits(guard uint) Option {
+	return func(o *options) { o.guard = guard }
+}
+
+// newOptions returns the configuration set by opts for the argument z.
+func newOptions(z *big.Float, opts []Option) options {
+	o := options{mode: z.Mode()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.prec == 0 {
+		o.prec = z.Prec()
+	}
+	return o
+}
+
+// evalWith returns f(z), computed with the precision of z or with
+// o.prec + o.guard bits if that's larger, and rounded as set by o. z
+// is never rounded to a lower precision, since that would change the
+// result, so f is computed with at least z's precision.
+func evalWith(f func(*big.Float) *big.Float, z *big.Float, o options) *big.Float {
+	t := z
+	if w := o.prec + o.guard; w > z.Prec() {
+		t = new(big.Float).SetPrec(w).Set(z)
+	}
+	return f(t).SetMode(o.mode).SetPrec(o.prec)
+}
+
+// SqrtWith returns a big.Float representation of the square root of
+// z, with the precision and the rounding mode set by opts. Without
+// options it's the same as Sqrt(z). The result is always correctly
+// rounded, so WithGuardBits has no effect. The function panics if z
+// is negative, returns ±0 when z = ±0, and +Inf when z = +Inf.
+func SqrtWith(z *big.Float, opts ...Option) *big.Float {
+
+	// panic on negative z
+	if z.Sign() == -1 {
+		panic("SqrtWith: argument is negative")
+	}
+
+	o := newOptions(z, opts)
+	return SqrtPrec(z, o.prec, o.mode)
+}
+
+// LogWith returns a big.Float representation of the natural logarithm
+// of z, with the precision, the rounding mode and the guard bits set
+// by opts. Without options it's the same as Log(z). The special values
+// are the ones of Log, and the function panics if z is negative.
+func LogWith(z *big.Float, opts ...Option) *big.Float {
+	return evalWith(Log, z, newOptions(z, opts))
+}
+
+// ExpWith returns a big.Float representation of exp(z), with the
+// precision, the rounding mode and the guard bits set by opts. Without
+// options it's the same as Exp(z). The special values are the ones of
+// Exp.
+func ExpWith(z *big.Float, opts ...Option) *big.Float {
+	return evalWith(Exp, z, newOptions(z, opts))
+}
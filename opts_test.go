@@ -0,0 +1,226 @@
+package bigfloat_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestSqrtOptsDefault(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000, 5000} {
+		for _, mode := range []big.RoundingMode{big.ToNearestEven, big.ToZero, big.AwayFromZero} {
+			for _, f := range []float64{2, 0.1, 1e100, 4} {
+				z := big.NewFloat(f).SetPrec(prec).SetMode(mode)
+				want := bigfloat.Sqrt(z)
+				x, err := bigfloat.SqrtOpts(z, bigfloat.SqrtOptions{})
+				if err != nil || x.Cmp(want) != 0 || x.Prec() != prec || x.Mode() != mode {
+					t.Errorf("prec = %d, SqrtOpts(%g, {}) =\ngot  %g, %v;\nwant %g, nil", prec, f, x, err, want)
+				}
+			}
+		}
+	}
+}
+
+func TestSqrtOptsMaxIter(t *testing.T) {
+	for _, prec := range []uint{100, 1000, 10000} {
+		z := big.NewFloat(2).SetPrec(prec)
+		want := bigfloat.Sqrt(z)
+
+		last := uint(0)
+		for iter := 1; ; iter++ {
+			x, err := bigfloat.SqrtOpts(z, bigfloat.SqrtOptions{MaxIter: iter})
+			if err == nil {
+				if x.Cmp(want) != 0 || x.Prec() != prec {
+					t.Errorf("prec = %d, SqrtOpts(2, {MaxIter: %d}) =\ngot  %g;\nwant %g", prec, iter, x, want)
+				}
+				if iter == 1 {
+					t.Errorf("prec = %d, SqrtOpts(2, {MaxIter: 1}) converged", prec)
+				}
+				break
+			}
+
+			if !errors.Is(err, bigfloat.ErrMaxIter) {
+				t.Fatalf("prec = %d, SqrtOpts(2, {MaxIter: %d}) returned error %v; want ErrMaxIter", prec, iter, err)
+			}
+
+			// the partial result must be more accurate at every
+			// iteration, and correct to its precision
+			if x.Prec() <= last || x.Prec() >= prec {
+				t.Errorf("prec = %d, SqrtOpts(2, {MaxIter: %d}) has precision %d; want in (%d, %d)", prec, iter, x.Prec(), last, prec)
+			}
+			last = x.Prec()
+			d := new(big.Float).Sub(x, want)
+			if d.Sign() != 0 && d.MantExp(nil) > x.MantExp(nil)-int(x.Prec())+1 {
+				t.Errorf("prec = %d, SqrtOpts(2, {MaxIter: %d}) = %g; too far from %g", prec, iter, x, want)
+			}
+		}
+	}
+}
+
+func TestSqrtOptsTolerance(t *testing.T) {
+	for _, test := range []struct {
+		tol  float64
+		prec uint // precision of the result
+	}{
+		{0.75, 1},
+		{0.25, 2},
+		{1e-10, 34},
+		{1e-100, 333},
+		{1e-300, 997},
+		{5e-324, 1000}, // below z's precision
+	} {
+		z := big.NewFloat(2).SetPrec(1000)
+		x, err := bigfloat.SqrtOpts(z, bigfloat.SqrtOptions{Tolerance: test.tol})
+		if err != nil {
+			t.Fatalf("SqrtOpts(2, {Tolerance: %g}) returned error %v", test.tol, err)
+		}
+		want := bigfloat.SqrtPrec(z, test.prec, z.Mode())
+		if x.Cmp(want) != 0 || x.Prec() != test.prec {
+			t.Errorf("SqrtOpts(2, {Tolerance: %g}) =\ngot  %g (prec = %d);\nwant %g (prec = %d)", test.tol, x, x.Prec(), want, test.prec)
+		}
+
+		// check the relative error against the tolerance
+		d := new(big.Float).Sub(x, bigfloat.Sqrt(z))
+		d.Quo(d, x)
+		if df, _ := d.Float64(); math.Abs(df) > test.tol {
+			t.Errorf("SqrtOpts(2, {Tolerance: %g}) has relative error %g", test.tol, df)
+		}
+	}
+}
+
+func TestSqrtOptsSpecialValues(t *testing.T) {
+	for _, f := range []float64{
+		+0.0,
+		-0.0,
+		math.Inf(+1),
+	} {
+		x, err := bigfloat.SqrtOpts(big.NewFloat(f), bigfloat.SqrtOptions{MaxIter: 1, Tolerance: 1e-3})
+		x64, _ := x.Float64()
+		want := math.Sqrt(f)
+		if err != nil || x64 != want || math.Signbit(x64) != math.Signbit(want) {
+			t.Errorf("SqrtOpts(%g) = %g, %v; want %g, nil", f, x64, err, want)
+		}
+	}
+
+	if x, err := bigfloat.SqrtOpts(big.NewFloat(-1), bigfloat.SqrtOptions{}); x != nil || err != bigfloat.ErrNegative {
+		t.Errorf("SqrtOpts(-1) = %v, %v; want nil, ErrNegative", x, err)
+	}
+}
+
+var withFuncs = []struct {
+	name string
+	f    func(*big.Float) *big.Float
+	g    func(*big.Float, ...bigfloat.Option) *big.Float
+}{
+	{"Sqrt", bigfloat.Sqrt, bigfloat.SqrtWith},
+	{"Log", bigfloat.Log, bigfloat.LogWith},
+	{"Exp", bigfloat.Exp, bigfloat.ExpWith},
+}
+
+func TestWithDefault(t *testing.T) {
+	for _, test := range withFuncs {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 500, 1000} {
+			for _, mode := range []big.RoundingMode{big.ToNearestEven, big.ToZero, big.AwayFromZero} {
+				for _, f := range []float64{2, 0.1, 10} {
+					z := big.NewFloat(f).SetPrec(prec).SetMode(mode)
+					want := test.f(z)
+					if x := test.g(z); x.Cmp(want) != 0 || x.Prec() != prec || x.Mode() != mode {
+						t.Errorf("prec = %d, %sWith(%g) =\ngot  %g;\nwant %g", prec, test.name, f, x, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestWithPrec(t *testing.T) {
+	for _, test := range withFuncs {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 500, 1000} {
+			z := big.NewFloat(0.1) // 53 bits, exact at higher precisions
+
+			// the result is computed with the precision of z if it's
+			// larger, and with the result's one otherwise
+			var want *big.Float
+			if prec < 53 {
+				want = test.f(z).SetPrec(prec)
+			} else {
+				want = test.f(new(big.Float).SetPrec(prec).Set(z))
+			}
+
+			if x := test.g(z, bigfloat.WithPrec(prec)); x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("%sWith(0.1, WithPrec(%d)) =\ngot  %g;\nwant %g", test.name, prec, x, want)
+			}
+		}
+
+		// 0 is the precision of the argument
+		z := big.NewFloat(2).SetPrec(100)
+		if x := test.g(z, bigfloat.WithPrec(0)); x.Cmp(test.f(z)) != 0 || x.Prec() != 100 {
+			t.Errorf("%sWith(2, WithPrec(0)) = %g (prec = %d); want %g (prec = 100)", test.name, x, x.Prec(), test.f(z))
+		}
+	}
+}
+
+// With guard bits, the results rounded down and up are the floats
+// around the exact value.
+func TestWithModeGuardBits(t *testing.T) {
+	for _, test := range withFuncs {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 500, 1000} {
+			z := big.NewFloat(3).SetPrec(prec)
+			exact := test.f(new(big.Float).SetPrec(prec + 200).Set(z))
+
+			down := test.g(z, bigfloat.WithMode(big.ToNegativeInf), bigfloat.WithGuardBits(64))
+			up := test.g(z, bigfloat.WithMode(big.ToPositiveInf), bigfloat.WithGuardBits(64))
+			if down.Cmp(exact) >= 0 || up.Cmp(exact) <= 0 || bigfloat.NextAfter(down, up).Cmp(up) != 0 {
+				t.Errorf("prec = %d, %sWith(3) rounded down and up =\n%g,\n%g;\nexact %g", prec, test.name, down, up, exact)
+			}
+			if down.Mode() != big.ToNegativeInf || up.Mode() != big.ToPositiveInf || down.Prec() != prec || up.Prec() != prec {
+				t.Errorf("prec = %d, %sWith(3) returned modes %s, %s and precisions %d, %d", prec, test.name, down.Mode(), up.Mode(), down.Prec(), up.Prec())
+			}
+
+			// to nearest, it's the closest of the two
+			want := down
+			if d, u := new(big.Float).Sub(exact, down), new(big.Float).Sub(up, exact); u.Cmp(d) < 0 {
+				want = up
+			}
+			if x := test.g(z, bigfloat.WithGuardBits(64)); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, %sWith(3, WithGuardBits(64)) =\ngot  %g;\nwant %g", prec, test.name, x, want)
+			}
+		}
+	}
+}
+
+func TestWithCompose(t *testing.T) {
+	for _, test := range withFuncs {
+		z := big.NewFloat(0.1).SetPrec(200)
+		want := test.f(new(big.Float).SetPrec(164).Set(z))
+		want.SetMode(big.ToZero).SetPrec(100)
+
+		// the options don't depend on each other, so their order doesn't
+		// matter
+		for _, opts := range [][]bigfloat.Option{
+			{bigfloat.WithPrec(100), bigfloat.WithMode(big.ToZero), bigfloat.WithGuardBits(64)},
+			{bigfloat.WithGuardBits(64), bigfloat.WithMode(big.ToZero), bigfloat.WithPrec(100)},
+		} {
+			if x := test.g(z, opts...); x.Cmp(want) != 0 || x.Prec() != 100 || x.Mode() != big.ToZero {
+				t.Errorf("%sWith(0.1) with the options in order =\ngot  %g;\nwant %g", test.name, x, want)
+			}
+		}
+
+		// the last option of a kind is used
+		if x := test.g(z, bigfloat.WithPrec(50), bigfloat.WithMode(big.AwayFromZero), bigfloat.WithPrec(100), bigfloat.WithMode(big.ToZero), bigfloat.WithGuardBits(64)); x.Cmp(want) != 0 || x.Prec() != 100 || x.Mode() != big.ToZero {
+			t.Errorf("%sWith(0.1) with repeated options =\ngot  %g;\nwant %g", test.name, x, want)
+		}
+	}
+}
+
+func TestSqrtWithNegative(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("SqrtWith(-1) did not panic")
+		}
+	}()
+	bigfloat.SqrtWith(big.NewFloat(-1), bigfloat.WithPrec(100))
+}
@@ -0,0 +1,169 @@
+package bigfloat
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// PolyEval returns a big.Float representation of the polynomial with
+// coefficients coeffs evaluated at x,
+//
+//	coeffs[0] + coeffs[1]·x + coeffs[2]·x² + ... + coeffs[n-1]·xⁿ⁻¹
+//
+// computed with Horner's method. Precision is the largest of the
+// precisions of x and of the coefficients. The function returns 0 if
+// coeffs is empty.
+func PolyEval(coeffs []*big.Float, x *big.Float) *big.Float {
+	prec := x.Prec()
+	for _, c := range coeffs {
+		if c.Prec() > prec {
+			prec = c.Prec()
+		}
+	}
+
+	if len(coeffs) == 0 {
+		return new(big.Float).SetPrec(prec)
+	}
+
+	// each of the n steps carries a rounding error, so add log2(n)
+	// more guard digits
+	p := prec + 64 + uint(bits.Len(uint(len(coeffs)))) // guard digits
+
+	y := new(big.Float).SetPrec(p).Set(coeffs[len(coeffs)-1])
+	for i := len(coeffs) - 2; i >= 0; i-- {
+		y.Mul(y, x)
+		y.Add(y, coeffs[i])
+	}
+
+	return y.SetPrec(prec)
+}
+
+// SolveQuadratic returns the real roots of the equation
+//
+//	a·x² + b·x + c = 0
+//
+// in increasing order: two of them, one if the roots coincide, or
+// none if they are complex. If a is 0, it returns the root of the
+// linear equation b·x + c = 0, and none if b is 0 too. Precision is
+// the largest of the precisions of the coefficients. The function
+// panics if any of them is infinite.
+//
+// The roots are computed as
+//
+//	x₁ = q/a,    x₂ = c/q,    where q = -(b + sign(b)·√(b² - 4ac))/2
+//
+// which, unlike the textbook formula, doesn't lose bits to
+// cancellation when b² is much larger than 4ac.
+func SolveQuadratic(a, b, c *big.Float) []*big.Float {
+	if a.IsInf() || b.IsInf() || c.IsInf() {
+		panic("SolveQuadratic: argument is infinite")
+	}
+
+	prec := a.Prec()
+	for _, x := range []*big.Float{b, c} {
+		if x.Prec() > prec {
+			prec = x.Prec()
+		}
+	}
+	p := prec + 64 // guard digits
+
+	// linear equation
+	if a.Sign() == 0 {
+		if b.Sign() == 0 {
+			return nil
+		}
+		x := new(big.Float).SetPrec(p).Quo(c, b)
+		return []*big.Float{x.Neg(x).SetPrec(prec)}
+	}
+
+	// The discriminant may be much smaller than b² and 4ac, so compute
+	// them exactly before subtracting.
+	d := new(big.Float).SetPrec(2*b.Prec()).Mul(b, b)
+	ac := new(big.Float).SetPrec(a.Prec()+c.Prec()).Mul(a, c)
+	ac.SetMantExp(ac, 2)
+	d = new(big.Float).SetPrec(p).Sub(d, ac)
+
+	switch d.Sign() {
+	case -1:
+		return nil
+	case 0:
+		// x = -b/2a
+		x := new(big.Float).SetPrec(p).Quo(b, a)
+		x.SetMantExp(x, -1)
+		return []*big.Float{x.Neg(x).SetPrec(prec)}
+	}
+
+	q := Sqrt(d)
+	if b.Sign() < 0 {
+		q.Neg(q)
+	}
+	q.Add(q, b)
+	q.SetMantExp(q, -1)
+	q.Neg(q)
+
+	x1 := new(big.Float).SetPrec(p).Quo(q, a)
+	x2 := new(big.Float).SetPrec(p).Quo(c, q)
+	if x1.Cmp(x2) > 0 {
+		x1, x2 = x2, x1
+	}
+	return []*big.Float{x1.SetPrec(prec), x2.SetPrec(prec)}
+}
+
+// PolyRoots refines the approximate roots in guesses of the polynomial
+// with coefficients coeffs (in the same order as in PolyEval), and
+// returns them with prec bits of precision. If prec is 0, the largest
+// of the precisions of the coefficients is used. The derivative of the
+// polynomial is computed from the coefficients, and each root is found
+// with Newton's method.
+//
+// The guesses only need to be close enough to a root for the
+// iteration to converge to it; a few correct bits are usually enough.
+// Each guess is first polished with 64 bits of precision, and then
+// refined to prec bits in steps that double the precision. Near a
+// multiple root, or a cluster of close roots, the derivative is small
+// and the convergence is only linear: the polishing stops before the
+// guess is accurate, and the result may have far fewer than prec
+// correct bits. PolyRoots does not check for convergence.
+func PolyRoots(coeffs []*big.Float, guesses []float64, prec uint) []*big.Float {
+	if prec == 0 {
+		for _, c := range coeffs {
+			if c.Prec() > prec {
+				prec = c.Prec()
+			}
+		}
+	}
+
+	// p'(x) = coeffs[1] + 2·coeffs[2]·x + ...; the coefficients of the
+	// derivative are exact
+	dcoeffs := make([]*big.Float, 0, len(coeffs))
+	for i := 1; i < len(coeffs); i++ {
+		p := coeffs[i].Prec() + uint(bits.Len(uint(i)))
+		dcoeffs = append(dcoeffs, new(big.Float).SetPrec(p).Mul(coeffs[i], big.NewFloat(float64(i))))
+	}
+
+	// f(t)/f'(t) = p(t)/p'(t), and 0 at a root, even a multiple one
+	fOverDf := func(t *big.Float) *big.Float {
+		x := PolyEval(coeffs, t)
+		if x.Sign() == 0 {
+			return x
+		}
+		return x.Quo(x, PolyEval(dcoeffs, t))
+	}
+
+	roots := make([]*big.Float, len(guesses))
+	for i, g := range guesses {
+		// newton takes the precision of the guess as its number of
+		// correct bits, so iterate until the steps are below 2**-48
+		// of the guess first
+		x := big.NewFloat(g).SetPrec(64)
+		for n := 0; n < 100; n++ {
+			d := fOverDf(x)
+			x.Sub(x, d)
+			if d.Sign() == 0 || x.Sign() == 0 || d.MantExp(nil) < x.MantExp(nil)-48 {
+				break
+			}
+		}
+		roots[i] = newton(fOverDf, x.SetPrec(48), prec)
+	}
+	return roots
+}
@@ -0,0 +1,208 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+// floats returns the big.Float representations of fs, with
+// precision prec.
+func floats(prec uint, fs ...float64) []*big.Float {
+	var xs []*big.Float
+	for _, f := range fs {
+		xs = append(xs, big.NewFloat(f).SetPrec(prec))
+	}
+	return xs
+}
+
+func TestPolyEval(t *testing.T) {
+	// p(x) = 3 - 2x + 0.5x² + x³ - 0.25x⁵
+	coeffs := []float64{3, -2, 0.5, 1, 0, -0.25}
+	for _, test := range []struct {
+		x    float64
+		want float64
+	}{
+		{0, 3},
+		{1, 2.25},
+		{-1, 4.75},
+		{1.5, 2.6015625},
+		{-2.25, 13.056884765625},
+		{10, -23967},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			x := bigfloat.PolyEval(floats(prec, coeffs...), big.NewFloat(test.x).SetPrec(prec))
+			if x.Cmp(big.NewFloat(test.want)) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, p(%g) = %g (prec = %d); want %g", prec, test.x, x, x.Prec(), test.want)
+			}
+		}
+	}
+}
+
+// Random polynomials, checked against an exact evaluation.
+func TestPolyEvalRat(t *testing.T) {
+	rnd := rand.New(rand.NewSource(4))
+	for _, prec := range []uint{24, 53, 100, 500, 1000} {
+		for i := 0; i < 100; i++ {
+			coeffs := make([]*big.Float, rnd.Intn(20)+1)
+			for j := range coeffs {
+				coeffs[j] = big.NewFloat(rnd.NormFloat64()).SetPrec(prec)
+			}
+			x := big.NewFloat(rnd.Float64()*4 - 2).SetPrec(prec)
+
+			// Horner's method with exact rationals
+			xr, _ := x.Rat(nil)
+			exact := new(big.Rat)
+			for j := len(coeffs) - 1; j >= 0; j-- {
+				c, _ := coeffs[j].Rat(nil)
+				exact.Mul(exact, xr)
+				exact.Add(exact, c)
+			}
+			want := new(big.Float).SetPrec(prec).SetRat(exact)
+
+			if got := bigfloat.PolyEval(coeffs, x); got.Cmp(want) != 0 {
+				t.Errorf("prec = %d, PolyEval(%v, %g) =\ngot  %g;\nwant %g", prec, coeffs, x, got, want)
+			}
+		}
+	}
+}
+
+func TestPolyEvalPrec(t *testing.T) {
+	x := big.NewFloat(2).SetPrec(53)
+	if y := bigfloat.PolyEval(nil, x); y.Sign() != 0 || y.Prec() != 53 {
+		t.Errorf("PolyEval(nil, 2) = %g (prec = %d); want 0 (prec = 53)", y, y.Prec())
+	}
+
+	coeffs := append(floats(100, 1, 2), big.NewFloat(3).SetPrec(500))
+	if y := bigfloat.PolyEval(coeffs, x); y.Cmp(big.NewFloat(17)) != 0 || y.Prec() != 500 {
+		t.Errorf("PolyEval(1 + 2x + 3x², 2) = %g (prec = %d); want 17 (prec = 500)", y, y.Prec())
+	}
+}
+
+func TestSolveQuadratic(t *testing.T) {
+	const sqrt2 = "1.4142135623730950488016887242096980785696718753769480731766797379907324784621070388503875343276415727350138462309122970249248360558507372126441214970999358314132226659275055927557999505011527820605714701095599716059702745345968620147285174186408891986095523292304843087143214508397626036279952514079896872533965463318088296406206152583523950547457503"
+	for _, test := range []struct {
+		a, b, c float64
+		want    []string
+	}{
+		{1, -3, 2, []string{"1", "2"}},           // distinct roots
+		{1, -2, 1, []string{"1"}},                // repeated root
+		{4, 12, 9, []string{"-1.5"}},             // repeated root
+		{1, 0, 1, nil},                           // complex roots
+		{1, 1, 1, nil},                           // complex roots
+		{1, 0, -2, []string{"-" + sqrt2, sqrt2}}, // b = 0
+		{-1, 0, 2, []string{"-" + sqrt2, sqrt2}}, // a < 0
+		{1, 5, 0, []string{"-5", "0"}},           // c = 0
+		{0, 2, -4, []string{"2"}},                // linear
+		{0, 0, 1, nil},                           // no equation
+		{2, 3, -7, []string{
+			"-2.7655644370746374130916533075759427827835990764021433469841480973159687377564220507400385666793076609093606165349864780534371630300928404329660166249659879086670183119035241151944538424268184622436968723483813609849172996842329303487493648101502658807531508046749790846604003139275165237380852794868428936764312492964004574624763974487280383087735324",
+			"1.2655644370746374130916533075759427827835990764021433469841480973159687377564220507400385666793076609093606165349864780534371630300928404329660166249659879086670183119035241151944538424268184622436968723483813609849172996842329303487493648101502658807531508046749790846604003139275165237380852794868428936764312492964004574624763974487280383087735324",
+		}},
+
+		// the small root is lost to cancellation by the textbook
+		// formula
+		{1, -1e10, 1, []string{
+			"1.0000000000000000000100000000000000000002000000000000000000050000000000000000001400000000000000000042000000000000000001320000000000000000042900000000000000001430000000000000000048620000000000000001679600000000000000058786000000000000002080120000000000000074290000000000000002674440000000000000096948450000000000003535767000000000000129644790000000000e-10",
+			"9999999999.9999999998999999999999999999989999999999999999999799999999999999999994999999999999999999859999999999999999995799999999999999999867999999999999999995709999999999999999856999999999999999995137999999999999999832039999999999999994121399999999999999791987999999999999992570999999999999999732555999999999999990305154999999999999646423300000000000",
+		}},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			abc := floats(prec, test.a, test.b, test.c)
+			roots := bigfloat.SolveQuadratic(abc[0], abc[1], abc[2])
+
+			if len(roots) != len(test.want) {
+				t.Errorf("prec = %d, SolveQuadratic(%g, %g, %g) returned %d roots; want %d", prec, test.a, test.b, test.c, len(roots), len(test.want))
+				continue
+			}
+			for i, x := range roots {
+				want := new(big.Float).SetPrec(prec)
+				want.Parse(test.want[i], 10)
+				if x.Cmp(want) != 0 || x.Prec() != prec {
+					t.Errorf("prec = %d, SolveQuadratic(%g, %g, %g)[%d] =\ngot  %g (prec = %d);\nwant %g", prec, test.a, test.b, test.c, i, x, x.Prec(), want)
+				}
+			}
+		}
+	}
+}
+
+// b² and 4ac agree to 120 bits, more than the precision of the
+// coefficients, but the discriminant is not 0.
+func TestSolveQuadraticSmallDiscriminant(t *testing.T) {
+	// (x - 1)(x - (1 + 2**-60)) = x² - (2 + 2**-60)x + (1 + 2**-60)
+	e := new(big.Float).SetPrec(100).SetMantExp(big.NewFloat(1).SetPrec(100), -60)
+	one := big.NewFloat(1).SetPrec(100)
+	b := new(big.Float).Add(e, big.NewFloat(2))
+	c := new(big.Float).Add(e, one)
+	roots := bigfloat.SolveQuadratic(one, b.Neg(b), c)
+	if len(roots) != 2 || roots[0].Cmp(one) != 0 || roots[1].Cmp(c) != 0 {
+		t.Errorf("SolveQuadratic(1, -(2 + 2**-60), 1 + 2**-60) = %v; want [1, 1 + 2**-60]", roots)
+	}
+}
+
+func TestSolveQuadraticPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("SolveQuadratic(1, +Inf, 1) did not panic")
+		}
+	}()
+	bigfloat.SolveQuadratic(big.NewFloat(1), big.NewFloat(math.Inf(+1)), big.NewFloat(1))
+}
+
+func TestPolyRoots(t *testing.T) {
+	// x⁴ - 10x² + 1, with roots ±√2 ± √3
+	coeffs := []float64{1, 0, -10, 0, 1}
+	guesses := []float64{-3, -0.3, 0.3, 3}
+	want := []string{
+		"-3.1462643699419723423291350657155704455124771291873287012324867174426654953709070759315337210848901484106399876463190000548947811508496896914557770091943094799413158978298111748237747706022374569838364854219032385092931611852691166836503553898679363302699201908185033587008588306991520712783427579840404438717446759379097772428078055834355408842697101",
+		"-0.31783724519578224472575761729617428837313337843343255487912724146120053844669299823075865242960700294061229518449440600504510903914821526616753401499443781711487056597479998931217486959993189286269354520278329529735261211607539265419332055258615793305081553235753474127221592901962686402235225516806106936495158327429211796156657506673075077477820955",
+		"0.31783724519578224472575761729617428837313337843343255487912724146120053844669299823075865242960700294061229518449440600504510903914821526616753401499443781711487056597479998931217486959993189286269354520278329529735261211607539265419332055258615793305081553235753474127221592901962686402235225516806106936495158327429211796156657506673075077477820955",
+		"3.1462643699419723423291350657155704455124771291873287012324867174426654953709070759315337210848901484106399876463190000548947811508496896914557770091943094799413158978298111748237747706022374569838364854219032385092931611852691166836503553898679363302699201908185033587008588306991520712783427579840404438717446759379097772428078055834355408842697101",
+	}
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		roots := bigfloat.PolyRoots(floats(53, coeffs...), guesses, prec)
+		for i, x := range roots {
+			w := new(big.Float).SetPrec(prec)
+			w.Parse(want[i], 10)
+			if x.Cmp(w) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, root near %g =\ngot  %g (prec = %d);\nwant %g", prec, guesses[i], x, x.Prec(), w)
+			}
+		}
+	}
+
+	// the precision of the coefficients is the default
+	if roots := bigfloat.PolyRoots(floats(500, coeffs...), guesses[3:], 0); roots[0].Prec() != 500 {
+		t.Errorf("PolyRoots returned prec = %d; want 500", roots[0].Prec())
+	}
+}
+
+// An exact guess of a double root, where the derivative is 0 too.
+func TestPolyRootsMultiple(t *testing.T) {
+	// (x - 1)²(x + 2) = x³ - 3x + 2
+	coeffs := floats(100, 2, -3, 0, 1)
+	if roots := bigfloat.PolyRoots(coeffs, []float64{1, -2.1}, 100); roots[0].Cmp(big.NewFloat(1)) != 0 || roots[1].Cmp(big.NewFloat(-2)) != 0 {
+		t.Errorf("PolyRoots(x³ - 3x + 2, [1, -2.1]) = %v; want [1, -2]", roots)
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkPolyEval(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		coeffs := make([]*big.Float, 20)
+		for i := range coeffs {
+			coeffs[i] = big.NewFloat(1 / float64(i+1)).SetPrec(prec)
+		}
+		x := big.NewFloat(0.7).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.PolyEval(coeffs, x)
+			}
+		})
+	}
+}
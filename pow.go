@@ -1,13 +1,20 @@
 package bigfloat
 
-import "math/big"
+import (
+	"math"
+	"math/big"
+	"math/bits"
+)
 
 // Pow returns a big.Float representation of z**w. Precision is the same as the one
-// of the first argument. The function panics when z is negative.
+// of the first argument. The function panics when z is negative and w is not an
+// integer. Pow(±0, w) is +0 when w > 0 and +Inf when w < 0; Pow(+Inf, w)
+// is +Inf when w > 0 and +0 when w < 0. Integer exponents are computed using
+// binary exponentiation.
 func Pow(z *big.Float, w *big.Float) *big.Float {
 
-	if z.Sign() < 0 {
-		panic("Pow: negative base")
+	if z.Sign() < 0 && !w.IsInt() {
+		panic("Pow: negative base with non-integer exponent")
 	}
 
 	// Pow(z, 0) = 1.0
@@ -16,56 +23,184 @@ func Pow(z *big.Float, w *big.Float) *big.Float {
 	}
 
 	// Pow(z, 1) = z
-	// Pow(+Inf, n) = +Inf
-	if w.Cmp(big.NewFloat(1)) == 0 || z.IsInf() {
+	if w.Cmp(big.NewFloat(1)) == 0 {
 		return new(big.Float).Copy(z)
 	}
 
+	// Pow(±0, w) = +0 for w > 0
+	// Pow(±0, w) = +Inf for w < 0
+	// Pow(+Inf, w) = +Inf for w > 0
+	// Pow(+Inf, w) = +0 for w < 0
+	if (z.Sign() == 0 || z.IsInf()) && z.Sign() >= 0 {
+		if (z.Sign() == 0) == (w.Sign() > 0) {
+			return new(big.Float).SetPrec(z.Prec())
+		}
+		return new(big.Float).SetPrec(z.Prec()).SetInf(false)
+	}
+
+	// Pow(1, w) = 1
+	if z.Cmp(big.NewFloat(1)) == 0 {
+		return big.NewFloat(1).SetPrec(z.Prec())
+	}
+
 	// Pow(z, -w) = 1 / Pow(z, w)
 	if w.Sign() < 0 {
 		x := new(big.Float)
-		zExt := new(big.Float).Copy(z).SetPrec(z.Prec() + 64)
+		zExt := WithGuard(z, 64)
 		wNeg := new(big.Float).Neg(w)
 		return x.Quo(big.NewFloat(1), Pow(zExt, wNeg)).SetPrec(z.Prec())
 	}
 
-	// w integer fast path (disabled because introduces rounding
-	// errors)
-	if false && w.IsInt() {
-		wi, _ := w.Int64()
-		return powInt(z, int(wi))
+	// w integer fast path
+	if wi, acc := w.Int64(); w.IsInt() && acc == big.Exact {
+		return powInt(z, uint64(wi))
 	}
 
 	// compute w**z as exp(z log(w))
+	//
+	// If z < 0, w must be an integer too large for powInt, so we use
+	// |z| and fix the sign afterwards. w is odd if its last bit is the
+	// units bit, which can happen when w has more than 63 bits of
+	// precision.
 	x := new(big.Float).SetPrec(z.Prec() + 64)
-	logZ := Log(new(big.Float).Copy(z).SetPrec(z.Prec() + 64))
+	logZ := Log(new(big.Float).Abs(z).SetPrec(z.Prec() + 64))
 	x.Mul(w, logZ)
 	x = Exp(x)
+	if z.Sign() < 0 && w.MantExp(nil) <= int(w.Prec()) {
+		if wi, _ := w.Int(nil); wi.Bit(0) == 1 {
+			x.Neg(x)
+		}
+	}
 	return x.SetPrec(z.Prec())
 
 }
 
-// fast path for z**w when w is an integer
-func powInt(z *big.Float, w int) *big.Float {
+// PowInt returns a big.Float representation of z**n, computed using
+// binary exponentiation. Precision is the same as the one of the
+// argument. PowInt(z, 0) is 1 for every z, including ±0 and ±Inf;
+// PowInt(±0, n) is +0 when n > 0 and +Inf when n < 0.
+func PowInt(z *big.Float, n int) *big.Float {
 
-	// get mantissa and exponent of z
-	mant := new(big.Float)
-	exp := z.MantExp(mant)
+	// PowInt(z, 0) = 1
+	if n == 0 {
+		return big.NewFloat(1).SetPrec(z.Prec())
+	}
 
-	// result's exponent
-	exp = exp * w
+	// PowInt(±0, n) = +0 for n > 0
+	// PowInt(±0, n) = +Inf for n < 0
+	if z.Sign() == 0 {
+		if n > 0 {
+			return new(big.Float).SetPrec(z.Prec())
+		}
+		return new(big.Float).SetPrec(z.Prec()).SetInf(false)
+	}
+
+	if n > 0 {
+		return powInt(z, uint64(n))
+	}
+
+	// PowInt(z, -n) = 1 / PowInt(z, n). -n overflows when n is the
+	// smallest int, so compute |n| as an unsigned.
+	zExt := WithGuard(z, 64)
+	x := powInt(zExt, uint64(-(n+1))+1)
+	return x.Quo(big.NewFloat(1), x).SetPrec(z.Prec())
+}
 
-	// result's mantissa
-	x := big.NewFloat(1).SetPrec(z.Prec())
+// PowRat returns a big.Float representation of z**e, for a rational
+// exponent e = p/q in lowest terms. Precision is the same as the one
+// of the first argument. When q <= 1024 and p fits in an int32, the
+// result is computed as Root(z, q)**p, which is exact when the root
+// is, as in 8**(2/3) = 4; otherwise, it's computed as exp(e·log(z)).
+//
+// A negative z has a real root if q is odd, so PowRat(z, p/q) is
+// -PowRat(-z, p/q) for odd p and PowRat(-z, p/q) for even p; the
+// function panics if z is negative and q is even. PowRat(±0, e) is
+// +0 when e > 0 and +Inf when e < 0, and PowRat(z, 0) is 1 for every
+// z.
+func PowRat(z *big.Float, e *big.Rat) *big.Float {
+
+	p, q := e.Num(), e.Denom()
+	if z.Sign() < 0 && q.Bit(0) == 0 {
+		panic("PowRat: negative base with even denominator")
+	}
+
+	// PowRat(z, 0) = 1
+	if p.Sign() == 0 {
+		return big.NewFloat(1).SetPrec(z.Prec())
+	}
+
+	// PowRat(z, p/q) = ±PowRat(-z, p/q) for negative z
+	neg := z.Sign() < 0 && p.Bit(0) == 1
+	x := new(big.Float).Abs(z)
+
+	// PowRat(±0, e) = +0 for e > 0, +Inf for e < 0
+	// PowRat(±Inf, e) = ±Inf for e > 0, ±0 for e < 0
+	if x.Sign() == 0 || x.IsInf() {
+		r := new(big.Float).SetPrec(z.Prec())
+		if (x.Sign() == 0) != (p.Sign() > 0) {
+			r.SetInf(false)
+		}
+		if neg && z.IsInf() {
+			r.Neg(r)
+		}
+		return r
+	}
+
+	if q.IsInt64() && q.Int64() <= 1024 && p.IsInt64() && p.Int64() >= math.MinInt32 && p.Int64() <= math.MaxInt32 {
+		n := int(p.Int64())
+		switch {
+		case n == 1:
+			x = Root(x, int(q.Int64()))
+		default:
+			// the relative error of the root is multiplied by |p|
+			guard := 64 + uint(p.BitLen())
+			x = PowInt(Root(x.SetPrec(z.Prec()+guard), int(q.Int64())), n)
+		}
+	} else {
+		// The error of e·log(z) is also the relative error of the
+		// result, so if e·log(z) is large recompute it with as many
+		// more guard digits.
+		prec := z.Prec() + 64
+		t := eLogZ(x, e, prec)
+		if exp := t.MantExp(nil); exp > 0 {
+			prec += uint(exp)
+			t = eLogZ(x, e, prec)
+		}
+		x = Exp(t)
+	}
+
+	if neg {
+		x.Neg(x)
+	}
+	return x.SetPrec(z.Prec())
+}
+
+// eLogZ returns e·log(z) computed with prec bits of precision.
+func eLogZ(z *big.Float, e *big.Rat, prec uint) *big.Float {
+	t := Log(new(big.Float).SetPrec(prec).Set(z))
+	return t.Mul(t, FromRat(e, prec))
+}
+
+// fast path for z**w when w is a positive integer
+func powInt(z *big.Float, w uint64) *big.Float {
+
+	// Every squaring doubles the relative error accumulated so far,
+	// so we need about log2(w) guard digits on top of the usual 64.
+	prec := z.Prec() + 64 + uint(bits.Len64(w))
+
+	x := big.NewFloat(1).SetPrec(prec)
+	t := new(big.Float).Copy(z).SetPrec(prec)
 
 	// Classic right-to-left binary exponentiation
 	for w > 0 {
 		if w%2 == 1 {
-			x.Mul(x, mant)
+			x.Mul(x, t)
 		}
 		w >>= 1
-		mant.Mul(mant, mant)
+		if w > 0 {
+			t.Mul(t, t)
+		}
 	}
 
-	return new(big.Float).SetMantExp(x, exp)
+	return x.SetPrec(z.Prec())
 }
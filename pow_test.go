@@ -53,6 +53,12 @@ func TestPowIntegers(t *testing.T) {
 		{"2", "-64", "5.42101086242752217003726400434970855712890625e-20"},
 
 		{"1.5", "8", "25.62890625"},
+		{"1.5", "100", "406561177535215237.3972797075670416710103878906323797634290517698787563831961701377171181093217455781996250152587890625"},
+		{"3", "200", "265613988875874769338781322035779626829233452653394495974574961739092490901302182994384699044001"},
+
+		{"-1.5", "7", "-17.0859375"},
+		{"-1.5", "8", "25.62890625"},
+		{"-2", "-3", "-0.125"},
 	} {
 		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
 			want := new(big.Float).SetPrec(prec)
@@ -72,6 +78,130 @@ func TestPowIntegers(t *testing.T) {
 	}
 }
 
+func TestPowInt(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		n    int
+		want string
+	}{
+		{"2", 10, "1024"},
+		{"2", 64, "18446744073709551616"},
+		{"2", -10, "0.0009765625"},
+		{"1.5", 100, "406561177535215237.3972797075670416710103878906323797634290517698787563831961701377171181093217455781996250152587890625"},
+		{"3", 200, "265613988875874769338781322035779626829233452653394495974574961739092490901302182994384699044001"},
+		{"-1.5", 7, "-17.0859375"},
+		{"-2", -3, "-0.125"},
+		{"0", 0, "1"},
+		{"0", 5, "0"},
+		{"-7", 1, "-7"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			if x := bigfloat.PowInt(z, test.n); x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, PowInt(%v, %d) =\ngot  %g;\nwant %g", prec, test.z, test.n, x, want)
+			}
+		}
+	}
+}
+
+// For z = a/2**k and integer n, z**n is a rational number that can
+// be computed exactly, so check that PowInt is correctly rounded.
+func TestPowIntRat(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		a := rand.Int63n(1<<20) + 1
+		k := rand.Intn(20)
+		n := rand.Intn(401) - 200
+		for _, prec := range []uint{53, 100, 500, 1000} {
+			z := new(big.Float).SetPrec(prec).SetInt64(a)
+			z.SetMantExp(z, -k)
+
+			r, _ := z.Rat(nil)
+			p := new(big.Rat).SetInt64(1)
+			for j := 0; j < abs(n); j++ {
+				p.Mul(p, r)
+			}
+			if n < 0 {
+				p.Inv(p)
+			}
+			want := new(big.Float).SetPrec(prec).SetRat(p)
+
+			if x := bigfloat.PowInt(z, n); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, PowInt(%g, %d) =\ngot  %g;\nwant %g", prec, z, n, x, want)
+			}
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func TestPowIntAgreesWithPow(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 17, 100, 1000, -1, -2, -17, -1000} {
+		for _, prec := range []uint{100, 500, 1000, 2000} {
+			z := new(big.Float).SetPrec(prec)
+			z.Parse("1.0001", 10)
+			w := new(big.Float).SetPrec(prec).SetInt64(int64(n))
+			if x, want := bigfloat.PowInt(z, n), bigfloat.Pow(z, w); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, PowInt(1.0001, %d) =\ngot  %g;\nwant %g", prec, n, x, want)
+			}
+		}
+	}
+}
+
+func TestPowIntSpecialValues(t *testing.T) {
+	for _, test := range []struct {
+		z    float64
+		n    int
+		want float64
+	}{
+		{+0.0, 0, 1},
+		{-0.0, 0, 1},
+		{math.Inf(+1), 0, 1},
+		{+0.0, 3, +0.0},
+		{-0.0, 3, +0.0},
+		{+0.0, -3, math.Inf(+1)},
+		{-0.0, -3, math.Inf(+1)},
+		{math.Inf(+1), 3, math.Inf(+1)},
+		{math.Inf(+1), -3, +0.0},
+		{math.Inf(-1), 3, math.Inf(-1)},
+		{math.Inf(-1), 2, math.Inf(+1)},
+		{2, math.MinInt64, +0.0},
+		{1, math.MinInt64, 1},
+	} {
+		z := big.NewFloat(test.z).SetPrec(53)
+		x64, acc := bigfloat.PowInt(z, test.n).Float64()
+		if x64 != test.want || math.Signbit(x64) != math.Signbit(test.want) || acc != big.Exact {
+			t.Errorf("PowInt(%g, %d) =\n got %g (%s);\nwant %g (Exact)", test.z, test.n, x64, acc, test.want)
+		}
+	}
+}
+
+func TestPowHalf(t *testing.T) {
+	for _, z := range []string{"2", "3", "5", "0.1", "1e10"} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(z, 10)
+			half := big.NewFloat(0.5).SetPrec(prec)
+
+			pow := bigfloat.Pow(x, half)
+			want := bigfloat.Sqrt(x)
+
+			if pow.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Pow(%v, 0.5) =\ngot  %g;\nwant %g", prec, z, pow, want)
+			}
+		}
+	}
+}
+
 func testPowFloat64(scale float64, nTests int, t *testing.T) {
 	for i := 0; i < nTests; i++ {
 		r1 := math.Abs(rand.Float64() * scale) // base always > 0
@@ -120,6 +250,12 @@ func TestPowSpecialValues(t *testing.T) {
 		{2, -0.0},
 		{4.2, 1.0},
 		{math.Inf(+1), 2.0},
+		{math.Inf(+1), -2.0},
+		{+0.0, 2.5},
+		{+0.0, -2.5},
+		{1.0, 3.7},
+		{1.0, -3.7},
+		{-2.0, 3.0},
 	} {
 		z := big.NewFloat(f.z).SetPrec(53)
 		w := big.NewFloat(f.w).SetPrec(53)
@@ -131,6 +267,129 @@ func TestPowSpecialValues(t *testing.T) {
 	}
 }
 
+func TestPowNegativeBase(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Pow(-2, 0.5) did not panic")
+		}
+	}()
+	bigfloat.Pow(big.NewFloat(-2), big.NewFloat(0.5))
+}
+
+// Integer exponents too large for the fast path are odd when they
+// have enough bits of precision to have a units bit.
+func TestPowNegativeBaseLargeExponent(t *testing.T) {
+	two64 := new(big.Float).SetPrec(100).SetMantExp(big.NewFloat(1).SetPrec(100), 64)
+	odd := new(big.Float).SetPrec(100).Add(two64, big.NewFloat(1))
+	even := new(big.Float).SetPrec(100).Add(two64, big.NewFloat(2))
+
+	for _, test := range []struct {
+		z    string
+		w    *big.Float
+		sign int
+		inf  bool
+	}{
+		{"-1.0000001", odd, -1, true},
+		{"-1.0000001", even, +1, true},
+		{"-0.9999999", odd, -1, false},
+		{"-1.00000000000000000000001", odd, -1, false},
+		{"-1.00000000000000000000001", even, +1, false},
+		{"-1.0000001", two64, +1, true},
+	} {
+		z := new(big.Float).SetPrec(100)
+		z.Parse(test.z, 10)
+		x := bigfloat.Pow(z, test.w)
+		if x.Signbit() != (test.sign < 0) || x.IsInf() != test.inf {
+			t.Errorf("Pow(%s, %s) = %g", test.z, test.w.Text('f', 0), x)
+		}
+	}
+}
+
+func TestPowRat(t *testing.T) {
+	for _, test := range []struct {
+		z    float64
+		p, q int64
+		want string
+	}{
+		{5, 2, 3, "2.9240177382128660655067873601379227785304986351010300414225735100725605585932173170657204553370813217828852194916325635422906476433320379219967161752366280136937345160001063735013096836532415791949587230172495188737691023610057513303403988535712351280272189737486624884170645237238792095878906768917849535542856960734726569962137360416284697546621994"},
+		{0.375, -7, 5, "3.9478098398629690458785120903929867243269686272958490525136566777982894111014980613906315870443162896400909625471048159589679302019407670241077078128647060476375311512989771947173069881409317260728820708000868189121814731303668596080445194074725190289296806087071209187696571147819399555483570268116340478766184828428608804866763968080401825601439685"},
+		{8, 2, 3, "4"},
+		{-8, 1, 3, "-2"},
+		{-8, 2, 3, "4"},
+		{-8, -1, 3, "-0.5"},
+		{-32, 3, 5, "-8"},
+		{2, 10, 1, "1024"},
+
+		// computed as exp(e·log(z))
+		{10, 123456789, 1000000007, "1.3287913371471514494172906548284493928161927725076675163417254655267241568818657369251023638641156734618159964125559297834454069203625325633962819489158707036107337580044890200268576706446045787054168823723527431396559998796392705427403886060274070520492294231548447521436030399477227889810337259942782491593542143542155175093112519096113262060058418"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := big.NewFloat(test.z).SetPrec(prec)
+			e := big.NewRat(test.p, test.q)
+			if x := bigfloat.PowRat(z, e); x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, PowRat(%g, %s) =\ngot  %g;\nwant %g", prec, test.z, e, x, want)
+			}
+		}
+	}
+
+	// a numerator too large for the root path, and a large
+	// e·log(z)
+	e, _ := new(big.Rat).SetString("1000000000000000000000000000001/10000000000000000000000000000")
+	want := new(big.Float).SetPrec(1000)
+	want.Parse("1267650600228229401496703205463.8668439483319573618263538078699144861746283139767442165390781280235079647473941689116827873780315618045934265264823531348891181939195951510031970790842775450697095781592266800568698794107594920376188975665813995678866964751310246677273701044421814184004463554171453285248493927175195201292627310554739595186142742218256", 10)
+	if x := bigfloat.PowRat(big.NewFloat(2).SetPrec(1000), e); x.Cmp(want) != 0 {
+		t.Errorf("PowRat(2, %s) =\ngot  %g;\nwant %g", e, x, want)
+	}
+}
+
+func TestPowRatSqrt(t *testing.T) {
+	half := big.NewRat(1, 2)
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		for _, f := range []float64{2, 3, 0.5, 1e10, 1e-300, 4} {
+			z := big.NewFloat(f).SetPrec(prec)
+			if x, want := bigfloat.PowRat(z, half), bigfloat.Sqrt(z); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, PowRat(%g, 1/2) =\ngot  %g;\nwant %g", prec, f, x, want)
+			}
+		}
+	}
+}
+
+func TestPowRatSpecialValues(t *testing.T) {
+	inf, ninf := math.Inf(+1), math.Inf(-1)
+	for _, test := range []struct {
+		z    float64
+		p, q int64
+		want float64
+	}{
+		{0, 1, 3, 0},
+		{math.Copysign(0, -1), 1, 3, 0},
+		{0, -1, 3, inf},
+		{inf, 2, 3, inf},
+		{inf, -2, 3, 0},
+		{ninf, 1, 3, ninf},
+		{ninf, -1, 3, math.Copysign(0, -1)},
+		{ninf, 2, 3, inf},
+		{-5, 0, 1, 1},
+		{inf, 0, 1, 1},
+	} {
+		x := bigfloat.PowRat(big.NewFloat(test.z), big.NewRat(test.p, test.q))
+		x64, _ := x.Float64()
+		if x64 != test.want || math.Signbit(x64) != math.Signbit(test.want) {
+			t.Errorf("PowRat(%g, %d/%d) = %g; want %g", test.z, test.p, test.q, x64, test.want)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("PowRat(-8, 1/2) did not panic")
+		}
+	}()
+	bigfloat.PowRat(big.NewFloat(-8), big.NewRat(1, 2))
+}
+
 // ---------- Benchmarks ----------
 
 func BenchmarkPowInt(b *testing.B) {
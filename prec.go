@@ -0,0 +1,30 @@
+package bigfloat
+
+import "math/big"
+
+// WithGuard returns a copy of z with guard more bits of precision,
+// and rounding mode ToNearestEven. Adding bits doesn't round, so the
+// copy has the same value as z. The functions of the package use it to
+// compute their results with guard digits, and then round them to the
+// precision of the argument, as in
+//
+//	x := WithGuard(z, 64)
+//	// ... compute with x ...
+//	return x.SetPrec(z.Prec())
+//
+// which keeps the rounding errors of the intermediate steps out of the
+// result.
+func WithGuard(z *big.Float, guard uint) *big.Float {
+	return new(big.Float).SetPrec(z.Prec() + guard).Set(z)
+}
+
+// RoundTo returns z rounded to prec bits with the given rounding mode,
+// and the accuracy of the result: big.Below or big.Above if rounding
+// made it smaller or larger than z, and big.Exact if z is representable
+// with prec bits. The result has rounding mode mode, and z is not
+// modified. As for big.Float.SetPrec, a prec of 0 rounds every finite
+// value to ±0, with the accuracy reporting the direction.
+func RoundTo(z *big.Float, prec uint, mode big.RoundingMode) (*big.Float, big.Accuracy) {
+	x := new(big.Float).Copy(z).SetMode(mode).SetPrec(prec)
+	return x, x.Acc()
+}
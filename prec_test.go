@@ -0,0 +1,91 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestWithGuard(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 1000} {
+		for _, f := range []float64{1, -1.5, math.Pi, 1e300, 0, math.Copysign(0, -1), math.Inf(+1), math.Inf(-1)} {
+			z := big.NewFloat(f).SetPrec(prec).SetMode(big.ToZero)
+			for _, guard := range []uint{0, 1, 64, 1000} {
+				x := bigfloat.WithGuard(z, guard)
+				if x.Prec() != prec+guard {
+					t.Errorf("WithGuard(%g, %d) has prec = %d; want %d", z, guard, x.Prec(), prec+guard)
+				}
+				if x.Cmp(z) != 0 || x.Signbit() != z.Signbit() || x.Acc() != big.Exact {
+					t.Errorf("WithGuard(%g, %d) = %g (acc = %s); want %g", z, guard, x, x.Acc(), z)
+				}
+				if x.Mode() != big.ToNearestEven {
+					t.Errorf("WithGuard(%g, %d) has mode %s; want ToNearestEven", z, guard, x.Mode())
+				}
+				if x == z {
+					t.Errorf("WithGuard(%g, %d) returned its argument", z, guard)
+				}
+			}
+		}
+	}
+
+	// rounding the copy back gives z again
+	z := new(big.Float).SetPrec(100).Quo(big.NewFloat(1), big.NewFloat(3))
+	if x := bigfloat.WithGuard(z, 64).SetPrec(100); x.Cmp(z) != 0 {
+		t.Errorf("WithGuard(1/3, 64) rounded to 100 bits = %g; want %g", x, z)
+	}
+}
+
+func TestRoundTo(t *testing.T) {
+	third := new(big.Float).SetPrec(100).Quo(big.NewFloat(1), big.NewFloat(3))
+	for _, test := range []struct {
+		z    *big.Float
+		prec uint
+		mode big.RoundingMode
+		acc  big.Accuracy
+	}{
+		// exactly representable
+		{big.NewFloat(1.5), 2, big.ToNearestEven, big.Exact},
+		{big.NewFloat(1.5), 24, big.ToZero, big.Exact},
+		{big.NewFloat(-1.5), 1000, big.AwayFromZero, big.Exact},
+		{big.NewFloat(0), 1, big.ToPositiveInf, big.Exact},
+		{big.NewFloat(math.Inf(-1)), 24, big.ToNearestEven, big.Exact},
+
+		// rounded
+		{big.NewFloat(1.75), 2, big.ToNearestEven, big.Above},
+		{big.NewFloat(1.25), 2, big.ToNearestEven, big.Below},
+		{third, 24, big.ToNearestEven, big.Above},
+		{third, 24, big.ToNegativeInf, big.Below},
+		{third, 24, big.ToPositiveInf, big.Above},
+		{third, 24, big.AwayFromZero, big.Above},
+		{new(big.Float).Neg(third), 24, big.AwayFromZero, big.Below},
+		{new(big.Float).Neg(third), 24, big.ToZero, big.Above},
+		{third, 0, big.ToNearestEven, big.Below},
+	} {
+		zPrec, zMode, zAcc := test.z.Prec(), test.z.Mode(), test.z.Acc()
+		zVal := new(big.Float).Copy(test.z)
+
+		x, acc := bigfloat.RoundTo(test.z, test.prec, test.mode)
+		if acc != test.acc || x.Acc() != test.acc {
+			t.Errorf("RoundTo(%g, %d, %s) has accuracy %s; want %s", test.z, test.prec, test.mode, acc, test.acc)
+		}
+		if x.Prec() != test.prec || x.Mode() != test.mode {
+			t.Errorf("RoundTo(%g, %d, %s) has prec = %d and mode %s", test.z, test.prec, test.mode, x.Prec(), x.Mode())
+		}
+
+		// the result must be z rounded as big.Float does it, and it
+		// must be in the direction reported
+		want := new(big.Float).SetPrec(test.prec).SetMode(test.mode)
+		if test.prec > 0 {
+			want.Set(test.z)
+		}
+		if x.Cmp(want) != 0 || big.Accuracy(x.Cmp(test.z)) != acc {
+			t.Errorf("RoundTo(%g, %d, %s) = %g", test.z, test.prec, test.mode, x)
+		}
+
+		if test.z.Prec() != zPrec || test.z.Mode() != zMode || test.z.Acc() != zAcc || test.z.Cmp(zVal) != 0 {
+			t.Errorf("RoundTo(%g, %d, %s) modified its argument", test.z, test.prec, test.mode)
+		}
+	}
+}
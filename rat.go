@@ -0,0 +1,34 @@
+package bigfloat
+
+import "math/big"
+
+// FromRat returns r rounded to prec bits, to nearest even. A big.Rat
+// can hold values, like 1/3, that have no finite binary
+// representation, and those are rounded; the conversion is exact
+// (and the result's accuracy is big.Exact) only when the denominator
+// is a power of two and the numerator fits in prec bits. If prec is 0,
+// it's set to the larger of the bit lengths of the numerator and
+// denominator, and at least 64, as in big.Float.SetRat.
+func FromRat(r *big.Rat, prec uint) *big.Float {
+	return new(big.Float).SetPrec(prec).SetRat(r)
+}
+
+// SqrtRat returns a big.Float representation of the square root of
+// r, rounded to prec bits. r is converted with 64 guard digits before
+// taking the root, so the result is correctly rounded unless √r is
+// extremely close to the midpoint between two floats. If prec is 0,
+// it's chosen as in FromRat.
+// The function panics if r is negative, and returns +0 when r = 0.
+func SqrtRat(r *big.Rat, prec uint) *big.Float {
+
+	// panic on negative r
+	if r.Sign() < 0 {
+		panic("SqrtRat: argument is negative")
+	}
+
+	if prec == 0 {
+		prec = FromRat(r, 0).Prec()
+	}
+
+	return SqrtPrec(FromRat(r, prec+64), prec, big.ToNearestEven)
+}
@@ -0,0 +1,64 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestFromRat(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		for _, r := range []*big.Rat{
+			big.NewRat(1, 3),
+			big.NewRat(-2, 7),
+			big.NewRat(10, 1),
+			big.NewRat(1, 1<<40),
+			new(big.Rat).SetFrac(new(big.Int).Lsh(big.NewInt(1), 2000), big.NewInt(3)),
+		} {
+			// Quo of two exact integers is correctly rounded
+			want := new(big.Float).SetPrec(prec).Quo(
+				new(big.Float).SetInt(r.Num()),
+				new(big.Float).SetInt(r.Denom()))
+			if x := bigfloat.FromRat(r, prec); x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, FromRat(%s) =\ngot  %g;\nwant %g", prec, r, x, want)
+			}
+		}
+	}
+
+	if _, acc := bigfloat.FromRat(big.NewRat(3, 4), 24).Float64(); acc != big.Exact {
+		t.Errorf("FromRat(3/4) is not exact")
+	}
+	if x := bigfloat.FromRat(big.NewRat(1, 3), 0); x.Prec() != 64 {
+		t.Errorf("FromRat(1/3, 0) has precision %d; want 64", x.Prec())
+	}
+}
+
+func TestSqrtRat(t *testing.T) {
+	for _, test := range []struct {
+		r    *big.Rat
+		want string
+	}{
+		{big.NewRat(9, 4), "1.5"},
+		{big.NewRat(0, 1), "0"},
+		{big.NewRat(1, 3), "0.57735026918962576450914878050195745564760175127012687601860232648397767230293334569371539558574952522520871380513556767665664836499965082627055183736479121617603107730076852735599160670036155830775500510411442230110762888355741822297394599040901571055345595386267301666217912661979648921678250219201691887278270986870031586739573010836104860984131994"},
+		{big.NewRat(2, 7), "0.53452248382484876936910696175950704310800282968267527804339220961714787947241986113954427074205422450014158410396456822078665219545203465609429172179311930248677158921069409027301428789005751828558055071733414714824758413887549921997292859563396561671856492474215149998949121352553140621456925547758947289393994227104020706052002693004314784701158962"},
+		{new(big.Rat).SetFrac(new(big.Int).Exp(big.NewInt(10), big.NewInt(40), nil), big.NewInt(3)), "57735026918962576450.914878050195745564760175127012687601860232648397767230293334569371539558574952522520871380513556767665664836499965082627055183736479121617603107730076852735599160670036155830775500510411442230110762888355741822297394599040901571055345595386267301666217912661979648921678250219201691887278270986870031586739573010836104860984131994"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			if x := bigfloat.SqrtRat(test.r, prec); x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, SqrtRat(%s) =\ngot  %g;\nwant %g", prec, test.r, x, want)
+			}
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("SqrtRat(-1/2) did not panic")
+		}
+	}()
+	bigfloat.SqrtRat(big.NewRat(-1, 2), 53)
+}
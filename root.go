@@ -0,0 +1,77 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// Root returns a big.Float representation of the n-th root of
+// z. Precision is the same as the one of the argument. The function
+// panics if n <= 0, or if z is negative and n is even. It returns ±0
+// when z = ±0, and ±Inf when z = ±Inf.
+func Root(z *big.Float, n int) *big.Float {
+
+	if n <= 0 {
+		panic("Root: n is not positive")
+	}
+
+	// panic on negative z when n is even
+	if z.Sign() == -1 && n%2 == 0 {
+		panic("Root: argument is negative and n is even")
+	}
+
+	switch n {
+	case 1:
+		return new(big.Float).Copy(z)
+	case 2:
+		return Sqrt(z)
+	case 3:
+		return Cbrt(z)
+	}
+
+	// ⁿ√±0 = ±0
+	// ⁿ√±Inf = ±Inf
+	if z.Sign() == 0 || z.IsInf() {
+		return new(big.Float).Copy(z)
+	}
+
+	// ⁿ√(-z) = -ⁿ√z (n is odd here)
+	neg := z.Sign() < 0
+
+	// Compute ⁿ√(a·2**b) as ⁿ√(a·2**r)·2**((b-r)/n), where r is the
+	// non-negative remainder of b modulo n.
+	mant := new(big.Float)
+	exp := z.MantExp(mant)
+	mant.Abs(mant)
+	r := exp % n
+	if r < 0 {
+		r += n
+	}
+
+	// initial guess, computed before re-attaching 2**r because
+	// a·2**r may not fit in a float64 when n is large.
+	mf, _ := mant.Float64()
+	guess := big.NewFloat(math.Pow(mf, 1/float64(n)) * math.Exp2(float64(r)/float64(n)))
+
+	mant.SetMantExp(mant, r)
+	exp -= r
+
+	// f(t)/f'(t) = (tⁿ - z)/ntⁿ⁻¹
+	nf := big.NewFloat(float64(n))
+	f := func(t *big.Float) *big.Float {
+		u := powInt(t, uint64(n-1))   // u = tⁿ⁻¹
+		x := new(big.Float).Mul(u, t) // x = tⁿ
+		x.Sub(x, mant)                // x = tⁿ - z
+		u.Mul(nf, u)                  // u = ntⁿ⁻¹
+		return x.Quo(x, u)            // return x = (tⁿ - z)/ntⁿ⁻¹
+	}
+
+	x := newton(f, guess, z.Prec())
+
+	// re-attach the exponent and sign and return
+	x.SetMantExp(x, exp/n)
+	if neg {
+		x.Neg(x)
+	}
+	return x
+}
@@ -0,0 +1,143 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+// See note in sqrt_test.go about which numbers
+// can we safely test this way.
+
+func TestRoot(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		n    int
+		want string
+	}{
+		// 350 decimal digits are enough to give us up to 1000 binary digits
+		{"2", 5, "1.1486983549970350067986269467779275894438508890977975055137111184936032062535130568114731130115084739145757178282528087299001897285537126761599491702063767695940385453926322649203330132212219062513064546832007838635028580690794908512770828398279704396964038256366794534443110652378965414725597257831570410332630205027201741423525599315155378237517388"},
+		{"10", 7, "1.3894954943731376371299852173530116221130467144910002049456286790316002424103165813841756389754214323881923266149080532501908980741273813959901199701429753073470907628244009525403778763687048007997790131441153378403318972990666766882035047498681299843202537000382006433077748111955004814293230847398026226983610426319290203606899041246575090641761121"},
+		{"0.375", 4, "0.78254229003664365829224274957934490490536347138739461140990454600541172052644100437101763595522945469309419417471830793606037479782035385957333864088049025869319306209739772337114815269397266803323039166610774167574299826715852616257963140664413978536826889785588334888394033435255591611522941673620627729016366510623235861471258260920826300838393505"},
+		{"-3", 5, "-1.2457309396155173259666803366403050809393099930687798110461730143607466537754935666058951445881234256590280757992509749233994748093805395708835499550212454890661318980512486312502748245524127398086674056997993204689779437864981369731319471703715071774295142529583580792144534141134617965944344086077550152396112677058650189858703981047971293989601945"},
+		{"1000", 10, "1.9952623149688796013524553967395355579862743154053460992299136670049309106980489644753800797975347960810859246301126364444851466211005975564128896768475238551986353912676562511781213227879722622397873300633484414884355383901866915118001152184845428696208652600189249471817588473864019482443866231548036382538816573852037962379404347301066581249767470"},
+		{"1.5", 100, "1.0040628822999231097921678262939853106034341255439779432223661978553521652191435966995640797203262020846946205297133674441706454868789590260117901683970406679715708581927306089580919527808795738877345802502711687454056880305027136595504465451428852819577950168785827626210832273427392146339698178800733191628592815712393356485288941747887669536877234"},
+
+		{"32", 5, "2"},
+		{"-32", 5, "-2"},
+		{"1p1000", 10, "1p100"},
+		{"1p-1000", 10, "1p-100"},
+		{"1p1001", 7, "1p143"},
+		{"-1p-1001", 7, "-1p-143"},
+		{"0.0625", 4, "0.5"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Root(z, test.n)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Root(%v, %d) =\ngot  %g;\nwant %g", prec, test.z, test.n, x, want)
+			}
+		}
+	}
+}
+
+func TestRootSmallN(t *testing.T) {
+	for _, z := range []string{"2", "0.3", "1e100"} {
+		for _, prec := range []uint{24, 53, 100, 200, 1000} {
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(z, 10)
+
+			if r := bigfloat.Root(x, 1); r.Cmp(x) != 0 {
+				t.Errorf("prec = %d, Root(%v, 1) = %g; want %g", prec, z, r, x)
+			}
+			if r, s := bigfloat.Root(x, 2), bigfloat.Sqrt(x); r.Cmp(s) != 0 {
+				t.Errorf("prec = %d, Root(%v, 2) = %g; want %g", prec, z, r, s)
+			}
+		}
+	}
+}
+
+func TestRootPow(t *testing.T) {
+	for _, z := range []string{"2", "0.3", "1e100"} {
+		for _, n := range []int{4, 5, 6, 17} {
+			for _, prec := range []uint{100, 200, 500, 1000} {
+				x := new(big.Float).SetPrec(prec)
+				x.Parse(z, 10)
+
+				w := new(big.Float).SetPrec(prec + 64).SetInt64(1)
+				w.Quo(w, big.NewFloat(float64(n)))
+
+				root, pow := bigfloat.Root(x, n), bigfloat.Pow(x, w)
+
+				// Pow(x, 1/n) gets 1/n rounded, so just require
+				// the results to agree up to the last bit.
+				diff := new(big.Float).Sub(root, pow)
+				if diff.Sign() != 0 && diff.MantExp(nil) > root.MantExp(nil)-int(prec)+1 {
+					t.Errorf("prec = %d, Root(%v, %d) =\ngot  %g;\nwant %g", prec, z, n, root, pow)
+				}
+			}
+		}
+	}
+}
+
+func TestRootSpecialValues(t *testing.T) {
+	for _, test := range []struct {
+		f float64
+		n int
+	}{
+		{+0.0, 4},
+		{-0.0, 5},
+		{math.Inf(+1), 4},
+		{math.Inf(-1), 5},
+	} {
+		z := big.NewFloat(test.f)
+		x := bigfloat.Root(z, test.n)
+		x64, acc := x.Float64()
+		if x64 != test.f || x.Signbit() != math.Signbit(test.f) || acc != big.Exact {
+			t.Errorf("Root(%g, %d) =\n got %g (%s);\nwant %g (Exact)", z, test.n, x64, acc, test.f)
+		}
+	}
+}
+
+func TestRootPanics(t *testing.T) {
+	for _, test := range []struct {
+		f float64
+		n int
+	}{
+		{2, 0},
+		{2, -3},
+		{-2, 4},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Root(%g, %d) did not panic", test.f, test.n)
+				}
+			}()
+			bigfloat.Root(big.NewFloat(test.f), test.n)
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkRoot(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		z := big.NewFloat(2).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Root(z, 5)
+			}
+		})
+	}
+}
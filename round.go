@@ -0,0 +1,214 @@
+package bigfloat
+
+import "math/big"
+
+// Trunc returns a big.Float holding the integer value of z rounded
+// toward zero. Precision is the same as the one of the argument. The
+// function returns ±0 when -1 < z < 1, and ±Inf when z = ±Inf.
+func Trunc(z *big.Float) *big.Float {
+
+	// Trunc(±Inf) = ±Inf, and integers are unchanged
+	if z.IsInf() || z.IsInt() {
+		return new(big.Float).Copy(z)
+	}
+
+	// z is not an integer, so its exponent is smaller than its
+	// precision and the integer part fits in z.Prec() bits
+	i, _ := z.Int(nil)
+	x := new(big.Float).SetPrec(z.Prec()).SetInt(i)
+
+	// keep the sign of z when the result is 0
+	if x.Sign() == 0 && z.Signbit() {
+		x.Neg(x)
+	}
+
+	return x
+}
+
+// Frac returns a big.Float holding the fractional part of z, z -
+// Trunc(z), which has the same sign as z. Precision is the same as
+// the one of the argument, and the result is exact. The function
+// returns ±0 when z is an integer, with the sign of z, and panics if z
+// = ±Inf.
+func Frac(z *big.Float) *big.Float {
+
+	if z.IsInf() {
+		panic("Frac: argument is infinite")
+	}
+
+	// z - Trunc(z) just drops the integer bits of z, so it's exact
+	x := new(big.Float).SetPrec(z.Prec()).Sub(z, Trunc(z))
+
+	// x - x is +0, keep the sign of z
+	if x.Sign() == 0 && z.Signbit() {
+		x.Neg(x)
+	}
+
+	return x
+}
+
+// Floor returns a big.Float holding the greatest integer value less
+// than or equal to z. Precision is the same as the one of the
+// argument. The function returns ±0 when z = ±0, and ±Inf when z =
+// ±Inf.
+func Floor(z *big.Float) *big.Float {
+	x := Trunc(z)
+	if z.Sign() < 0 && x.Cmp(z) != 0 {
+		x.Sub(x, big.NewFloat(1))
+	}
+	return x
+}
+
+// Ceil returns a big.Float holding the least integer value greater
+// than or equal to z. Precision is the same as the one of the
+// argument. The function returns -0 when -1 < z < 0, ±0 when z = ±0,
+// and ±Inf when z = ±Inf.
+func Ceil(z *big.Float) *big.Float {
+	x := Trunc(z)
+	if z.Sign() > 0 && x.Cmp(z) != 0 {
+		x.Add(x, big.NewFloat(1))
+	}
+	return x
+}
+
+// Round returns a big.Float holding the nearest integer to z,
+// rounding half away from zero. Precision is the same as the one of
+// the argument. The function returns ±0 when |z| < 0.5, and ±Inf
+// when z = ±Inf.
+func Round(z *big.Float) *big.Float {
+	x := Trunc(z)
+	if x.IsInf() {
+		return x
+	}
+
+	// z - x is exact, since it just drops the integer bits of z
+	frac := new(big.Float).SetPrec(z.Prec()).Sub(z, x)
+	if frac.Abs(frac).Cmp(big.NewFloat(0.5)) >= 0 {
+		if z.Sign() > 0 {
+			x.Add(x, big.NewFloat(1))
+		} else {
+			x.Sub(x, big.NewFloat(1))
+		}
+	}
+
+	return x
+}
+
+// RoundDecimal returns a big.Float holding z rounded to places
+// decimal digits after the decimal point, rounding half away from
+// zero; negative values of places round to tens, hundreds and so on.
+// Precision is the same as the one of the argument. The scaling by
+// 10**places and the rounding are done exactly, so the only error is
+// the final rounding of the decimal result to binary. The function
+// returns ±0 when the result rounds to 0, and ±Inf when z = ±Inf.
+func RoundDecimal(z *big.Float, places int) *big.Float {
+
+	// RoundDecimal(±0) = ±0, RoundDecimal(±Inf) = ±Inf
+	if z.IsInf() || z.Sign() == 0 {
+		return new(big.Float).Copy(z)
+	}
+
+	// z has at most z.Prec() - exp binary digits after the point, and
+	// as many decimal ones, so if places is at least that it's
+	// unchanged
+	if exp := z.MantExp(nil); places >= 0 && places >= int(z.Prec())-exp {
+		return new(big.Float).Copy(z)
+	}
+
+	i, scale := roundScaled(z, places)
+	r := new(big.Rat)
+	if places >= 0 {
+		r.SetFrac(i, scale)
+	} else {
+		r.SetInt(i.Mul(i, scale))
+	}
+
+	x := new(big.Float).SetPrec(z.Prec()).SetRat(r)
+	if z.Signbit() {
+		x.Neg(x)
+	}
+
+	return x
+}
+
+// roundScaled returns i = |z|·10**places rounded to the nearest
+// integer, half away from zero, and scale = 10**|places|. z must be
+// finite.
+func roundScaled(z *big.Float, places int) (i, scale *big.Int) {
+	n := places
+	if n < 0 {
+		n = -n
+	}
+	scale = new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+
+	// r = |z|·10**places, exactly
+	r, _ := z.Rat(nil)
+	r.Abs(r)
+	if places >= 0 {
+		r.Mul(r, new(big.Rat).SetInt(scale))
+	} else {
+		r.Quo(r, new(big.Rat).SetInt(scale))
+	}
+
+	// i = ⌊(2·num + den) / 2·den⌋
+	den := new(big.Int).Lsh(r.Denom(), 1)
+	i = new(big.Int).Lsh(r.Num(), 1)
+	i.Add(i, r.Denom())
+	i.Quo(i, den)
+
+	return i, scale
+}
+
+// Mod returns a big.Float representation of the floating-point
+// remainder of x/y, that is x - n·y where n is x/y rounded toward
+// zero, rounded to x's precision. As in math.Mod, the result has the
+// sign of x and its magnitude is less than |y|. The function panics
+// if y = 0 or x = ±Inf, and returns x when y = ±Inf.
+//
+// The remainder is computed exactly before the final rounding, so
+// reducing a large x modulo a high-precision constant (like 2π)
+// doesn't lose any of the low bits of x.
+func Mod(x, y *big.Float) *big.Float {
+
+	if y.Sign() == 0 {
+		panic("Mod: division by zero")
+	}
+	if x.IsInf() {
+		panic("Mod: x is infinite")
+	}
+
+	// Mod(±0, y) = ±0, Mod(x, ±Inf) = x
+	if x.Sign() == 0 || y.IsInf() {
+		return new(big.Float).Copy(x)
+	}
+
+	// |x| < |y|, nothing to do
+	ax, ay := new(big.Float).Abs(x), new(big.Float).Abs(y)
+	if ax.Cmp(ay) < 0 {
+		return new(big.Float).Copy(x)
+	}
+
+	// n = trunc(|x|/|y|) has about exp(x) - exp(y) bits. Compute it with
+	// some guard digits; it may still be off by one when |x|/|y| is
+	// very close to an integer, and that is fixed below.
+	bits := x.MantExp(nil) - y.MantExp(nil) + 1
+	q := new(big.Float).SetPrec(uint(bits)+64).Quo(ax, ay)
+	n, _ := q.Int(nil)
+
+	// With this precision r = |x| - n·|y| and the corrections are exact.
+	prec := x.Prec() + y.Prec() + uint(n.BitLen()) + 2
+	r := new(big.Float).SetPrec(prec).SetInt(n)
+	r.Mul(r, ay)
+	r.Sub(ax, r)
+	if r.Sign() < 0 {
+		r.Add(r, ay)
+	} else if r.Cmp(ay) >= 0 {
+		r.Sub(r, ay)
+	}
+
+	if x.Sign() < 0 {
+		r.Neg(r)
+	}
+
+	return r.SetPrec(x.Prec())
+}
@@ -0,0 +1,236 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestRoundingFloat64(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		f    func(*big.Float) *big.Float
+		g    func(float64) float64
+	}{
+		{"Trunc", bigfloat.Trunc, math.Trunc},
+		{"Floor", bigfloat.Floor, math.Floor},
+		{"Ceil", bigfloat.Ceil, math.Ceil},
+		{"Round", bigfloat.Round, math.Round},
+	} {
+		for _, f := range []float64{
+			0, math.Copysign(0, -1), 0.25, -0.25, 0.5, -0.5, 0.75, -0.75,
+			1, -1, 2.5, -2.5, 3.5, -3.5, 1e15 + 0.5, -1e15 - 0.5,
+			1 << 60, math.Inf(+1), math.Inf(-1),
+		} {
+			z := big.NewFloat(f).SetPrec(53)
+			x := test.f(z)
+			x64, acc := x.Float64()
+			want := test.g(f)
+			if x64 != want || math.Signbit(x64) != math.Signbit(want) || acc != big.Exact || x.Prec() != 53 {
+				t.Errorf("%s(%g) =\n got %g (%s, prec = %d);\nwant %g (Exact, prec = 53)", test.name, f, x64, acc, x.Prec(), want)
+			}
+		}
+	}
+}
+
+func TestRoundingHighPrecision(t *testing.T) {
+	// z = ±(2**200 + 0.5), which needs 202 bits
+	for _, test := range []struct {
+		name        string
+		f           func(*big.Float) *big.Float
+		plus, minus int // result - 2**200 for +z and -z, in units of 1
+	}{
+		{"Trunc", bigfloat.Trunc, 0, 0},
+		{"Floor", bigfloat.Floor, 0, 1},
+		{"Ceil", bigfloat.Ceil, 1, 0},
+		{"Round", bigfloat.Round, 1, 1},
+	} {
+		p := new(big.Float).SetPrec(300).SetMantExp(big.NewFloat(1), 200)
+		z := new(big.Float).SetPrec(300).Add(p, big.NewFloat(0.5))
+
+		want := new(big.Float).SetPrec(300).Add(p, big.NewFloat(float64(test.plus)))
+		if x := test.f(z); x.Cmp(want) != 0 || x.Prec() != 300 {
+			t.Errorf("%s(2**200 + 0.5) =\ngot  %g;\nwant %g", test.name, x, want)
+		}
+
+		z.Neg(z)
+		want.Add(p, big.NewFloat(float64(test.minus)))
+		want.Neg(want)
+		if x := test.f(z); x.Cmp(want) != 0 || x.Prec() != 300 {
+			t.Errorf("%s(-2**200 - 0.5) =\ngot  %g;\nwant %g", test.name, x, want)
+		}
+	}
+}
+
+func TestFrac(t *testing.T) {
+	for _, f := range []float64{
+		0, math.Copysign(0, -1), 0.25, -0.25, 3.75, -3.75, 3, -3,
+		1e15 + 0.5, -1e15 - 0.5, 1 << 60, -1 << 60,
+	} {
+		z := big.NewFloat(f).SetPrec(53)
+		x := bigfloat.Frac(z)
+		x64, acc := x.Float64()
+		_, want := math.Modf(f)
+		if x64 != want || math.Signbit(x64) != math.Signbit(want) || acc != big.Exact || x.Prec() != 53 {
+			t.Errorf("Frac(%g) =\n got %g (%s, prec = %d);\nwant %g (Exact, prec = 53)", f, x64, acc, x.Prec(), want)
+		}
+	}
+
+	// the fractional part of ±(2**200 + 0.5 + 2**-100) keeps all the
+	// low bits of z
+	p := new(big.Float).SetPrec(400).SetMantExp(big.NewFloat(1), 200)
+	z := new(big.Float).SetPrec(400).Add(p, big.NewFloat(0.5))
+	z.Add(z, big.NewFloat(0x1p-100))
+	want := new(big.Float).SetPrec(400).Add(big.NewFloat(0.5), big.NewFloat(0x1p-100))
+	if x := bigfloat.Frac(z); x.Cmp(want) != 0 || x.Prec() != 400 {
+		t.Errorf("Frac(2**200 + 0.5 + 2**-100) =\ngot  %g;\nwant %g", x, want)
+	}
+	if x := bigfloat.Frac(z.Neg(z)); x.Cmp(want.Neg(want)) != 0 || x.Prec() != 400 {
+		t.Errorf("Frac(-2**200 - 0.5 - 2**-100) =\ngot  %g;\nwant %g", x, want)
+	}
+
+	for _, f := range []float64{math.Inf(+1), math.Inf(-1)} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Frac(%g) did not panic", f)
+				}
+			}()
+			bigfloat.Frac(big.NewFloat(f))
+		}()
+	}
+}
+
+func TestRoundDecimal(t *testing.T) {
+	for _, test := range []struct {
+		z      string
+		places int
+		want   string
+	}{
+		{"3.14159", 2, "3.14"},
+		{"3.14159", 4, "3.1416"},
+		{"3.14159", 0, "3"},
+		{"-3.14159", 3, "-3.142"},
+		{"12345", -2, "12300"},
+		{"12350", -2, "12400"},
+		{"-12350", -2, "-12400"},
+		{"12345", -5, "0"},
+		{"62345", -5, "100000"},
+		{"0.125", 2, "0.13"}, // exact tie, away from zero
+		{"-0.125", 2, "-0.13"},
+		{"2.675", 2, "2.67"}, // the binary 2.675 is below the tie
+		{"2.5", 0, "3"},
+		{"1e-30", 10, "0"},
+		{"1e30", 2, "1e30"},
+		{"0.1", 100, "0.1"}, // already rounded
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			x := bigfloat.RoundDecimal(z, test.places)
+			if x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, RoundDecimal(%s, %d) =\ngot  %g;\nwant %g", prec, test.z, test.places, x, want)
+			}
+		}
+	}
+}
+
+func TestRoundDecimalSpecialValues(t *testing.T) {
+	for _, test := range []struct {
+		z      float64
+		places int
+		want   float64
+	}{
+		{+0.0, 2, +0.0},
+		{math.Copysign(0, -1), 2, math.Copysign(0, -1)},
+		{-0.001, 2, math.Copysign(0, -1)},
+		{-4, -1, math.Copysign(0, -1)},
+		{math.Inf(+1), 2, math.Inf(+1)},
+		{math.Inf(-1), -2, math.Inf(-1)},
+	} {
+		x := bigfloat.RoundDecimal(big.NewFloat(test.z), test.places)
+		if x64, _ := x.Float64(); x64 != test.want || math.Signbit(x64) != math.Signbit(test.want) {
+			t.Errorf("RoundDecimal(%g, %d) = %g; want %g", test.z, test.places, x64, test.want)
+		}
+	}
+}
+
+func TestModFloat64(t *testing.T) {
+	for i := 0; i < 1e4; i++ {
+		x := (rand.Float64() - 0.5) * math.Pow(10, float64(rand.Intn(40)-10))
+		y := (rand.Float64() - 0.5) * math.Pow(10, float64(rand.Intn(20)-10))
+
+		// math.Mod is exact, so the results must match
+		z64, acc := bigfloat.Mod(big.NewFloat(x), big.NewFloat(y)).Float64()
+		want := math.Mod(x, y)
+		if z64 != want || math.Signbit(z64) != math.Signbit(want) || acc != big.Exact {
+			t.Errorf("Mod(%g, %g) =\n got %g (%s);\nwant %g (Exact)", x, y, z64, acc, want)
+		}
+	}
+}
+
+func TestModPi(t *testing.T) {
+	for _, prec := range []uint{53, 100, 200, 500, 1000} {
+		twoPi := bigfloat.Pi(prec)
+		twoPi.Mul(twoPi, big.NewFloat(2))
+
+		for _, k := range []int64{1, 3, 1000, -12345, 1e15} {
+			// x = k·2π + 0.125, rounded to prec bits
+			x := new(big.Float).SetPrec(prec).SetInt64(k)
+			x.Mul(x, twoPi)
+			if k > 0 {
+				x.Add(x, big.NewFloat(0.125))
+			} else {
+				x.Sub(x, big.NewFloat(0.125))
+			}
+
+			// direct computation of x - k·2π, exact at 4·prec bits.
+			// When prec is small the rounding of x can move it past
+			// k·2π, so fix the sign of the difference.
+			want := new(big.Float).SetPrec(4 * prec).SetInt64(k)
+			want.Mul(want, twoPi)
+			want.Sub(x, want)
+			if want.Sign() != 0 && want.Sign() != x.Sign() {
+				if k > 0 {
+					want.Add(want, twoPi)
+				} else {
+					want.Sub(want, twoPi)
+				}
+			}
+			want.SetPrec(prec)
+
+			if r := bigfloat.Mod(x, twoPi); r.Cmp(want) != 0 || r.Prec() != prec {
+				t.Errorf("prec = %d, Mod(%d·2π + 0.125, 2π) =\ngot  %g;\nwant %g", prec, k, r, want)
+			}
+		}
+	}
+}
+
+func TestModSpecialValues(t *testing.T) {
+	for _, test := range [][2]float64{
+		{0, 3}, {math.Copysign(0, -1), 3}, {2.5, math.Inf(+1)}, {-2.5, math.Inf(-1)}, {6, 3}, {-6, 3},
+	} {
+		z64, acc := bigfloat.Mod(big.NewFloat(test[0]), big.NewFloat(test[1])).Float64()
+		want := math.Mod(test[0], test[1])
+		if z64 != want || math.Signbit(z64) != math.Signbit(want) || acc != big.Exact {
+			t.Errorf("Mod(%g, %g) =\n got %g (%s);\nwant %g (Exact)", test[0], test[1], z64, acc, want)
+		}
+	}
+
+	for _, test := range [][2]float64{{1, 0}, {math.Inf(+1), 2}} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Mod(%g, %g) did not panic", test[0], test[1])
+				}
+			}()
+			bigfloat.Mod(big.NewFloat(test[0]), big.NewFloat(test[1]))
+		}()
+	}
+}
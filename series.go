@@ -0,0 +1,164 @@
+package bigfloat
+
+import "math/big"
+
+// ContinuedFraction returns the value of the continued fraction
+//
+//	b(0) + a(1)/(b(1) + a(2)/(b(2) + a(3)/(b(3) + ...)))
+//
+// with prec bits of precision, evaluated with the modified Lentz
+// algorithm. The fraction ends when a term a(n) is zero. a and b are
+// called in order of increasing n, and they can return values of any
+// precision; the computation is done with 64 guard digits.
+//
+// The evaluation stops when the convergents agree to the requested
+// precision. If they don't after 100·prec iterations, the fraction is
+// assumed not to converge, and the last convergent is returned. The
+// function panics if prec is 0.
+func ContinuedFraction(a, b func(n int) *big.Float, prec uint) *big.Float {
+
+	if prec == 0 {
+		panic("ContinuedFraction: prec is 0")
+	}
+
+	p := prec + 64 // guard digits
+
+	// Lentz replaces zero denominators with a value smaller than
+	// anything the fraction can produce at this precision
+	tiny := big.NewFloat(1).SetPrec(p)
+	tiny.SetMantExp(tiny, -4*int(p))
+
+	one := big.NewFloat(1)
+	f := new(big.Float).SetPrec(p).Set(b(0))
+	if f.Sign() == 0 {
+		f.Set(tiny)
+	}
+	c := new(big.Float).SetPrec(p).Set(f)
+	d := new(big.Float).SetPrec(p)
+	t := new(big.Float).SetPrec(p)
+
+	maxIter := 100 * int(prec)
+	for n := 1; n <= maxIter; n++ {
+		an, bn := a(n), b(n)
+		if an.Sign() == 0 {
+			break
+		}
+
+		// d = 1/(b(n) + a(n)·d)
+		d.Add(bn, t.Mul(an, d))
+		if d.Sign() == 0 {
+			d.Set(tiny)
+		}
+		d.Quo(one, d)
+
+		// c = b(n) + a(n)/c
+		c.Add(bn, t.Quo(an, c))
+		if c.Sign() == 0 {
+			c.Set(tiny)
+		}
+
+		// f = f·c·d, and stop when c·d is 1 to the precision; c·d is
+		// off by a few ulps, so leave half of the guard digits to the
+		// rounding errors
+		t.Mul(c, d)
+		f.Mul(f, t)
+		if t.Sub(t, one); t.Sign() == 0 || t.MantExp(nil) < -int(prec)-32 {
+			break
+		}
+	}
+
+	return f.SetPrec(prec)
+}
+
+// SumSeries returns the sum of the series term(0) + term(1) + ...,
+// with prec bits of precision. term is called in order of increasing
+// n, and it can return values of any precision. The terms are added
+// with 64 guard digits and Kahan compensation, so the rounding errors
+// of the single additions don't accumulate.
+//
+// The summation stops at the first term that is zero or smaller than
+// an ulp of the partial sum at the working precision, so the terms
+// must eventually decrease in magnitude, as in the Taylor series of
+// the package. If the summation doesn't stop after 100·prec terms,
+// the series is assumed to converge too slowly, and the partial sum is
+// returned. The function panics if prec is 0.
+func SumSeries(term func(n int) *big.Float, prec uint) *big.Float {
+
+	if prec == 0 {
+		panic("SumSeries: prec is 0")
+	}
+
+	p := prec + 64 // guard digits
+
+	sum := new(big.Float).SetPrec(p)
+	comp := new(big.Float).SetPrec(p) // the low-order bits lost by sum
+	y := new(big.Float).SetPrec(p)
+	t := new(big.Float).SetPrec(p)
+
+	maxIter := 100 * int(prec)
+	for n := 0; n < maxIter; n++ {
+		x := term(n)
+		if x.Sign() == 0 || (sum.Sign() != 0 && x.MantExp(nil) < sum.MantExp(nil)-int(p)) {
+			break
+		}
+
+		// y = x - comp, t = sum + y, comp = (t - sum) - y
+		y.Sub(x, comp)
+		t.Add(sum, y)
+		comp.Sub(t, sum)
+		comp.Sub(comp, y)
+		sum, t = t, sum
+	}
+
+	return sum.SetPrec(prec)
+}
+
+// GeomSeriesSum returns a big.Float representation of the sum of the
+// first n terms of the geometric series with first term a and ratio
+// r,
+//
+//	a + a·r + a·r² + ... + a·rⁿ⁻¹ = a·(1 - rⁿ)/(1 - r)
+//
+// and a·n for r = 1. Precision is the larger of the precisions of a
+// and r. When r is close to 1, 1 - rⁿ and 1 - r cancel, so rⁿ is
+// computed with as many more guard digits as the leading zeros of
+// 1 - r. The function panics if n is negative or if a or r is
+// infinite, and returns 0 if n is 0.
+func GeomSeriesSum(a, r *big.Float, n int) *big.Float {
+
+	if n < 0 {
+		panic("GeomSeriesSum: n is negative")
+	}
+	if a.IsInf() || r.IsInf() {
+		panic("GeomSeriesSum: argument is infinite")
+	}
+
+	prec := a.Prec()
+	if r.Prec() > prec {
+		prec = r.Prec()
+	}
+	if n == 0 {
+		return new(big.Float).SetPrec(prec)
+	}
+
+	// GeomSeriesSum(a, 1, n) = a·n
+	one := big.NewFloat(1)
+	if r.Cmp(one) == 0 {
+		x := new(big.Float).SetPrec(prec + 64)
+		return x.Mul(a, x.SetInt64(int64(n))).SetPrec(prec)
+	}
+
+	p := prec + 64 // guard digits
+
+	// 1 - r is exact, and it loses the bits of r that are the same as
+	// the ones of 1
+	d := new(big.Float).SetPrec(p).Sub(one, r)
+	if e := d.MantExp(nil); e < 0 {
+		p += uint(-e)
+	}
+
+	x := PowInt(new(big.Float).SetPrec(p).Set(r), n)
+	x.Sub(one, x)
+	x.Quo(x, d)
+	return x.Mul(x, a).SetPrec(prec)
+}
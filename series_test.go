@@ -0,0 +1,217 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestContinuedFraction(t *testing.T) {
+	one := func(n int) *big.Float { return big.NewFloat(1) }
+
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		phi := new(big.Float).SetPrec(prec + 64).SetInt64(5)
+		phi = bigfloat.Sqrt(phi)
+		phi.Add(phi, big.NewFloat(1))
+		phi.Quo(phi, big.NewFloat(2))
+
+		for _, test := range []struct {
+			name string
+			a, b func(n int) *big.Float
+			want *big.Float
+		}{
+			// φ = 1 + 1/(1 + 1/(1 + ...))
+			{"φ", one, one, new(big.Float).SetPrec(prec).Set(phi)},
+
+			// φ - 1 = 0 + 1/(1 + 1/(1 + ...)), with b(0) = 0
+			{
+				"φ-1", one,
+				func(n int) *big.Float {
+					if n == 0 {
+						return new(big.Float)
+					}
+					return big.NewFloat(1)
+				},
+				new(big.Float).SetPrec(prec).Sub(phi, big.NewFloat(1)),
+			},
+
+			// e = [2; 1, 2, 1, 1, 4, 1, 1, 6, ...]
+			{
+				"e", one,
+				func(n int) *big.Float {
+					switch {
+					case n == 0:
+						return big.NewFloat(2)
+					case n%3 == 2:
+						return big.NewFloat(float64(2 * (n + 1) / 3))
+					}
+					return big.NewFloat(1)
+				},
+				bigfloat.E(prec),
+			},
+
+			// 4/π = 1 + 1²/(3 + 2²/(5 + 3²/(7 + ...)))
+			{
+				"4/π",
+				func(n int) *big.Float { return big.NewFloat(float64(n * n)) },
+				func(n int) *big.Float { return big.NewFloat(float64(2*n + 1)) },
+				new(big.Float).SetPrec(prec).Quo(big.NewFloat(4), bigfloat.Pi(prec+64)),
+			},
+		} {
+			if x := bigfloat.ContinuedFraction(test.a, test.b, prec); x.Cmp(test.want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, %s =\ngot  %g;\nwant %g", prec, test.name, x, test.want)
+			}
+		}
+	}
+}
+
+// Fractions with a zero a(n) end there.
+func TestContinuedFractionFinite(t *testing.T) {
+	// 1 + 1/(2 + 1/3) = 10/7
+	b := func(n int) *big.Float { return big.NewFloat(float64(n + 1)) }
+	a := func(n int) *big.Float {
+		if n > 2 {
+			return new(big.Float)
+		}
+		return big.NewFloat(1)
+	}
+	want := new(big.Float).SetPrec(100).Quo(big.NewFloat(10), big.NewFloat(7))
+	if x := bigfloat.ContinuedFraction(a, b, 100); x.Cmp(want) != 0 {
+		t.Errorf("1 + 1/(2 + 1/3) = %g; want %g", x, want)
+	}
+}
+
+// A fraction that doesn't converge stops at the iteration limit.
+func TestContinuedFractionMaxIter(t *testing.T) {
+	var n int
+	a := func(int) *big.Float { n++; return big.NewFloat(1) }
+	b := func(int) *big.Float { return big.NewFloat(0) }
+	bigfloat.ContinuedFraction(a, b, 53)
+	if n != 100*53 {
+		t.Errorf("a was called %d times; want %d", n, 100*53)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("ContinuedFraction(prec = 0) did not panic")
+		}
+	}()
+	bigfloat.ContinuedFraction(a, b, 0)
+}
+
+func TestSumSeries(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+
+		// e = Σ 1/n!
+		fact := new(big.Int)
+		e := bigfloat.SumSeries(func(n int) *big.Float {
+			fact.MulRange(1, int64(n))
+			return new(big.Float).SetPrec(prec+64).Quo(big.NewFloat(1), new(big.Float).SetInt(fact))
+		}, prec)
+		if want := bigfloat.E(prec); e.Cmp(want) != 0 || e.Prec() != prec {
+			t.Errorf("prec = %d, Σ 1/n! =\ngot  %g;\nwant %g", prec, e, want)
+		}
+
+		// π²/6 = Σ 1/n² = 3·Σ 1/(n²·C(2n, n)), which converges at a
+		// rate of about 2 bits per term
+		binom := new(big.Int)
+		zeta2 := bigfloat.SumSeries(func(n int) *big.Float {
+			n++
+			binom.Binomial(int64(2*n), int64(n))
+			binom.Mul(binom, big.NewInt(int64(n*n)))
+			return new(big.Float).SetPrec(prec+64).Quo(big.NewFloat(3), new(big.Float).SetInt(binom))
+		}, prec)
+		want := bigfloat.Pi(prec + 64)
+		want.Mul(want, want)
+		want.Quo(want, big.NewFloat(6)).SetPrec(prec)
+		if zeta2.Cmp(want) != 0 || zeta2.Prec() != prec {
+			t.Errorf("prec = %d, Σ 1/n² =\ngot  %g;\nwant %g", prec, zeta2, want)
+		}
+	}
+}
+
+// Σ 1/n² is too slow to sum directly; SumSeries stops after 100·prec
+// terms, and the error is about the size of the remaining terms.
+func TestSumSeriesMaxIter(t *testing.T) {
+	var calls int
+	x := bigfloat.SumSeries(func(n int) *big.Float {
+		calls++
+		return big.NewFloat(1 / float64(n+1) / float64(n+1))
+	}, 24)
+	x64, _ := x.Float64()
+	if want := math.Pi * math.Pi / 6; calls != 2400 || math.Abs(x64-want) > 1.0/2400 {
+		t.Errorf("Σ 1/n² = %g after %d terms; want %g after 2400 terms", x64, calls, want)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("SumSeries(prec = 0) did not panic")
+		}
+	}()
+	bigfloat.SumSeries(func(int) *big.Float { return big.NewFloat(1) }, 0)
+}
+
+func TestGeomSeriesSum(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		near1 := big.NewFloat(1).SetPrec(prec)
+		near1.SetMantExp(near1, -int(prec)+1) // an ulp of 1
+
+		rs := []*big.Float{
+			big.NewFloat(0.5),
+			big.NewFloat(2),
+			big.NewFloat(-0.75),
+			big.NewFloat(0),
+			big.NewFloat(1),
+			big.NewFloat(-1),
+			big.NewFloat(1 + 1.0/(1<<20)),
+			big.NewFloat(1 - 1.0/(1<<20)),
+			new(big.Float).SetPrec(prec).Add(big.NewFloat(1), near1),
+			new(big.Float).SetPrec(prec).Sub(big.NewFloat(1), near1),
+		}
+		a := new(big.Float).SetPrec(prec).Quo(big.NewFloat(1), big.NewFloat(3))
+		for _, r := range rs {
+			r.SetPrec(prec)
+			for _, n := range []int{0, 1, 2, 10, 100} {
+
+				// the term-by-term sum, which is exact at this precision:
+				// a·rᵏ fits in (k+1)·prec bits, and the exponents of the
+				// terms are within 2n of each other
+				p := uint(n+1)*prec + uint(2*n) + 64
+				term := new(big.Float).SetPrec(p).Set(a)
+				sum := new(big.Float).SetPrec(p)
+				for k := 0; k < n; k++ {
+					sum.Add(sum, term)
+					term.Mul(term, r)
+				}
+				want := sum.SetPrec(prec)
+
+				if x := bigfloat.GeomSeriesSum(a, r, n); x.Cmp(want) != 0 || x.Prec() != prec {
+					t.Errorf("prec = %d, GeomSeriesSum(1/3, %g, %d) =\ngot  %g;\nwant %g", prec, r, n, x, want)
+				}
+			}
+		}
+	}
+}
+
+func TestGeomSeriesSumPanics(t *testing.T) {
+	inf := big.NewFloat(math.Inf(+1))
+	for _, test := range []struct {
+		a, r *big.Float
+		n    int
+	}{
+		{big.NewFloat(1), big.NewFloat(0.5), -1},
+		{inf, big.NewFloat(0.5), 2},
+		{big.NewFloat(1), inf, 2},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("GeomSeriesSum(%g, %g, %d) did not panic", test.a, test.r, test.n)
+				}
+			}()
+			bigfloat.GeomSeriesSum(test.a, test.r, test.n)
+		}()
+	}
+}
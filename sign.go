@@ -0,0 +1,37 @@
+package bigfloat
+
+import "math/big"
+
+// Abs returns a big.Float representation of the absolute value of z.
+// Precision and rounding mode are the same as the ones of the
+// argument, and z is not modified. The function returns +0 when z =
+// ±0, and +Inf when z = ±Inf.
+func Abs(z *big.Float) *big.Float {
+	return new(big.Float).Copy(z).Abs(z)
+}
+
+// Neg returns a big.Float representation of -z. Precision and
+// rounding mode are the same as the ones of the argument, and z is not
+// modified. The function returns ∓0 when z = ±0, and ∓Inf when z =
+// ±Inf.
+func Neg(z *big.Float) *big.Float {
+	return new(big.Float).Copy(z).Neg(z)
+}
+
+// Copysign returns a big.Float with the magnitude of x and the sign of
+// sign. Precision and rounding mode are the same as the ones of x,
+// and neither argument is modified. The sign of ±0 and ±Inf is
+// honored in both arguments, so Copysign(1, -0) = -1.
+func Copysign(x, sign *big.Float) *big.Float {
+	z := Abs(x)
+	if sign.Signbit() {
+		z.Neg(z)
+	}
+	return z
+}
+
+// Signbit reports whether z is negative or negative zero. Unlike
+// z.Sign() < 0, it's true for -0.
+func Signbit(z *big.Float) bool {
+	return z.Signbit()
+}
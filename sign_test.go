@@ -0,0 +1,70 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+var signValues = []float64{
+	+0.0,
+	math.Copysign(0, -1),
+	1.5,
+	-1.5,
+	1e300,
+	-1e-300,
+	math.Inf(+1),
+	math.Inf(-1),
+}
+
+// checkFloat64 reports an error if x doesn't hold f, including the sign
+// of zero.
+func checkFloat64(t *testing.T, name string, x *big.Float, f float64) {
+	t.Helper()
+	x64, _ := x.Float64()
+	if x64 != f || math.Signbit(x64) != math.Signbit(f) {
+		t.Errorf("%s = %g; want %g", name, x64, f)
+	}
+}
+
+func TestAbsNeg(t *testing.T) {
+	for _, f := range signValues {
+		z := big.NewFloat(f).SetPrec(100).SetMode(big.ToZero)
+
+		x := bigfloat.Abs(z)
+		checkFloat64(t, "Abs", x, math.Abs(f))
+		if x.Prec() != 100 || x.Mode() != big.ToZero {
+			t.Errorf("Abs(%g) has precision %d and mode %s; want 100 and ToZero", f, x.Prec(), x.Mode())
+		}
+
+		x = bigfloat.Neg(z)
+		checkFloat64(t, "Neg", x, -f)
+		if x.Prec() != 100 || x.Mode() != big.ToZero {
+			t.Errorf("Neg(%g) has precision %d and mode %s; want 100 and ToZero", f, x.Prec(), x.Mode())
+		}
+
+		// the argument must be left unmodified
+		checkFloat64(t, "z", z, f)
+	}
+}
+
+func TestCopysign(t *testing.T) {
+	for _, f := range signValues {
+		for _, s := range signValues {
+			x, sign := big.NewFloat(f), big.NewFloat(s)
+			checkFloat64(t, "Copysign", bigfloat.Copysign(x, sign), math.Copysign(f, s))
+			checkFloat64(t, "x", x, f)
+			checkFloat64(t, "sign", sign, s)
+		}
+	}
+}
+
+func TestSignbit(t *testing.T) {
+	for _, f := range signValues {
+		if got := bigfloat.Signbit(big.NewFloat(f)); got != math.Signbit(f) {
+			t.Errorf("Signbit(%g) = %v; want %v", f, got, math.Signbit(f))
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package bigfloat
+
+import "math/big"
+
+// Solve returns a root of f in the interval [lo, hi], with prec bits
+// of precision. f(lo) and f(hi) must have opposite signs; the function
+// panics if they don't. If prec is 0, the larger of the precisions of
+// lo and hi is used. f is called with arguments of precision prec+64,
+// and it should compute its result with the precision of its argument,
+// as the functions of the package do.
+//
+// Unlike Newton, Solve doesn't need the derivative of f or a good
+// initial guess. It keeps the root bracketed, and shrinks the bracket
+// with regula falsi steps (in the Illinois variant, which converges
+// superlinearly), falling back to bisection whenever a step doesn't
+// halve the bracket, so it always converges.
+func Solve(f func(*big.Float) *big.Float, lo, hi *big.Float, prec uint) *big.Float {
+	if prec == 0 {
+		prec = lo.Prec()
+		if hi.Prec() > prec {
+			prec = hi.Prec()
+		}
+	}
+	p := prec + 64 // guard digits
+
+	a := new(big.Float).SetPrec(p).Set(lo)
+	b := new(big.Float).SetPrec(p).Set(hi)
+	fa, fb := f(a), f(b)
+	if fa.Sign() == 0 {
+		return a.SetPrec(prec)
+	}
+	if fb.Sign() == 0 {
+		return b.SetPrec(prec)
+	}
+	if fa.Sign() == fb.Sign() {
+		panic("Solve: f(lo) and f(hi) have the same sign")
+	}
+	if a.Cmp(b) > 0 {
+		a, b, fa, fb = b, a, fb, fa
+	}
+
+	// e0 is the exponent of the larger of |lo| and |hi|
+	e0 := a.MantExp(nil)
+	if be := b.MantExp(nil); a.Sign() == 0 || (b.Sign() != 0 && be > e0) {
+		e0 = be
+	}
+
+	// side is the endpoint that was kept by the last step: -1 for a,
+	// +1 for b
+	side := 0
+	bisect := false
+	w := new(big.Float).SetPrec(p).Sub(b, a)
+	for {
+		// Stop when the bracket is below half an ulp of the result. If
+		// the bracket still contains 0, the root may be 0 itself, and
+		// there's no ulp to measure against, so use the magnitude of
+		// the initial interval instead.
+		e := e0
+		if a.Sign() == b.Sign() {
+			e = a.MantExp(nil)
+			if be := b.MantExp(nil); be > e {
+				e = be
+			}
+		}
+		if w.MantExp(nil) < e-int(prec)-1 {
+			break
+		}
+
+		// regula falsi: c = b - fb·(b - a)/(fb - fa)
+		c := new(big.Float).SetPrec(p)
+		if !bisect {
+			d := new(big.Float).SetPrec(p).Sub(fb, fa)
+			c.Mul(fb, w)
+			c.Quo(c, d)
+			c.Sub(b, c)
+		}
+		if bisect || c.Cmp(a) <= 0 || c.Cmp(b) >= 0 {
+			c.Add(a, b)
+			c.SetMantExp(c, -1)
+
+			// a and b are adjacent at the working precision
+			if c.Cmp(a) == 0 || c.Cmp(b) == 0 {
+				break
+			}
+		}
+
+		fc := f(c)
+		if fc.Sign() == 0 {
+			return c.SetPrec(prec)
+		}
+
+		// Replace the endpoint with the same sign as fc. If the other
+		// one was kept by the last step too, halve its f value, so that
+		// the next step moves closer to it (the Illinois trick).
+		if fc.Sign() == fb.Sign() {
+			b, fb = c, fc
+			if side == -1 {
+				fa = new(big.Float).SetMantExp(fa, -1)
+			}
+			side = -1
+		} else {
+			a, fa = c, fc
+			if side == +1 {
+				fb = new(big.Float).SetMantExp(fb, -1)
+			}
+			side = +1
+		}
+
+		// bisect next if the bracket didn't halve
+		nw := new(big.Float).SetPrec(p).Sub(b, a)
+		bisect = !bisect && nw.Cmp(new(big.Float).SetMantExp(w, -1)) > 0
+		w = nw
+	}
+
+	x := new(big.Float).SetPrec(p).Add(a, b)
+	x.SetMantExp(x, -1)
+	return x.SetPrec(prec)
+}
@@ -0,0 +1,93 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestSolve(t *testing.T) {
+	const (
+		sqrt2  = "1.4142135623730950488016887242096980785696718753769480731766797379907324784621070388503875343276415727350138462309122970249248360558507372126441214970999358314132226659275055927557999505011527820605714701095599716059702745345968620147285174186408891986095523292304843087143214508397626036279952514079896872533965463318088296406206152583523950547457503"
+		dottie = "0.73908513321516064165531208767387340401341175890075746496568063577328465488354759459937610693176653184980124664398716302771490369130842031578044057462077868852490389153928943884509523480133563127677223158095635377657245120437341993643351253840978003434064670047940214347808027180188377113613820420663163350372779916967312232300613886582036217708109979"
+	)
+
+	for _, test := range []struct {
+		name   string
+		f      func(*big.Float) *big.Float
+		lo, hi float64
+		want   string
+	}{
+		{"t² - 2", func(t *big.Float) *big.Float {
+			x := new(big.Float).Mul(t, t)
+			return x.Sub(x, big.NewFloat(2))
+		}, 1, 2, sqrt2},
+		{"2 - t²", func(t *big.Float) *big.Float { // decreasing f, swapped bounds
+			x := new(big.Float).Mul(t, t)
+			return x.Sub(big.NewFloat(2), x)
+		}, 2, 0, sqrt2},
+		{"cos(t) - t", func(t *big.Float) *big.Float {
+			return new(big.Float).Sub(bigfloat.Cos(t), t)
+		}, 0, 1, dottie},
+		{"t - 3", func(t *big.Float) *big.Float {
+			return new(big.Float).Sub(t, big.NewFloat(3))
+		}, 3, 4, "3"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			lo, hi := big.NewFloat(test.lo).SetPrec(prec), big.NewFloat(test.hi).SetPrec(prec)
+			x := bigfloat.Solve(test.f, lo, hi, prec)
+
+			if x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, Solve(%s, %g, %g) =\ngot  %g (prec = %d);\nwant %g", prec, test.name, test.lo, test.hi, x, x.Prec(), want)
+			}
+		}
+	}
+}
+
+// A root at 0 has no ulp to converge to, so Solve stops when the
+// bracket is below half an ulp of the larger bound, and the result is
+// 0 to within that. t³ is flat at 0, so the bracket doesn't shrink
+// around it faster than bisection would.
+func TestSolveZeroRoot(t *testing.T) {
+	f := func(t *big.Float) *big.Float {
+		x := new(big.Float).Mul(t, t)
+		return x.Mul(x, t)
+	}
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		lo, hi := big.NewFloat(-1).SetPrec(prec), big.NewFloat(2).SetPrec(prec)
+		x := bigfloat.Solve(f, lo, hi, prec)
+
+		if x.Sign() != 0 && x.MantExp(nil) > 2-int(prec) || x.Prec() != prec {
+			t.Errorf("prec = %d, Solve(t³, -1, 2) = %g (prec = %d); want 0", prec, x, x.Prec())
+		}
+	}
+}
+
+// log(10) as the root of e^t - 10, with the default precision.
+func TestSolveExp(t *testing.T) {
+	for _, prec := range []uint{53, 100, 500, 1000} {
+		f := func(t *big.Float) *big.Float {
+			return new(big.Float).Sub(bigfloat.Exp(t), big.NewFloat(10))
+		}
+		x := bigfloat.Solve(f, big.NewFloat(0).SetPrec(prec), big.NewFloat(10).SetPrec(prec), 0)
+		want := bigfloat.Log(big.NewFloat(10).SetPrec(prec))
+		if x.Cmp(want) != 0 || x.Prec() != prec {
+			t.Errorf("prec = %d, Solve(e^t - 10, 0, 10) =\ngot  %g (prec = %d);\nwant %g", prec, x, x.Prec(), want)
+		}
+	}
+}
+
+func TestSolvePanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Solve did not panic on a bracket with no sign change")
+		}
+	}()
+	f := func(t *big.Float) *big.Float { return new(big.Float).Mul(t, t) }
+	bigfloat.Solve(f, big.NewFloat(1), big.NewFloat(math.Inf(+1)), 53)
+}
@@ -3,31 +3,239 @@
 package bigfloat
 
 import (
+	"context"
+	"errors"
 	"math"
 	"math/big"
 )
 
+// ErrNegative is returned by the error-returning variants of the
+// package functions when the argument is negative.
+var ErrNegative = errors.New("bigfloat: argument is negative")
+
 // Sqrt returns a big.Float representation of the square root of
-// z. Precision is the same as the one of the argument. The function
-// panics if z is negative, returns ±0 when z = ±0, and +Inf when z =
-// +Inf.
+// z. Precision and rounding mode are the same as the ones of the
+// argument, and the result is correctly rounded: it's the exact root
+// rounded according to the rounding mode, as if computed with infinite
+// precision. The function panics if z is negative, returns ±0 when z
+// = ±0, and +Inf when z = +Inf.
 func Sqrt(z *big.Float) *big.Float {
+	x, err := SqrtErr(z)
+	if err != nil {
+		panic("Sqrt: argument is negative")
+	}
+	return x
+}
+
+// SqrtErr is like Sqrt, but it returns ErrNegative instead of
+// panicking when z is negative.
+func SqrtErr(z *big.Float) (*big.Float, error) {
+	return SqrtContext(context.Background(), z)
+}
+
+// SqrtContext is like SqrtErr, but it stops the computation and
+// returns ctx.Err() if ctx is done before the result is ready. The
+// context is checked at the start of every iteration.
+func SqrtContext(ctx context.Context, z *big.Float) (*big.Float, error) {
+
+	// error on negative z
+	if z.Sign() == -1 {
+		return nil, ErrNegative
+	}
+
+	// √±0 = ±0
+	if z.Sign() == 0 {
+		return big.NewFloat(float64(z.Sign())), nil
+	}
+
+	// √+Inf  = +Inf
+	if z.IsInf() {
+		return big.NewFloat(math.Inf(+1)), nil
+	}
+
+	x, _, err := sqrtContext(ctx, new(big.Float), z, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	return sqrtRound(x, z, z.Mode()), nil
+}
+
+// SqrtPrec returns a big.Float representation of the square root of
+// z, correctly rounded to prec bits using the given rounding mode. The
+// precision of z does not need to match prec; if prec is 0, z's
+// precision is used. The function panics if z is negative, returns
+// ±0 when z = ±0, and +Inf when z = +Inf.
+func SqrtPrec(z *big.Float, prec uint, mode big.RoundingMode) *big.Float {
 
 	// panic on negative z
 	if z.Sign() == -1 {
-		panic("Sqrt: argument is negative")
+		panic("SqrtPrec: argument is negative")
+	}
+
+	if prec == 0 {
+		prec = z.Prec()
 	}
 
 	// √±0 = ±0
 	if z.Sign() == 0 {
-		return big.NewFloat(float64(z.Sign()))
+		return new(big.Float).SetPrec(prec).SetMode(mode).Set(z)
 	}
 
 	// √+Inf  = +Inf
 	if z.IsInf() {
-		return big.NewFloat(math.Inf(+1))
+		return new(big.Float).SetPrec(prec).SetMode(mode).SetInf(false)
+	}
+
+	// sqrt computes the root with the precision of its argument, so
+	// make sure z has at least prec bits, and round it to prec+2 bits if
+	// it has more: that's enough for sqrtRound to fix the result,
+	// which is checked against the original z.
+	t := z
+	if z.Prec() != prec+2 {
+		t = new(big.Float).SetPrec(prec + 2).Set(z)
+	}
+	x := sqrt(new(big.Float), t).SetPrec(prec)
+
+	return sqrtRound(x, z, mode)
+}
+
+// SqrtGuess is like Sqrt, but it starts the iteration from guess, an
+// approximation of √z supplied by the caller, instead of computing
+// one. A good guess, for example a root of z computed earlier with a
+// lower precision, saves iterations. The accuracy of guess is measured
+// against z, so its precision doesn't need to match its number of
+// correct bits; a guess that is less accurate than the float64
+// approximation Sqrt starts from is ignored. The function panics if z
+// is negative, or if guess is not positive and finite.
+func SqrtGuess(z, guess *big.Float) *big.Float {
+
+	// panic on negative z
+	if z.Sign() == -1 {
+		panic("SqrtGuess: argument is negative")
+	}
+
+	if guess.Sign() <= 0 || guess.IsInf() {
+		panic("SqrtGuess: guess is not positive and finite")
+	}
+
+	// √±0 = ±0, √+Inf = +Inf
+	if z.Sign() == 0 || z.IsInf() {
+		return Sqrt(z)
+	}
+
+	// If g = √z·(1 + ε), then g² - z = z·(2ε + ε²), so the guess has
+	// about exp(z) - exp(g² - z) correct bits. Subtracting the exact g²
+	// and rounding the difference gives its exponent exactly.
+	sq := new(big.Float).SetPrec(2*guess.Prec()).Mul(guess, guess)
+	d := new(big.Float).SetPrec(64).Sub(sq, z)
+	if d.Sign() == 0 {
+		// the guess is the exact root
+		return new(big.Float).SetPrec(z.Prec()).SetMode(z.Mode()).Set(guess)
+	}
+	bits := z.MantExp(nil) - d.MantExp(nil) - 1
+	if bits < 53 {
+		return Sqrt(z)
+	}
+
+	// more correct bits than the iteration needs are just discarded
+	if max := 2 * int(z.Prec()+64); bits > max {
+		bits = max
+	}
+
+	g := new(big.Float).SetPrec(uint(bits)).Set(guess)
+	x, _, _ := sqrtContext(context.Background(), new(big.Float), z, g, 0)
+	return sqrtRound(x, z, z.Mode())
+}
+
+// SqrtFloat64 returns the square root of z as a float64, computed
+// with math.Sqrt, and true, if z has at most 53 bits of precision and
+// is exactly representable as a float64; in that case the result is
+// √z correctly rounded to nearest even. Otherwise, or if z is
+// negative, it returns 0 and false, and the caller should use Sqrt.
+// SqrtFloat64 doesn't allocate a result, and it's much faster than
+// Sqrt.
+func SqrtFloat64(z *big.Float) (float64, bool) {
+	if z.Prec() > 53 || z.Sign() < 0 {
+		return 0, false
+	}
+	f, acc := z.Float64()
+	if acc != big.Exact {
+		return 0, false
+	}
+	return math.Sqrt(f), true
+}
+
+// sqrtRound adjusts x, an approximation of √z rounded to nearest that
+// may be off by one ulp, so that it's correctly rounded in the
+// direction given by mode, and returns x.
+//
+// Deciding the direction of the rounding from the Newton iteration's
+// guard digits alone is not enough: when √z is exactly representable,
+// or very close to the midpoint between two floats, the iteration may
+// converge to it from either side. Comparing squares with z instead is
+// exact.
+func sqrtRound(x, z *big.Float, mode big.RoundingMode) *big.Float {
+	prec := x.Prec()
+	tiny := new(big.Float).SetMantExp(big.NewFloat(1), x.MantExp(nil)-int(prec)-2)
+
+	switch mode {
+	case big.ToZero, big.ToNegativeInf:
+		// x must be the largest float with x² <= z
+		sq := new(big.Float).SetPrec(2*prec).Mul(x, x)
+		if sq.Cmp(z) > 0 {
+			x.SetMode(big.ToNegativeInf).Sub(x, tiny)
+		}
+	case big.AwayFromZero, big.ToPositiveInf:
+		// x must be the smallest float with x² >= z
+		sq := new(big.Float).SetPrec(2*prec).Mul(x, x)
+		if sq.Cmp(z) < 0 {
+			x.SetMode(big.ToPositiveInf).Add(x, tiny)
+		}
+	default:
+		// √z must lie between the midpoints of x and its two
+		// neighbours. The midpoints have prec+2 bits, so their squares
+		// are exact with 2·prec+4 bits.
+		mid := func(n *big.Float) (*big.Float, int) {
+			m := new(big.Float).SetPrec(prec+2).Add(x, n)
+			m.SetMantExp(m, -1)
+			sq := new(big.Float).SetPrec(2*prec+4).Mul(m, m)
+			return m, sq.Cmp(z)
+		}
+		lo := new(big.Float).SetPrec(prec).SetMode(big.ToNegativeInf).Sub(x, tiny)
+		hi := new(big.Float).SetPrec(prec).SetMode(big.ToPositiveInf).Add(x, tiny)
+
+		// on a tie, round the midpoint itself
+		if m, c := mid(lo); c > 0 {
+			x.Set(lo)
+		} else if c == 0 {
+			x.SetMode(mode).Set(m)
+		} else if m, c := mid(hi); c < 0 {
+			x.Set(hi)
+		} else if c == 0 {
+			x.SetMode(mode).Set(m)
+		}
 	}
 
+	return x.SetMode(mode)
+}
+
+// sqrt sets x to √z, computed with z's precision, and returns x.
+// z must be positive and finite. The mantissa of x is reused for
+// the result.
+func sqrt(x, z *big.Float) *big.Float {
+	x, _, _ = sqrtContext(context.Background(), x, z, nil, 0)
+	return x
+}
+
+// sqrtContext is like sqrt, but returns ctx.Err() if ctx is done
+// before the result is ready, together with the partial result, and
+// ErrMaxIter if maxIter > 0 iterations are not enough. If guess is not
+// nil, it's used as the starting point of the iteration, and its
+// precision is taken as the number of its correct bits; otherwise the
+// starting point is computed from a float64 approximation of z. The
+// returned Stats describe the computation.
+func sqrtContext(ctx context.Context, x, z, guess *big.Float, maxIter int) (*big.Float, Stats, error) {
+
 	// Compute √(a·2**b) as
 	//   √(a)·2**b/2       if b is even
 	//   √(2a)·2**b/2      if b > 0 is odd
@@ -35,6 +243,10 @@ func Sqrt(z *big.Float) *big.Float {
 	//
 	// The difference in the odd exponent case is due to the fact that
 	// exp/2 is rounded in different directions when exp is negative.
+	//
+	// Since 0.25 <= mant < 2, this also keeps the float64 approximation
+	// the initial guesses are computed from finite and nonzero, however
+	// far z is outside of the float64 range.
 	mant := new(big.Float)
 	exp := z.MantExp(mant)
 	switch exp % 2 {
@@ -44,28 +256,78 @@ func Sqrt(z *big.Float) *big.Float {
 		mant.Mul(big.NewFloat(0.5), mant)
 	}
 
+	// scale the guess in the same way
+	if guess != nil {
+		guess = new(big.Float).SetMantExp(guess, -(exp / 2))
+	} else {
+		mf, _ := mant.Float64()
+		guess = big.NewFloat(math.Sqrt(mf))
+	}
+
 	// Solving x² - z = 0 directly requires a Quo call, but it's
 	// faster for small precisions.
 	//
 	// Solving 1/x² - z = 0 avoids the Quo call and is much faster for
 	// high precisions.
 	//
-	// Use sqrtDirect for prec <= 128 and sqrtInverse for prec > 128.
-	var x *big.Float
-	if z.Prec() <= 128 {
-		x = sqrtDirect(mant)
+	// Use sqrtDirect for prec <= sqrtDirectThreshold and sqrtInverse
+	// for larger precisions.
+	var (
+		stats Stats
+		err   error
+	)
+	if z.Prec() <= sqrtDirectThreshold {
+		_, stats, err = sqrtDirect(ctx, x, mant, guess, maxIter)
 	} else {
-		x = sqrtInverse(mant)
+		_, stats, err = sqrtInverse(ctx, x, mant, guess, maxIter)
 	}
+	if err != nil {
+		// keep the partial result
+		return x.SetMantExp(x, exp/2), stats, err
+	}
+
+	// If guess = √mant·(1 + ε), then guess² - mant = mant·(2ε + ε²).
+	// Subtracting the exact square keeps all the bits of the
+	// difference, so |ε| ≈ |guess² - mant|/2mant is accurate however
+	// good the guess is.
+	d := new(big.Float).SetPrec(2*guess.Prec()).Mul(guess, guess)
+	d.SetPrec(64).Sub(d, mant)
+	d.Quo(d, mant)
+	d.SetMantExp(d, -1)
+	stats.GuessError, _ = d.Abs(d).Float64()
 
 	// re-attach the exponent and return
-	return x.SetMantExp(x, exp/2)
+	return x.SetMantExp(x, exp/2), stats, nil
 
 }
 
+// DefaultSqrtThreshold is the default precision up to which Sqrt
+// solves x² - z = 0 directly instead of computing 1/√z first.
+const DefaultSqrtThreshold uint = 128
+
+// sqrtDirectThreshold is the precision up to which sqrt uses
+// sqrtDirect instead of sqrtInverse.
+var sqrtDirectThreshold = DefaultSqrtThreshold
+
+// SetSqrtThreshold sets the precision up to which Sqrt and the other
+// square root functions solve x² - z = 0 directly, which needs a
+// division per iteration, instead of solving 1/x² - z = 0, which
+// doesn't but needs a final multiplication. The best value depends on
+// the relative speed of big.Float division and multiplication on the
+// machine; the default is DefaultSqrtThreshold. SetSqrtThreshold
+// returns the previous threshold.
+//
+// SetSqrtThreshold must not be called concurrently with the functions
+// of the package.
+func SetSqrtThreshold(bits uint) uint {
+	old := sqrtDirectThreshold
+	sqrtDirectThreshold = bits
+	return old
+}
+
 // compute √z using newton to solve
-// t² - z = 0 for t
-func sqrtDirect(z *big.Float) *big.Float {
+// t² - z = 0 for t from guess, storing the result in x
+func sqrtDirect(ctx context.Context, x, z, guess *big.Float, maxIter int) (*big.Float, Stats, error) {
 	// f(t)/f'(t) = 0.5(t² - z)/t
 	half := big.NewFloat(0.5)
 	f := func(t *big.Float) *big.Float {
@@ -75,16 +337,60 @@ func sqrtDirect(z *big.Float) *big.Float {
 		return x.Quo(x, t)            // return x = 0.5(t² - z)/t
 	}
 
-	// initial guess
-	zf, _ := z.Float64()
-	guess := big.NewFloat(math.Sqrt(zf))
-
-	return newton(f, guess, z.Prec())
+	_, n, err := newtonContext(ctx, f, x.SetPrec(guess.Prec()).Set(guess), z.Prec(), maxIter)
+	return x, Stats{Iterations: n, Path: PathDirect}, err
 }
 
+// sqrtHalleyThreshold is the precision above which sqrtInverse uses
+// Halley's method instead of Newton's.
+var sqrtHalleyThreshold uint = 4096
+
 // compute √z using newton to solve
-// 1/t² - z = 0 for x and then inverting.
-func sqrtInverse(z *big.Float) *big.Float {
+// 1/t² - z = 0 for x and then inverting, storing the result in x.
+// guess is the initial guess for √z.
+//
+// The result is √z correctly rounded to nearest even. z·(1/√z) is
+// within a couple of ulps of √z at z.Prec()+32 bits, and the 32 guard
+// digits decide the rounding unless √z is very close to the midpoint
+// between two floats. The root of 1 + 6·2**(-prec) is less than
+// 2**(-prec) ulps below a midpoint, which no fixed number of guard
+// digits resolves, so results that close to a midpoint are checked
+// against z with sqrtRound.
+func sqrtInverse(ctx context.Context, x, z, guess *big.Float, maxIter int) (*big.Float, Stats, error) {
+	guess = new(big.Float).SetPrec(guess.Prec()).Quo(big.NewFloat(1), guess)
+	_, stats, err := rsqrtInverse(ctx, x, z, guess, maxIter)
+	if err != nil {
+		// the partial result has x's precision
+		x.Mul(z, x)
+		if x.Prec() > z.Prec() {
+			x.SetPrec(z.Prec())
+		}
+		return x, stats, err
+	}
+	x.Mul(z, x)
+	if nearMidpoint(x, z.Prec()) {
+		return sqrtRound(x.SetPrec(z.Prec()), z, big.ToNearestEven), stats, nil
+	}
+	return x.SetPrec(z.Prec()), stats, nil
+}
+
+// nearMidpoint reports whether x, which is accurate to a couple of
+// ulps, is too close to the midpoint between two floats with prec bits
+// for its rounding to prec bits to be decided.
+func nearMidpoint(x *big.Float, prec uint) bool {
+	// d = x - trunc(x) - ulp/2, where the ulp is the one at prec bits
+	t := new(big.Float).SetPrec(prec).SetMode(big.ToZero).Set(x)
+	d := new(big.Float).SetPrec(x.Prec()).Sub(x, t)
+	half := new(big.Float).SetMantExp(big.NewFloat(1), x.MantExp(nil)-int(prec)-1)
+	d.Sub(d, half)
+	return d.Sign() == 0 || d.MantExp(nil) <= x.MantExp(nil)-int(x.Prec())+2
+}
+
+// compute 1/√z using newton to solve
+// 1/t² - z = 0 for t, storing the result in x with z.Prec() + 32
+// bits of precision. If guess is not nil, it's used as the initial
+// guess for 1/√z.
+func rsqrtInverse(ctx context.Context, x, z, guess *big.Float, maxIter int) (*big.Float, Stats, error) {
 	// f(t)/f'(t) = -0.5t(1 - zt²)
 	nhalf := big.NewFloat(-0.5)
 	one := big.NewFloat(1)
@@ -97,13 +403,88 @@ func sqrtInverse(z *big.Float) *big.Float {
 		return new(big.Float).Mul(t, u) // x = -0.5t(1 - zt²)
 	}
 
+	// Halley's step for the same equation is
+	//   -t·e/(2 - 1.5e),    where e = 1 - zt²
+	// and since e is small, replacing the division with the first
+	// terms of its series expansion
+	//   -t·(0.5e + 0.375e²)
+	// keeps the cubic convergence.
+	n3o8 := big.NewFloat(-0.375)
+	h := func(t *big.Float) *big.Float {
+		e := new(big.Float)
+		e.Mul(t, t)                     // e = t²
+		e.Mul(e, z)                     // e = zt²
+		e.Sub(one, e)                   // e = 1 - zt²
+		u := new(big.Float).Mul(e, e)   // u = e²
+		u.Mul(u, n3o8)                  // u = -0.375e²
+		e.Mul(e, nhalf)                 // e = -0.5e
+		u.Add(u, e)                     // u = -(0.5e + 0.375e²)
+		return new(big.Float).Mul(t, u) // x = -t(0.5e + 0.375e²)
+	}
+
 	// initial guess
-	zf, _ := z.Float64()
-	guess := big.NewFloat(1 / math.Sqrt(zf))
+	if guess != nil {
+		guess = x.SetPrec(guess.Prec()).Set(guess)
+	} else {
+		zf, _ := z.Float64()
+		guess = x.SetPrec(53).SetFloat64(1 / math.Sqrt(zf))
+	}
 
 	// There's another operation after newton,
 	// so we need to force it to return at least
 	// a few guard digits. Use 32.
-	x := newton(f, guess, z.Prec()+32)
-	return x.Mul(z, x).SetPrec(z.Prec())
+	//
+	// At high precisions the cubic convergence of Halley's method
+	// saves enough iterations to pay for the extra multiplication.
+	if z.Prec() > sqrtHalleyThreshold {
+		_, n, err := halleyContext(ctx, h, guess, z.Prec()+32, maxIter)
+		return x, Stats{Iterations: n, Path: PathInverseHalley}, err
+	}
+	_, n, err := newtonContext(ctx, f, guess, z.Prec()+32, maxIter)
+	return x, Stats{Iterations: n, Path: PathInverse}, err
+}
+
+// Rsqrt returns a big.Float representation of the reciprocal of the
+// square root of z. Precision is the same as the one of the argument.
+// The function panics if z is negative, returns ±Inf when z = ±0, and
+// +0 when z = +Inf.
+//
+// Rsqrt is faster and more accurate than computing 1/Sqrt(z), since
+// 1/√z is what the Newton iteration in Sqrt converges to before the
+// final multiplication by z.
+func Rsqrt(z *big.Float) *big.Float {
+
+	// panic on negative z
+	if z.Sign() == -1 {
+		panic("Rsqrt: argument is negative")
+	}
+
+	// 1/√±0 = ±Inf
+	if z.Sign() == 0 {
+		return new(big.Float).SetPrec(z.Prec()).SetInf(z.Signbit())
+	}
+
+	// 1/√+Inf = +0
+	if z.IsInf() {
+		return new(big.Float).SetPrec(z.Prec())
+	}
+
+	// Compute 1/√(a·2**b) as
+	//   1/√(a)·2**(-b/2)       if b is even
+	//   1/√(2a)·2**(-b/2)      if b > 0 is odd
+	//   1/√(0.5a)·2**(-b/2)    if b < 0 is odd
+	// as in sqrt.
+	mant := new(big.Float)
+	exp := z.MantExp(mant)
+	switch exp % 2 {
+	case 1:
+		mant.Mul(big.NewFloat(2), mant)
+	case -1:
+		mant.Mul(big.NewFloat(0.5), mant)
+	}
+
+	x, _, _ := rsqrtInverse(context.Background(), new(big.Float), mant, nil, 0)
+	x.SetMantExp(x, -(exp / 2))
+
+	return x.SetPrec(z.Prec())
 }
@@ -1,6 +1,7 @@
 package bigfloat_test
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"math/big"
@@ -115,6 +116,537 @@ func TestSqrtSpecialValues(t *testing.T) {
 	}
 }
 
+func TestSqrtErr(t *testing.T) {
+	for _, f := range []float64{
+		+0.0,
+		-0.0,
+		2.0,
+		math.Inf(+1),
+	} {
+		z := big.NewFloat(f)
+		x, err := bigfloat.SqrtErr(z)
+		if err != nil {
+			t.Errorf("SqrtErr(%g) returned error %v", z, err)
+			continue
+		}
+		if want := bigfloat.Sqrt(z); x.Cmp(want) != 0 {
+			t.Errorf("SqrtErr(%g) = %g; want %g", z, x, want)
+		}
+	}
+
+	x, err := bigfloat.SqrtErr(big.NewFloat(-2))
+	if err != bigfloat.ErrNegative || x != nil {
+		t.Errorf("SqrtErr(-2) = (%v, %v); want (nil, ErrNegative)", x, err)
+	}
+}
+
+func TestSqrtNegative(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Sqrt(-2) did not panic")
+		}
+	}()
+	bigfloat.Sqrt(big.NewFloat(-2))
+}
+
+func TestSqrtRoundingMode(t *testing.T) {
+	for _, z := range []string{"2", "3", "5", "0.1", "1e101"} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 500, 1000} {
+			down := new(big.Float).SetPrec(prec).SetMode(big.ToNegativeInf)
+			down.Parse(z, 10)
+			up := new(big.Float).SetPrec(prec).SetMode(big.ToPositiveInf)
+			up.Set(down)
+
+			lo, hi := bigfloat.Sqrt(down), bigfloat.Sqrt(up)
+			if lo.Mode() != big.ToNegativeInf || hi.Mode() != big.ToPositiveInf {
+				t.Errorf("prec = %d, Sqrt(%v) did not keep the rounding mode", prec, z)
+			}
+
+			// none of the roots is exactly representable, so the two
+			// results must differ by exactly one ulp
+			ulp := new(big.Float).SetMantExp(big.NewFloat(1), lo.MantExp(nil)-int(prec))
+			diff := new(big.Float).Sub(hi, lo)
+			if diff.Cmp(ulp) != 0 {
+				t.Errorf("prec = %d, Sqrt(%v) =\nToNegativeInf %g;\nToPositiveInf %g", prec, z, lo, hi)
+			}
+
+			// and the exact root must lie between them
+			sqLo := new(big.Float).SetPrec(2*prec).Mul(lo, lo)
+			sqHi := new(big.Float).SetPrec(2*prec).Mul(hi, hi)
+			if sqLo.Cmp(down) >= 0 || sqHi.Cmp(down) <= 0 {
+				t.Errorf("prec = %d, Sqrt(%v) =\nToNegativeInf %g;\nToPositiveInf %g", prec, z, lo, hi)
+			}
+		}
+	}
+}
+
+// When the root is exactly representable, every rounding mode must
+// return it.
+func TestSqrtRoundingModeExact(t *testing.T) {
+	for _, test := range []struct {
+		z, want string
+	}{
+		{"4", "2"},
+		{"0.25", "0.5"},
+		{"1p-1000", "1p-500"},
+		{"1e100", "1e50"},
+	} {
+		for _, prec := range []uint{400, 500, 1000} {
+			for _, mode := range []big.RoundingMode{
+				big.ToNearestEven, big.ToNearestAway, big.ToZero,
+				big.AwayFromZero, big.ToNegativeInf, big.ToPositiveInf,
+			} {
+				z := new(big.Float).SetPrec(prec).SetMode(mode)
+				z.Parse(test.z, 10)
+				want := new(big.Float).SetPrec(prec)
+				want.Parse(test.want, 10)
+
+				if x := bigfloat.Sqrt(z); x.Cmp(want) != 0 {
+					t.Errorf("prec = %d, Sqrt(%v, %s) =\ngot  %g;\nwant %g", prec, test.z, mode, x, want)
+				}
+			}
+		}
+	}
+}
+
+// sqrtRef returns √z correctly rounded to nearest even at prec bits,
+// computed with integer arithmetic only.
+func sqrtRef(z *big.Float, prec uint) *big.Float {
+
+	// z = n·2**e, with n an integer and e even
+	mant := new(big.Float)
+	e := z.MantExp(mant) - int(z.Prec())
+	n, _ := mant.SetMantExp(mant, int(z.Prec())).Int(nil)
+	if e%2 != 0 {
+		n.Lsh(n, 1)
+		e--
+	}
+
+	// scale n by 4**t so that its root has at least prec+2 bits
+	t := 0
+	if d := int(prec) + 2 - (n.BitLen()+1)/2; d > 0 {
+		t = d
+		n.Lsh(n, uint(2*t))
+	}
+
+	// √n = r + f, with 0 <= f < 1; 2r+1 has the same rounding as
+	// 2(r + f) if f > 0, since r has at least prec+2 bits
+	r := new(big.Int).Sqrt(n)
+	r2 := new(big.Int).Lsh(r, 1)
+	if new(big.Int).Mul(r, r).Cmp(n) != 0 {
+		r2.SetBit(r2, 0, 1)
+	}
+
+	x := new(big.Float).SetPrec(prec).SetInt(r2)
+	return x.SetMantExp(x, e/2-t-1)
+}
+
+// Inputs whose root is within a tiny fraction of an ulp from the
+// midpoint between two floats are the hardest to round correctly,
+// since the Newton iteration's guard digits can't tell on which side
+// of it the root lies. Build them as z = m², rounded, with m one of
+// the midpoints.
+func TestSqrtHardToRound(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, prec := range []uint{24, 53, 64, 100, 113, 128, 129, 200, 256, 500, 1000} {
+		for i := 0; i < 200; i++ {
+			// m is an odd integer with prec+1 bits
+			m := new(big.Int).Rand(rnd, new(big.Int).Lsh(big.NewInt(1), prec-1))
+			m.SetBit(m, int(prec), 1)
+			m.SetBit(m, 0, 1)
+			mf := new(big.Float).SetInt(m)
+			mf.SetMantExp(mf, -int(prec)-rnd.Intn(50))
+
+			for _, mode := range []big.RoundingMode{big.ToNearestEven, big.ToZero, big.AwayFromZero} {
+				z := new(big.Float).SetPrec(prec).SetMode(mode).Mul(mf, mf)
+				z.SetMode(big.ToNearestEven)
+				if x, want := bigfloat.Sqrt(z), sqrtRef(z, prec); x.Cmp(want) != 0 {
+					t.Errorf("prec = %d, Sqrt(%s) =\ngot  %s;\nwant %s", prec, z.Text('p', 0), x.Text('p', 0), want.Text('p', 0))
+				}
+			}
+		}
+	}
+}
+
+// The same, with z exactly the square of a midpoint: the root is a
+// tie, and it must be rounded to even.
+func TestSqrtPrecTies(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, prec := range []uint{24, 53, 64, 100, 200, 500, 1000} {
+		for i := 0; i < 100; i++ {
+			m := new(big.Int).Rand(rnd, new(big.Int).Lsh(big.NewInt(1), prec-1))
+			m.SetBit(m, int(prec), 1)
+			m.SetBit(m, 0, 1)
+			mf := new(big.Float).SetInt(m)
+			z := new(big.Float).SetPrec(2*prec+2).Mul(mf, mf) // exact
+
+			for _, mode := range []big.RoundingMode{big.ToNearestEven, big.ToNearestAway} {
+				want := new(big.Float).SetPrec(prec).SetMode(mode).Set(mf)
+				if x := bigfloat.SqrtPrec(z, prec, mode); x.Cmp(want) != 0 {
+					t.Errorf("prec = %d, SqrtPrec(%s, %s) =\ngot  %s;\nwant %s", prec, z.Text('p', 0), mode, x.Text('p', 0), want.Text('p', 0))
+				}
+			}
+		}
+	}
+}
+
+func TestSqrtCorrectlyRounded(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		for i := 0; i < 100; i++ {
+			z := new(big.Float).SetPrec(prec).SetFloat64(rnd.Float64() + 0.5)
+			z.SetMantExp(z, rnd.Intn(200)-100)
+			if x, want := bigfloat.Sqrt(z), sqrtRef(z, prec); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Sqrt(%s) =\ngot  %s;\nwant %s", prec, z.Text('p', 0), x.Text('p', 0), want.Text('p', 0))
+			}
+		}
+	}
+}
+
+// Inputs whose exponent is outside of the float64 range, where
+// z.Float64() is ±Inf or 0. The initial guesses are computed from the
+// mantissa, so both sqrtDirect and sqrtInverse, below and above the
+// default threshold, converge as for any other input.
+func TestSqrtOutOfFloat64Range(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		for _, e := range []int{4000, 4001, -4000, -4001, 5000, 5001, -5000, -5001, 1 << 20, -(1 << 20)} {
+			for _, f := range []float64{1, 2, 3, 0.7} {
+				z := new(big.Float).SetPrec(prec).SetFloat64(f)
+				z.SetMantExp(z, e)
+				if x, want := bigfloat.Sqrt(z), sqrtRef(z, prec); x.Cmp(want) != 0 {
+					t.Errorf("prec = %d, Sqrt(%g·2**%d) =\ngot  %s;\nwant %s", prec, f, e, x.Text('p', 0), want.Text('p', 0))
+				}
+
+				// Rsqrt(z)·√z must be 1 to within a few ulps
+				r := bigfloat.Rsqrt(z)
+				d := new(big.Float).SetPrec(2*prec).Mul(r, sqrtRef(z, 2*prec))
+				d.Sub(d, big.NewFloat(1))
+				if d.Sign() != 0 && d.MantExp(nil) > 2-int(prec) {
+					t.Errorf("prec = %d, Rsqrt(%g·2**%d) = %s", prec, f, e, r.Text('p', 0))
+				}
+			}
+		}
+	}
+}
+
+func TestSqrtFloat64(t *testing.T) {
+	rnd := rand.New(rand.NewSource(60))
+	for i := 0; i < 10000; i++ {
+		f := math.Ldexp(rnd.Float64()+0.5, rnd.Intn(2000)-1000)
+		for _, prec := range []uint{24, 53} {
+			z := big.NewFloat(f).SetPrec(prec)
+			x, ok := bigfloat.SqrtFloat64(z)
+			want, _ := bigfloat.Sqrt(z.SetPrec(53)).Float64()
+			if !ok || x != want {
+				t.Errorf("SqrtFloat64(%g) = %g, %t; want %g, true", z, x, ok, want)
+			}
+		}
+	}
+
+	for _, f := range []float64{+0.0, -0.0, 1, 4, math.Inf(+1), math.SmallestNonzeroFloat64, math.MaxFloat64} {
+		x, ok := bigfloat.SqrtFloat64(big.NewFloat(f))
+		if want := math.Sqrt(f); !ok || x != want || math.Signbit(x) != math.Signbit(want) {
+			t.Errorf("SqrtFloat64(%g) = %g, %t; want %g, true", f, x, ok, want)
+		}
+	}
+}
+
+// Inputs that are not exactly representable as float64s, or negative,
+// don't take the fast path.
+func TestSqrtFloat64Fallback(t *testing.T) {
+	huge := big.NewFloat(1).SetPrec(53)
+	huge.SetMantExp(huge, 2000)
+	tiny := big.NewFloat(1).SetPrec(53)
+	tiny.SetMantExp(tiny, -2000)
+	subnormal := big.NewFloat(1 + 0x1p-52)
+	subnormal.SetMantExp(subnormal, -1060)
+
+	for _, z := range []*big.Float{
+		huge,
+		tiny,
+		subnormal,
+		big.NewFloat(2).SetPrec(54),
+		big.NewFloat(2).SetPrec(1000),
+		big.NewFloat(-4),
+		big.NewFloat(math.Inf(-1)),
+	} {
+		if x, ok := bigfloat.SqrtFloat64(z); ok || x != 0 {
+			t.Errorf("SqrtFloat64(%s (prec = %d)) = %g, %t; want 0, false", z.Text('p', 0), z.Prec(), x, ok)
+		}
+	}
+}
+
+func TestSqrtGuess(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		huge := big.NewFloat(3).SetPrec(prec)
+		huge.SetMantExp(huge, 5001)
+		for _, z := range []*big.Float{
+			big.NewFloat(2).SetPrec(prec),
+			big.NewFloat(0.1).SetPrec(prec),
+			huge,
+		} {
+			want := bigfloat.Sqrt(z)
+			for _, guess := range []*big.Float{
+				bigfloat.SqrtPrec(z, prec/2+1, big.ToNearestEven),             // fewer bits
+				bigfloat.SqrtPrec(z, 2*prec, big.ToNearestEven),               // more bits than needed
+				bigfloat.SqrtPrec(z, 53, big.ToZero),                          // float64 accuracy
+				new(big.Float).SetMantExp(big.NewFloat(1), want.MantExp(nil)), // poor
+			} {
+				if x := bigfloat.SqrtGuess(z, guess); x.Cmp(want) != 0 || x.Prec() != prec {
+					t.Errorf("prec = %d, SqrtGuess(%g, %g) =\ngot  %g (prec = %d);\nwant %g", prec, z, guess, x, x.Prec(), want)
+				}
+			}
+		}
+
+		// exact roots
+		z := big.NewFloat(2.25).SetPrec(prec).SetMode(big.ToZero)
+		if x := bigfloat.SqrtGuess(z, big.NewFloat(1.5)); x.Cmp(big.NewFloat(1.5)) != 0 || x.Prec() != prec || x.Mode() != big.ToZero {
+			t.Errorf("prec = %d, SqrtGuess(2.25, 1.5) = %g (prec = %d, %s); want 1.5", prec, x, x.Prec(), x.Mode())
+		}
+	}
+}
+
+func TestSqrtGuessPanics(t *testing.T) {
+	for _, test := range []struct {
+		z, guess float64
+	}{
+		{-1, 1},
+		{2, 0},
+		{2, -1},
+		{2, math.Inf(+1)},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("SqrtGuess(%g, %g) did not panic", test.z, test.guess)
+				}
+			}()
+			bigfloat.SqrtGuess(big.NewFloat(test.z), big.NewFloat(test.guess))
+		}()
+	}
+}
+
+func TestSqrtPrec(t *testing.T) {
+	for _, z := range []string{"2", "3", "4", "5", "0.1", "1e100"} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 500, 1000} {
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(z, 10)
+			for _, mode := range []big.RoundingMode{
+				big.ToNearestEven, big.ToNearestAway, big.ToZero,
+				big.AwayFromZero, big.ToNegativeInf, big.ToPositiveInf,
+			} {
+				want := bigfloat.Sqrt(new(big.Float).Copy(x).SetMode(mode))
+				got := bigfloat.SqrtPrec(x, prec, mode)
+				if got.Cmp(want) != 0 || got.Prec() != prec || got.Mode() != mode {
+					t.Errorf("prec = %d, SqrtPrec(%v, %s) =\ngot  %g;\nwant %g", prec, z, mode, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestSqrtPrecMixed(t *testing.T) {
+	sqrt2 := "1.4142135623730950488016887242096980785696718753769480731766797379907324784621070388503875343276415727350138462309122970249248360558507372126441214970999358314132226659275055927557999505011527820605714701095599716059702745345968620147285174186408891986095523292304843087143214508397626036279952514079896872533965463318088296406206152583523950547457503"
+	for _, zPrec := range []uint{24, 53, 100, 1000} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(sqrt2, 10)
+
+			z := big.NewFloat(2).SetPrec(zPrec)
+			x := bigfloat.SqrtPrec(z, prec, big.ToNearestEven)
+
+			if x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("SqrtPrec(2 (prec = %d), %d) =\ngot  %g;\nwant %g", zPrec, prec, x, want)
+			}
+		}
+	}
+}
+
+// z has many more bits than the result, and they all affect the
+// rounding.
+func TestSqrtPrecFewerBits(t *testing.T) {
+	rnd := rand.New(rand.NewSource(16))
+	for i := 0; i < 200; i++ {
+		z := big.NewFloat(rnd.Float64() + 0.5).SetPrec(1000)
+		low := big.NewFloat(rnd.Float64()).SetPrec(1000)
+		z.Add(z, low.SetMantExp(low, -60-rnd.Intn(900)))
+		for _, prec := range []uint{24, 53, 64, 100, 500} {
+			for _, mode := range []big.RoundingMode{
+				big.ToNearestEven, big.ToNearestAway, big.ToZero,
+				big.AwayFromZero, big.ToNegativeInf, big.ToPositiveInf,
+			} {
+				want := bigfloat.Sqrt(new(big.Float).SetPrec(2000).Set(z))
+				want.SetMode(mode).SetPrec(prec)
+				if x := bigfloat.SqrtPrec(z, prec, mode); x.Cmp(want) != 0 {
+					t.Errorf("prec = %d, SqrtPrec(%s, %s) =\ngot  %s;\nwant %s", prec, z.Text('p', 0), mode, x.Text('p', 0), want.Text('p', 0))
+				}
+			}
+		}
+	}
+}
+
+func TestSqrtPrecZero(t *testing.T) {
+	for _, f := range []float64{
+		+0.0,
+		2.0,
+		math.Inf(+1),
+	} {
+		z := big.NewFloat(f).SetPrec(100)
+		x := bigfloat.SqrtPrec(z, 0, big.ToNearestEven)
+		if want := bigfloat.Sqrt(z); x.Cmp(want) != 0 || x.Prec() != 100 {
+			t.Errorf("SqrtPrec(%g, 0) = %g (prec = %d); want %g (prec = 100)", z, x, x.Prec(), want)
+		}
+	}
+}
+
+// countCtx is a context that is never done for the first n calls to
+// Err, and is cancelled afterwards.
+type countCtx struct {
+	context.Context
+	n int
+}
+
+func (c *countCtx) Err() error {
+	if c.n > 0 {
+		c.n--
+		return nil
+	}
+	return context.Canceled
+}
+
+func TestSqrtContext(t *testing.T) {
+	z := big.NewFloat(2).SetPrec(1000)
+
+	x, err := bigfloat.SqrtContext(context.Background(), z)
+	if want := bigfloat.Sqrt(z); err != nil || x.Cmp(want) != 0 {
+		t.Errorf("SqrtContext(2) = %g, %v; want %g, nil", x, err, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if x, err := bigfloat.SqrtContext(ctx, z); err != context.Canceled {
+		t.Errorf("SqrtContext(cancelled, 2) = %g, %v; want nil, %v", x, err, context.Canceled)
+	}
+
+	ctx = &countCtx{context.Background(), 1}
+	if x, err := bigfloat.SqrtContext(ctx, z); err != context.Canceled {
+		t.Errorf("SqrtContext(cancelled after 1 iteration, 2) = %g, %v; want nil, %v", x, err, context.Canceled)
+	}
+
+	if _, err := bigfloat.SqrtContext(context.Background(), big.NewFloat(-1)); err != bigfloat.ErrNegative {
+		t.Errorf("SqrtContext(-1) returned error %v; want %v", err, bigfloat.ErrNegative)
+	}
+}
+
+// Starting from a 53 bits guess, sqrtDirect iterates until it has
+// 2·prec bits and sqrtInverse until it has 2·(prec+32), so at the
+// precisions below the two paths check the context a different number
+// of times.
+func TestSetSqrtThreshold(t *testing.T) {
+	defer bigfloat.SetSqrtThreshold(bigfloat.SetSqrtThreshold(bigfloat.DefaultSqrtThreshold))
+
+	for _, test := range []struct {
+		prec            uint
+		direct, inverse int
+	}{
+		{100, 2, 3},
+		{200, 3, 4},
+	} {
+		z := big.NewFloat(2).SetPrec(test.prec)
+		count := func() int {
+			ctx := &countCtx{context.Background(), 1000}
+			if _, err := bigfloat.SqrtContext(ctx, z); err != nil {
+				t.Fatalf("SqrtContext(2) returned error %v", err)
+			}
+			return 1000 - ctx.n
+		}
+
+		bigfloat.SetSqrtThreshold(test.prec)
+		if n := count(); n != test.direct {
+			t.Errorf("prec = %d, threshold = %d: Sqrt did %d iterations; want %d (direct)", test.prec, test.prec, n, test.direct)
+		}
+		d := bigfloat.Sqrt(z)
+
+		bigfloat.SetSqrtThreshold(test.prec - 1)
+		if n := count(); n != test.inverse {
+			t.Errorf("prec = %d, threshold = %d: Sqrt did %d iterations; want %d (inverse)", test.prec, test.prec-1, n, test.inverse)
+		}
+		i := bigfloat.Sqrt(z)
+
+		if d.Cmp(i) != 0 {
+			t.Errorf("prec = %d, Sqrt(2) =\ndirect  %g;\ninverse %g", test.prec, d, i)
+		}
+	}
+
+	if old := bigfloat.SetSqrtThreshold(bigfloat.DefaultSqrtThreshold); old != 199 {
+		t.Errorf("SetSqrtThreshold returned %d; want 199", old)
+	}
+}
+
+func TestRsqrt(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"4", "0.5"},
+		{"0.25", "2"},
+		{"1024", "0.03125"},
+		{"2", "0.70710678118654752440084436210484903928483593768847403658833986899536623923105351942519376716382078636750692311545614851246241802792536860632206074854996791570661133296375279637789997525057639103028573505477998580298513726729843100736425870932044459930477616461524215435716072541988130181399762570399484362669827316590441482031030762917619752737287514"},
+		{"1e100", "1e-50"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Rsqrt(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Rsqrt(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestRsqrtTimesSqrt(t *testing.T) {
+	for _, f := range []float64{2, 3, 0.1, 1e10, 1e-300} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000, 5000} {
+			z := big.NewFloat(f).SetPrec(prec)
+			x := new(big.Float).Mul(bigfloat.Rsqrt(z), bigfloat.Sqrt(z))
+
+			// both factors are within an ulp of the exact value
+			d := new(big.Float).Sub(x, big.NewFloat(1))
+			if d.Sign() != 0 && d.MantExp(nil) > -int(prec)+2 {
+				t.Errorf("prec = %d, Rsqrt(%g)·Sqrt(%g) = %g", prec, f, f, x)
+			}
+		}
+	}
+}
+
+func TestRsqrtSpecialValues(t *testing.T) {
+	for _, f := range []float64{
+		+0.0,
+		-0.0,
+		math.Inf(+1),
+	} {
+		z := big.NewFloat(f).SetPrec(53)
+		x64, acc := bigfloat.Rsqrt(z).Float64()
+		want := 1 / math.Sqrt(f)
+		if x64 != want || math.Signbit(x64) != math.Signbit(want) || acc != big.Exact {
+			t.Errorf("Rsqrt(%g) =\n got %g (%s);\nwant %g (Exact)", f, x64, acc, want)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Rsqrt(-1) did not panic")
+		}
+	}()
+	bigfloat.Rsqrt(big.NewFloat(-1))
+}
+
 // ---------- Benchmarks ----------
 
 func BenchmarkSqrt(b *testing.B) {
@@ -128,3 +660,28 @@ func BenchmarkSqrt(b *testing.B) {
 		})
 	}
 }
+
+// Run with -bench SqrtThreshold to find the precision at which the
+// inverse iteration becomes faster than the direct one.
+func BenchmarkSqrtThreshold(b *testing.B) {
+	defer bigfloat.SetSqrtThreshold(bigfloat.SetSqrtThreshold(bigfloat.DefaultSqrtThreshold))
+
+	for _, prec := range []uint{64, 96, 128, 192, 256, 384, 512, 1024} {
+		z := big.NewFloat(2).SetPrec(prec)
+		for _, test := range []struct {
+			name      string
+			threshold uint
+		}{
+			{"direct", ^uint(0)},
+			{"inverse", 0},
+		} {
+			b.Run(fmt.Sprintf("%v/%s", prec, test.name), func(b *testing.B) {
+				bigfloat.SetSqrtThreshold(test.threshold)
+				b.ReportAllocs()
+				for n := 0; n < b.N; n++ {
+					bigfloat.Sqrt(z)
+				}
+			})
+		}
+	}
+}
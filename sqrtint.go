@@ -0,0 +1,43 @@
+package bigfloat
+
+import "math/big"
+
+// SqrtInt returns the integer floor of the square root of z, as a
+// big.Int. The result is exact: SqrtInt(z)² <= z < (SqrtInt(z)+1)²
+// holds for any z, however close z is to a perfect square. The
+// function panics if z is negative or +Inf, and returns 0 when z =
+// ±0.
+func SqrtInt(z *big.Float) *big.Int {
+
+	// panic on negative z
+	if z.Sign() == -1 {
+		panic("SqrtInt: argument is negative")
+	}
+
+	// panic on +Inf, since a big.Int can't hold it
+	if z.IsInf() {
+		panic("SqrtInt: argument is +Inf")
+	}
+
+	// k² <= z iff k² <= ⌊z⌋ for integer k, so ⌊√z⌋ = ⌊√⌊z⌋⌋, and the
+	// integer square root of ⌊z⌋ is exact. Rounding a float root
+	// instead could give the wrong floor when √z is exactly an integer
+	// or within the rounding error of one.
+	n, _ := z.Int(nil)
+	return n.Sqrt(n)
+}
+
+// IsSquare reports whether z holds a non-negative integer that is a
+// perfect square. It returns false, rather than panicking, when z is
+// negative, not an integer, or ±Inf. The check is exact for any
+// integer value of z.
+func IsSquare(z *big.Float) bool {
+	if z.Sign() == -1 || z.IsInf() || !z.IsInt() {
+		return false
+	}
+
+	// z is a square iff ⌊√z⌋² = z
+	n, _ := z.Int(nil)
+	r := new(big.Int).Sqrt(n)
+	return r.Mul(r, r).Cmp(n) == 0
+}
@@ -0,0 +1,158 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestSqrtInt(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"0", "0"},
+		{"0.5", "0"},
+		{"1", "1"},
+		{"3.99", "1"},
+		{"4", "2"},
+		{"4.01", "2"},
+		{"1e100", "1e50"},
+		{"1e200", "1e100"},
+		{"1606938044258990275541962092341162602522202993782792835301376", "1267650600228229401496703205376"}, // 2**200
+		{"2e100", "141421356237309504880168872420969807856967187537694"},
+	} {
+		for _, prec := range []uint{700, 1000} {
+			z, _, err := big.ParseFloat(test.z, 10, prec, big.ToNearestEven)
+			if err != nil {
+				t.Fatalf("ParseFloat(%s) returned error %v", test.z, err)
+			}
+			want, _, _ := big.ParseFloat(test.want, 10, prec, big.ToNearestEven)
+			wantInt, _ := want.Int(nil)
+
+			if x := bigfloat.SqrtInt(z); x.Cmp(wantInt) != 0 {
+				t.Errorf("prec = %d, SqrtInt(%s) = %s; want %s", prec, test.z, x, wantInt)
+			}
+		}
+	}
+}
+
+// Perfect squares k², and values just above and below them.
+func TestSqrtIntPerfectSquares(t *testing.T) {
+	one := big.NewInt(1)
+	for _, k := range []*big.Int{
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(50), nil),
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(150), nil),
+		new(big.Int).Lsh(one, 500),
+		new(big.Int).Sub(new(big.Int).Lsh(one, 500), one),
+		new(big.Int).Exp(big.NewInt(3), big.NewInt(300), nil),
+	} {
+		n := new(big.Int).Mul(k, k)
+		prec := uint(n.BitLen() + 10)
+		kMinus1 := new(big.Int).Sub(k, one)
+
+		for _, test := range []struct {
+			z    *big.Float
+			want *big.Int
+		}{
+			{new(big.Float).SetPrec(prec).SetInt(n), k},
+			{new(big.Float).SetPrec(prec).SetInt(new(big.Int).Add(n, one)), k},
+			{new(big.Float).SetPrec(prec).SetInt(new(big.Int).Sub(n, one)), kMinus1},
+		} {
+			if x := bigfloat.SqrtInt(test.z); x.Cmp(test.want) != 0 {
+				t.Errorf("SqrtInt(%s) = %s; want %s", test.z.Text('f', 0), x, test.want)
+			}
+		}
+
+		// just below k², by less than the rounding error of Sqrt at
+		// the precision of n
+		z := new(big.Float).SetPrec(2 * prec).SetInt(n)
+		z.Sub(z, new(big.Float).SetMantExp(big.NewFloat(1), -int(prec)))
+		if x := bigfloat.SqrtInt(z); x.Cmp(kMinus1) != 0 {
+			t.Errorf("SqrtInt(%s - 2**-%d) = %s; want %s", n, prec, x, kMinus1)
+		}
+	}
+}
+
+func TestSqrtIntFloat64(t *testing.T) {
+	for i := 0; i < 1e4; i++ {
+		f := math.Floor(rand.Float64() * 1e12)
+		want := math.Floor(math.Sqrt(f))
+		if x := bigfloat.SqrtInt(big.NewFloat(f)); x.Int64() != int64(want) {
+			t.Errorf("SqrtInt(%g) = %s; want %g", f, x, want)
+		}
+	}
+}
+
+func TestSqrtIntPanics(t *testing.T) {
+	for _, f := range []float64{-1, math.Inf(+1)} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("SqrtInt(%g) did not panic", f)
+				}
+			}()
+			bigfloat.SqrtInt(big.NewFloat(f))
+		}()
+	}
+}
+
+func TestIsSquare(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want bool
+	}{
+		{"0", true},
+		{"-0", true},
+		{"1", true},
+		{"2", false},
+		{"4", true},
+		{"4.5", false},
+		{"0.25", false},
+		{"-4", false},
+		{"+Inf", false},
+		{"1e100", true},
+		{"1e101", false},
+	} {
+		for _, prec := range []uint{400, 1000} {
+			z, _, err := big.ParseFloat(test.z, 10, prec, big.ToNearestEven)
+			if err != nil {
+				t.Fatalf("ParseFloat(%s) returned error %v", test.z, err)
+			}
+			if got := bigfloat.IsSquare(z); got != test.want {
+				t.Errorf("prec = %d, IsSquare(%s) = %v; want %v", prec, test.z, got, test.want)
+			}
+		}
+	}
+}
+
+// Perfect squares k², and the integers next to them.
+func TestIsSquarePerfectSquares(t *testing.T) {
+	one := big.NewInt(1)
+	for _, k := range []*big.Int{
+		big.NewInt(3),
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(50), nil),
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(150), nil),
+		new(big.Int).Lsh(one, 500),
+		new(big.Int).Exp(big.NewInt(3), big.NewInt(300), nil),
+	} {
+		n := new(big.Int).Mul(k, k)
+		prec := uint(n.BitLen() + 10)
+		for _, test := range []struct {
+			n    *big.Int
+			want bool
+		}{
+			{n, true},
+			{new(big.Int).Add(n, one), false},
+			{new(big.Int).Sub(n, one), false},
+		} {
+			z := new(big.Float).SetPrec(prec).SetInt(test.n)
+			if got := bigfloat.IsSquare(z); got != test.want {
+				t.Errorf("IsSquare(%s) = %v; want %v", test.n, got, test.want)
+			}
+		}
+	}
+}
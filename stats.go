@@ -0,0 +1,58 @@
+package bigfloat
+
+import (
+	"context"
+	"math/big"
+)
+
+// Path is the algorithm used to compute a square root.
+type Path int
+
+const (
+	PathNone          Path = iota // no iteration, z is ±0 or +Inf
+	PathDirect                    // Newton's method on t² - z = 0
+	PathInverse                   // Newton's method on 1/t² - z = 0
+	PathInverseHalley             // Halley's method on 1/t² - z = 0
+)
+
+func (p Path) String() string {
+	switch p {
+	case PathNone:
+		return "none"
+	case PathDirect:
+		return "direct"
+	case PathInverse:
+		return "inverse"
+	case PathInverseHalley:
+		return "inverse-halley"
+	}
+	return "unknown"
+}
+
+// Stats describes how a square root was computed.
+type Stats struct {
+	Iterations int     // number of iterations of the solver
+	Path       Path    // algorithm used
+	GuessError float64 // relative error of the initial guess
+}
+
+// SqrtStats is like Sqrt, but it also returns a description of how
+// the root was computed: the number of iterations, which of the
+// algorithms was used, and the relative error of the starting point of
+// the iteration. The result is the same as the one of Sqrt. The
+// function panics if z is negative.
+func SqrtStats(z *big.Float) (*big.Float, Stats) {
+
+	// panic on negative z
+	if z.Sign() == -1 {
+		panic("SqrtStats: argument is negative")
+	}
+
+	// √±0 = ±0, √+Inf = +Inf
+	if z.Sign() == 0 || z.IsInf() {
+		return Sqrt(z), Stats{Path: PathNone}
+	}
+
+	x, stats, _ := sqrtContext(context.Background(), new(big.Float), z, nil, 0)
+	return sqrtRound(x, z, z.Mode()), stats
+}
@@ -0,0 +1,66 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestSqrtStats(t *testing.T) {
+	last := make(map[bigfloat.Path]int)
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000, 2000, 5000, 10000} {
+		z := big.NewFloat(2).SetPrec(prec)
+		x, stats := bigfloat.SqrtStats(z)
+		if want := bigfloat.Sqrt(z); x.Cmp(want) != 0 || x.Prec() != prec {
+			t.Errorf("prec = %d, SqrtStats(2) =\ngot  %g;\nwant %g", prec, x, want)
+		}
+
+		// Halley's method needs fewer iterations than Newton's, so only
+		// compare iteration counts on the same path
+		if n, ok := last[stats.Path]; ok && stats.Iterations < n {
+			t.Errorf("prec = %d, SqrtStats(2) took %d iterations on the %s path; want at least %d", prec, stats.Iterations, stats.Path, n)
+		}
+		last[stats.Path] = stats.Iterations
+
+		// √2 is irrational, so the float64 guess can't be exact
+		if stats.GuessError <= 0 || stats.GuessError > 0x1p-52 {
+			t.Errorf("prec = %d, SqrtStats(2) guess error = %g; want in (0, 2**-52]", prec, stats.GuessError)
+		}
+	}
+
+	for _, p := range []bigfloat.Path{bigfloat.PathDirect, bigfloat.PathInverse, bigfloat.PathInverseHalley} {
+		if _, ok := last[p]; !ok {
+			t.Errorf("SqrtStats never used the %s path", p)
+		}
+	}
+
+	_, lo := bigfloat.SqrtStats(big.NewFloat(2).SetPrec(53))
+	_, hi := bigfloat.SqrtStats(big.NewFloat(2).SetPrec(10000))
+	if lo.Iterations >= hi.Iterations {
+		t.Errorf("SqrtStats took %d iterations at prec 53 and %d at prec 10000", lo.Iterations, hi.Iterations)
+	}
+}
+
+func TestSqrtStatsSpecialValues(t *testing.T) {
+	for _, f := range []float64{
+		+0.0,
+		-0.0,
+		math.Inf(+1),
+	} {
+		x, stats := bigfloat.SqrtStats(big.NewFloat(f))
+		x64, _ := x.Float64()
+		want := math.Sqrt(f)
+		if x64 != want || math.Signbit(x64) != math.Signbit(want) || stats != (bigfloat.Stats{Path: bigfloat.PathNone}) {
+			t.Errorf("SqrtStats(%g) = %g, %+v; want %g, {Path: none}", f, x64, stats, want)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("SqrtStats(-1) did not panic")
+		}
+	}()
+	bigfloat.SqrtStats(big.NewFloat(-1))
+}
@@ -0,0 +1,210 @@
+package bigfloat
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// Sum returns a big.Float representation of the sum of the elements
+// of vals. Precision is the largest of the precisions of the
+// elements. The sum is computed with compensated (Neumaier)
+// summation, which keeps track of the low-order bits lost by each
+// addition, so the result is accurate even when the elements have
+// very different magnitudes or cancel each other. The function
+// panics if vals contains both +Inf and -Inf, and returns 0 if vals is
+// empty.
+func Sum(vals []*big.Float) *big.Float {
+
+	if len(vals) == 0 {
+		return new(big.Float)
+	}
+
+	prec, posInf, negInf := scanSlice(vals)
+	if posInf && negInf {
+		panic("Sum: sum of +Inf and -Inf")
+	}
+	if posInf || negInf {
+		return new(big.Float).SetPrec(prec).SetInf(negInf)
+	}
+
+	return compensatedSum(vals, prec)
+}
+
+// DotProduct returns a big.Float representation of the dot product
+// of a and b, the sum of the products a[i]·b[i]. Precision is the
+// largest of the precisions of the elements. The products are computed
+// exactly and added as in Sum, so that, unlike in a plain loop of Mul
+// and Add, the result is accurate even when the products cancel each
+// other. The function panics if a and b have different lengths, if a
+// product is 0·Inf, or if the products include both +Inf and -Inf, and
+// returns 0 if a and b are empty.
+func DotProduct(a, b []*big.Float) *big.Float {
+
+	if len(a) != len(b) {
+		panic("DotProduct: slices have different lengths")
+	}
+	if len(a) == 0 {
+		return new(big.Float)
+	}
+
+	var prec uint
+	posInf, negInf := false, false
+	prods := make([]*big.Float, len(a))
+	for i := range a {
+		x, y := a[i], b[i]
+		if (x.IsInf() && y.Sign() == 0) || (x.Sign() == 0 && y.IsInf()) {
+			panic("DotProduct: product of 0 and Inf")
+		}
+
+		// the product of a p-bit and a q-bit mantissa fits in p+q bits
+		prods[i] = new(big.Float).SetPrec(x.Prec()+y.Prec()).Mul(x, y)
+		if prods[i].IsInf() {
+			if prods[i].Sign() > 0 {
+				posInf = true
+			} else {
+				negInf = true
+			}
+		}
+		if x.Prec() > prec {
+			prec = x.Prec()
+		}
+		if y.Prec() > prec {
+			prec = y.Prec()
+		}
+	}
+
+	if posInf && negInf {
+		panic("DotProduct: sum of +Inf and -Inf")
+	}
+	if posInf || negInf {
+		return new(big.Float).SetPrec(prec).SetInf(negInf)
+	}
+
+	return compensatedSum(prods, prec)
+}
+
+// Product returns a big.Float representation of the product of the
+// elements of vals. Precision is the largest of the precisions of the
+// elements. The mantissas and the exponents of the partial products
+// are kept apart, so the partial products never overflow or underflow,
+// and the result is only ±Inf or ±0 when the full product is out of
+// the big.Float exponent range. The mantissas are multiplied with
+// 64 + log2(len(vals)) guard digits. The function panics if vals
+// contains both 0 and ±Inf, and returns 1 if vals is empty.
+func Product(vals []*big.Float) *big.Float {
+
+	if len(vals) == 0 {
+		return new(big.Float).SetInt64(1)
+	}
+
+	prec, posInf, negInf := scanSlice(vals)
+	zero, neg := false, false
+	for _, x := range vals {
+		if x.Sign() == 0 {
+			zero = true
+		}
+		if x.Signbit() {
+			neg = !neg
+		}
+	}
+
+	// Product(±0, ±Inf) = NaN, Product(±Inf...) = ±Inf, and
+	// Product(±0...) = ±0
+	switch {
+	case zero && (posInf || negInf):
+		panic("Product: product of 0 and Inf")
+	case posInf || negInf:
+		return new(big.Float).SetPrec(prec).SetInf(neg)
+	case zero:
+		z := new(big.Float).SetPrec(prec)
+		if neg {
+			z.Neg(z)
+		}
+		return z
+	}
+
+	// each of the multiplications carries a rounding error, so add
+	// log2(n) more guard digits
+	p := prec + 64 + uint(bits.Len(uint(len(vals)))) // guard digits
+
+	// m·2**e is the partial product, with 0.5 <= |m| < 1
+	m := big.NewFloat(1).SetPrec(p)
+	e := int64(m.MantExp(m))
+	mant := new(big.Float)
+	for _, x := range vals {
+		e += int64(x.MantExp(mant))
+		m.Mul(m, mant)
+		e += int64(m.MantExp(m))
+	}
+
+	// SetMantExp rounds to ±Inf or ±0 out of the exponent range; clamp
+	// e first, so that it fits in an int
+	if e > big.MaxExp {
+		e = big.MaxExp + 1
+	} else if e < big.MinExp-int64(p) {
+		e = big.MinExp - int64(p)
+	}
+	return m.SetMantExp(m, int(e)).SetPrec(prec)
+}
+
+// scanSlice returns the largest of the precisions of the elements of
+// vals, and whether vals contains +Inf and -Inf.
+func scanSlice(vals []*big.Float) (prec uint, posInf, negInf bool) {
+	for _, x := range vals {
+		if x.IsInf() {
+			if x.Sign() > 0 {
+				posInf = true
+			} else {
+				negInf = true
+			}
+		}
+		if x.Prec() > prec {
+			prec = x.Prec()
+		}
+	}
+	return
+}
+
+// compensatedSum returns the sum of the finite elements of vals,
+// rounded to prec bits, computed with Neumaier summation. The elements
+// can have more than prec bits.
+func compensatedSum(vals []*big.Float, prec uint) *big.Float {
+
+	// the rounding errors are only exact if the elements fit in the
+	// working precision
+	p := prec
+	for _, x := range vals {
+		if x.Prec() > p {
+			p = x.Prec()
+		}
+	}
+	p += 64 // guard digits
+
+	// Start from the first element, so that the sign of a zero sum is
+	// the one of the IEEE rules.
+	s := new(big.Float).SetPrec(p).Set(vals[0])
+	c := new(big.Float).SetPrec(p) // compensation
+	t := new(big.Float).SetPrec(p)
+	e := new(big.Float).SetPrec(p)
+	for _, x := range vals[1:] {
+		t.Add(s, x)
+
+		// the rounding error of s + x is exactly (s - t) + x if the
+		// exponent of s is not smaller than the one of x, and (x - t) + s
+		// otherwise
+		if s.MantExp(nil) >= x.MantExp(nil) {
+			e.Sub(s, t)
+			e.Add(e, x)
+		} else {
+			e.Sub(x, t)
+			e.Add(e, s)
+		}
+		c.Add(c, e)
+		s.Set(t)
+	}
+
+	if c.Sign() == 0 {
+		return s.SetPrec(prec)
+	}
+	return s.Add(s, c).SetPrec(prec)
+}
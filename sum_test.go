@@ -0,0 +1,314 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+// naiveSum adds the elements of vals in order, with the largest of
+// their precisions.
+func naiveSum(vals []*big.Float) *big.Float {
+	var prec uint
+	for _, x := range vals {
+		if x.Prec() > prec {
+			prec = x.Prec()
+		}
+	}
+	s := new(big.Float).SetPrec(prec)
+	for _, x := range vals {
+		s.Add(s, x)
+	}
+	return s
+}
+
+func TestSum(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+
+		// 1e400 cancels out, and the 1s are lost by naive summation
+		big1e400 := new(big.Float).SetPrec(prec)
+		big1e400.Parse("1e400", 10)
+		vals := []*big.Float{
+			big.NewFloat(1).SetPrec(prec),
+			big1e400,
+			big.NewFloat(1).SetPrec(prec),
+			new(big.Float).Neg(big1e400),
+		}
+		if naive := naiveSum(vals); naive.Sign() != 0 {
+			t.Fatalf("prec = %d, naive sum = %g; want 0", prec, naive)
+		}
+		if x := bigfloat.Sum(vals); x.Cmp(big.NewFloat(2)) != 0 || x.Prec() != prec {
+			t.Errorf("prec = %d, Sum(1, 1e400, 1, -1e400) = %g (prec = %d); want 2", prec, x, x.Prec())
+		}
+
+		// 1 + 2**-(prec+5) + ... + 2**-(prec+5), with 1024 small terms
+		// that naive summation drops one at a time
+		tiny := big.NewFloat(1).SetPrec(prec)
+		tiny.SetMantExp(tiny, -int(prec)-5)
+		vals = []*big.Float{big.NewFloat(1).SetPrec(prec)}
+		for i := 0; i < 1024; i++ {
+			vals = append(vals, tiny)
+		}
+		want := big.NewFloat(1).SetPrec(prec)
+		want.SetMantExp(want, -int(prec)+5)
+		want.Add(want, big.NewFloat(1))
+		if naive := naiveSum(vals); naive.Cmp(big.NewFloat(1)) != 0 {
+			t.Fatalf("prec = %d, naive sum = %g; want 1", prec, naive)
+		}
+		if x := bigfloat.Sum(vals); x.Cmp(want) != 0 {
+			t.Errorf("prec = %d, Sum(1, 2**-%d, ...) =\ngot  %s;\nwant %s", prec, prec+5, x.Text('p', 0), want.Text('p', 0))
+		}
+	}
+}
+
+// Sums of values with many different magnitudes, checked against the
+// exact sum.
+func TestSumMixedMagnitudes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	for _, prec := range []uint{24, 53, 100, 500, 1000} {
+		for i := 0; i < 50; i++ {
+			var vals []*big.Float
+			exact := new(big.Rat)
+			for j := 0; j < 100; j++ {
+				x := big.NewFloat(rnd.NormFloat64()).SetPrec(prec)
+				x.SetMantExp(x, rnd.Intn(400)-200)
+				vals = append(vals, x)
+				r, _ := x.Rat(nil)
+				exact.Add(exact, r)
+			}
+
+			want := new(big.Float).SetPrec(prec).SetRat(exact)
+			if x := bigfloat.Sum(vals); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Sum =\ngot  %s;\nwant %s", prec, x.Text('p', 0), want.Text('p', 0))
+			}
+		}
+	}
+}
+
+func TestSumSpecialValues(t *testing.T) {
+	if x := bigfloat.Sum(nil); x.Sign() != 0 {
+		t.Errorf("Sum(nil) = %g; want 0", x)
+	}
+
+	for _, test := range []struct {
+		vals []float64
+		want float64
+	}{
+		{[]float64{-0.0, -0.0}, -0.0},
+		{[]float64{-0.0, +0.0}, +0.0},
+		{[]float64{1, -1}, +0.0},
+		{[]float64{1, math.Inf(+1), -1e300}, math.Inf(+1)},
+		{[]float64{math.Inf(-1), math.Inf(-1)}, math.Inf(-1)},
+	} {
+		var vals []*big.Float
+		for _, f := range test.vals {
+			vals = append(vals, big.NewFloat(f).SetPrec(100))
+		}
+		x := bigfloat.Sum(vals)
+		x64, _ := x.Float64()
+		if x64 != test.want || math.Signbit(x64) != math.Signbit(test.want) || x.Prec() != 100 {
+			t.Errorf("Sum(%v) = %g (prec = %d); want %g (prec = 100)", test.vals, x64, x.Prec(), test.want)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Sum(+Inf, -Inf) did not panic")
+		}
+	}()
+	bigfloat.Sum([]*big.Float{big.NewFloat(math.Inf(+1)), big.NewFloat(math.Inf(-1))})
+}
+
+func TestDotProduct(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+
+		// u·2**prec, with u = 1/3, in a and b: the two large products
+		// cancel out, and a plain loop loses the 1 between them
+		u := new(big.Float).SetPrec(prec).Quo(big.NewFloat(1), big.NewFloat(3))
+		u.SetMantExp(u, int(prec))
+		one := big.NewFloat(1).SetPrec(prec)
+		a := []*big.Float{u, one, u}
+		b := []*big.Float{u, one, new(big.Float).Neg(u)}
+
+		naive := new(big.Float).SetPrec(prec)
+		for i := range a {
+			naive.Add(naive, new(big.Float).SetPrec(prec).Mul(a[i], b[i]))
+		}
+		if naive.Cmp(one) == 0 {
+			t.Fatalf("prec = %d, naive dot product = %g; want it to differ from 1", prec, naive)
+		}
+		if x := bigfloat.DotProduct(a, b); x.Cmp(one) != 0 || x.Prec() != prec {
+			t.Errorf("prec = %d, DotProduct = %g (prec = %d); want 1", prec, x, x.Prec())
+		}
+	}
+}
+
+// Dot products of vectors with many different magnitudes, checked
+// against the exact value.
+func TestDotProductMixedMagnitudes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(5))
+	for _, prec := range []uint{24, 53, 100, 500, 1000} {
+		for i := 0; i < 50; i++ {
+			var a, b []*big.Float
+			exact := new(big.Rat)
+			for j := 0; j < 100; j++ {
+				x := big.NewFloat(rnd.NormFloat64()).SetPrec(prec)
+				x.SetMantExp(x, rnd.Intn(200)-100)
+				y := big.NewFloat(rnd.NormFloat64()).SetPrec(prec)
+				y.SetMantExp(y, rnd.Intn(200)-100)
+				a, b = append(a, x), append(b, y)
+				rx, _ := x.Rat(nil)
+				ry, _ := y.Rat(nil)
+				exact.Add(exact, rx.Mul(rx, ry))
+			}
+
+			want := new(big.Float).SetPrec(prec).SetRat(exact)
+			if x := bigfloat.DotProduct(a, b); x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, DotProduct =\ngot  %s;\nwant %s", prec, x.Text('p', 0), want.Text('p', 0))
+			}
+		}
+	}
+}
+
+func TestDotProductSpecialValues(t *testing.T) {
+	if x := bigfloat.DotProduct(nil, nil); x.Sign() != 0 {
+		t.Errorf("DotProduct(nil, nil) = %g; want 0", x)
+	}
+
+	inf := big.NewFloat(math.Inf(+1))
+	if x := bigfloat.DotProduct(
+		[]*big.Float{inf, big.NewFloat(1)},
+		[]*big.Float{big.NewFloat(-2), big.NewFloat(1e300)},
+	); !x.IsInf() || x.Sign() > 0 {
+		t.Errorf("DotProduct((+Inf, 1), (-2, 1e300)) = %g; want -Inf", x)
+	}
+
+	for _, test := range []struct {
+		a, b []*big.Float
+	}{
+		{[]*big.Float{big.NewFloat(1)}, nil},
+		{[]*big.Float{inf}, []*big.Float{big.NewFloat(0)}},
+		{[]*big.Float{inf, inf}, []*big.Float{big.NewFloat(1), big.NewFloat(-1)}},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("DotProduct(%v, %v) did not panic", test.a, test.b)
+				}
+			}()
+			bigfloat.DotProduct(test.a, test.b)
+		}()
+	}
+}
+
+func TestProduct(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+
+		// 1000 values of 1e300·(1 + i/1000): the product is far out of
+		// the float64 range, and a float64 loop overflows
+		var vals []*big.Float
+		exact := big.NewRat(1, 1)
+		naive := 1.0
+		for i := 0; i < 1000; i++ {
+			f := 1e300 * (1 + float64(i)/1000)
+			x := big.NewFloat(f).SetPrec(prec)
+			vals = append(vals, x)
+			r, _ := x.Rat(nil)
+			exact.Mul(exact, r)
+			naive *= f
+		}
+		if !math.IsInf(naive, +1) {
+			t.Fatalf("float64 product = %g; want +Inf", naive)
+		}
+		want := new(big.Float).SetPrec(prec).SetRat(exact)
+		if x := bigfloat.Product(vals); x.Cmp(want) != 0 || x.Prec() != prec {
+			t.Errorf("prec = %d, Product =\ngot  %g (prec = %d);\nwant %g", prec, x, x.Prec(), want)
+		}
+
+		// 3·2**(MaxExp-2), 3·2**(MaxExp-2), -2**(-MaxExp), 2**(-MaxExp):
+		// the partial products overflow in a big.Float loop, but the
+		// product is -9/16
+		big3 := big.NewFloat(3).SetPrec(prec)
+		big3.SetMantExp(big3, big.MaxExp-2)
+		small := big.NewFloat(1).SetPrec(prec)
+		small.SetMantExp(small, -big.MaxExp)
+		vals = []*big.Float{big3, big3, new(big.Float).Neg(small), small}
+		if naive := naiveProduct(vals); !naive.IsInf() {
+			t.Fatalf("prec = %d, naive product = %g; want -Inf", prec, naive)
+		}
+		if x := bigfloat.Product(vals); x.Cmp(big.NewFloat(-9./16)) != 0 {
+			t.Errorf("prec = %d, Product(3·2**(MaxExp-2), ...) = %g; want -0.5625", prec, x)
+		}
+	}
+}
+
+// naiveProduct multiplies the elements of vals in order, with the
+// largest of their precisions.
+func naiveProduct(vals []*big.Float) *big.Float {
+	var prec uint
+	for _, x := range vals {
+		if x.Prec() > prec {
+			prec = x.Prec()
+		}
+	}
+	p := big.NewFloat(1).SetPrec(prec)
+	for _, x := range vals {
+		p.Mul(p, x)
+	}
+	return p
+}
+
+func TestProductSpecialValues(t *testing.T) {
+	if x := bigfloat.Product(nil); x.Cmp(big.NewFloat(1)) != 0 {
+		t.Errorf("Product(nil) = %g; want 1", x)
+	}
+
+	huge := big.NewFloat(1)
+	huge.SetMantExp(huge, big.MaxExp-1)
+	tiny := big.NewFloat(1)
+	tiny.SetMantExp(tiny, big.MinExp)
+	for _, test := range []struct {
+		vals []*big.Float
+		want float64
+	}{
+		{[]*big.Float{big.NewFloat(-2), big.NewFloat(0)}, math.Copysign(0, -1)},
+		{[]*big.Float{big.NewFloat(-2), big.NewFloat(math.Copysign(0, -1))}, +0.0},
+		{[]*big.Float{big.NewFloat(-2), big.NewFloat(math.Inf(+1))}, math.Inf(-1)},
+		{[]*big.Float{huge, huge, huge}, math.Inf(+1)},
+		{[]*big.Float{tiny, tiny, new(big.Float).Neg(tiny)}, math.Copysign(0, -1)},
+	} {
+		x := bigfloat.Product(test.vals)
+		x64, _ := x.Float64()
+		if x64 != test.want || math.Signbit(x64) != math.Signbit(test.want) {
+			t.Errorf("Product(%v) = %g; want %g", test.vals, x, test.want)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Product(0, +Inf) did not panic")
+		}
+	}()
+	bigfloat.Product([]*big.Float{big.NewFloat(0), big.NewFloat(math.Inf(+1))})
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkSum(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		vals := make([]*big.Float, 1000)
+		for i := range vals {
+			vals[i] = big.NewFloat(1 / float64(i+1)).SetPrec(prec)
+		}
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Sum(vals)
+			}
+		})
+	}
+}
@@ -0,0 +1,389 @@
+package bigfloat
+
+import "math/big"
+
+// Sin returns a big.Float representation of the sine of z (in
+// radians). Precision is the same as the one of the argument. The
+// function panics if z is ±Inf, and returns ±0 when z = ±0.
+func Sin(z *big.Float) *big.Float {
+
+	// panic on ±Inf
+	if z.IsInf() {
+		panic("Sin: argument is infinite")
+	}
+
+	// Sin(±0) = ±0
+	if z.Sign() == 0 {
+		return new(big.Float).Copy(z)
+	}
+
+	prec := z.Prec() + 64 // guard digits
+
+	// sin(r + q·π/2) is
+	//    sin(r)    if q = 0
+	//    cos(r)    if q = 1
+	//   -sin(r)    if q = 2
+	//   -cos(r)    if q = 3
+	r, q := reduce(z, prec)
+	var x *big.Float
+	switch q {
+	case 0:
+		x = sinTaylor(r)
+	case 1:
+		x = cosTaylor(r)
+	case 2:
+		x = sinTaylor(r)
+		x.Neg(x)
+	case 3:
+		x = cosTaylor(r)
+		x.Neg(x)
+	}
+
+	return x.SetPrec(z.Prec())
+}
+
+// Cos returns a big.Float representation of the cosine of z (in
+// radians). Precision is the same as the one of the argument. The
+// function panics if z is ±Inf, and returns 1 when z = ±0.
+func Cos(z *big.Float) *big.Float {
+
+	// panic on ±Inf
+	if z.IsInf() {
+		panic("Cos: argument is infinite")
+	}
+
+	// Cos(±0) = 1
+	if z.Sign() == 0 {
+		return big.NewFloat(1).SetPrec(z.Prec())
+	}
+
+	prec := z.Prec() + 64 // guard digits
+
+	// cos(r + q·π/2) is
+	//    cos(r)    if q = 0
+	//   -sin(r)    if q = 1
+	//   -cos(r)    if q = 2
+	//    sin(r)    if q = 3
+	r, q := reduce(z, prec)
+	var x *big.Float
+	switch q {
+	case 0:
+		x = cosTaylor(r)
+	case 1:
+		x = sinTaylor(r)
+		x.Neg(x)
+	case 2:
+		x = cosTaylor(r)
+		x.Neg(x)
+	case 3:
+		x = sinTaylor(r)
+	}
+
+	return x.SetPrec(z.Prec())
+}
+
+// SinCos returns big.Float representations of the sine and the
+// cosine of z (in radians), with the same precision as the argument.
+// The results are the same as the ones of Sin(z) and Cos(z), but the
+// argument reduction, which is the most expensive part of the
+// computation for large z, is only done once. The function panics if
+// z is ±Inf, and returns ±0 and 1 when z = ±0.
+func SinCos(z *big.Float) (sin, cos *big.Float) {
+
+	// panic on ±Inf
+	if z.IsInf() {
+		panic("SinCos: argument is infinite")
+	}
+
+	// SinCos(±0) = ±0, 1
+	if z.Sign() == 0 {
+		return new(big.Float).Copy(z), big.NewFloat(1).SetPrec(z.Prec())
+	}
+
+	sin, cos = sinCos(z, z.Prec()+64)
+	return sin.SetPrec(z.Prec()), cos.SetPrec(z.Prec())
+}
+
+// sinCos returns sin(z) and cos(z) computed with prec bits of
+// precision, for finite z != 0.
+func sinCos(z *big.Float, prec uint) (sin, cos *big.Float) {
+
+	// see Sin and Cos for the signs
+	r, q := reduce(z, prec)
+	sin, cos = sinTaylor(r), cosTaylor(r)
+	switch q {
+	case 1:
+		sin, cos = cos, sin.Neg(sin)
+	case 2:
+		sin.Neg(sin)
+		cos.Neg(cos)
+	case 3:
+		sin, cos = cos.Neg(cos), sin
+	}
+
+	return sin, cos
+}
+
+// Tan returns a big.Float representation of the tangent of z (in
+// radians). Precision is the same as the one of the argument. The
+// function panics if z is ±Inf, and returns ±0 when z = ±0. If the
+// cosine of z underflows to zero, the result is ±Inf.
+func Tan(z *big.Float) *big.Float {
+
+	// panic on ±Inf
+	if z.IsInf() {
+		panic("Tan: argument is infinite")
+	}
+
+	// Tan(±0) = ±0
+	if z.Sign() == 0 {
+		return new(big.Float).Copy(z)
+	}
+
+	prec := z.Prec() + 64 // guard digits
+
+	// tan(r + q·π/2) is
+	//    sin(r)/cos(r)    if q is even
+	//   -cos(r)/sin(r)    if q is odd
+	r, q := reduce(z, prec)
+	num, den := sinTaylor(r), cosTaylor(r)
+	if q%2 == 1 {
+		num, den = den.Neg(den), num
+	}
+
+	if den.Sign() == 0 {
+		return new(big.Float).SetPrec(z.Prec()).SetInf(num.Sign() < 0)
+	}
+
+	return num.Quo(num, den).SetPrec(z.Prec())
+}
+
+// Cot returns a big.Float representation of the cotangent of z (in
+// radians), cos(z)/sin(z). Precision is the same as the one of the
+// argument. The function panics if z is ±Inf, and returns ±Inf when z
+// = ±0. Close to the poles at the multiples of π the result is large
+// but accurate, since z is reduced with enough bits of π.
+func Cot(z *big.Float) *big.Float {
+
+	// panic on ±Inf
+	if z.IsInf() {
+		panic("Cot: argument is infinite")
+	}
+
+	// Cot(±0) = ±Inf
+	if z.Sign() == 0 {
+		return new(big.Float).SetPrec(z.Prec()).SetInf(z.Signbit())
+	}
+
+	sin, cos := sinCos(z, z.Prec()+64)
+	if sin.Sign() == 0 {
+		return new(big.Float).SetPrec(z.Prec()).SetInf(cos.Sign() < 0)
+	}
+
+	return cos.Quo(cos, sin).SetPrec(z.Prec())
+}
+
+// Sec returns a big.Float representation of the secant of z (in
+// radians), 1/cos(z). Precision is the same as the one of the
+// argument. The function panics if z is ±Inf, and returns 1 when z =
+// ±0. Close to the poles at the odd multiples of π/2 the result is
+// large but accurate, as for Cot.
+func Sec(z *big.Float) *big.Float {
+
+	// panic on ±Inf
+	if z.IsInf() {
+		panic("Sec: argument is infinite")
+	}
+
+	// Sec(±0) = 1
+	if z.Sign() == 0 {
+		return big.NewFloat(1).SetPrec(z.Prec())
+	}
+
+	x := Cos(WithGuard(z, 64)) // guard digits
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(z.Prec()).SetInf(false)
+	}
+
+	return x.Quo(big.NewFloat(1), x).SetPrec(z.Prec())
+}
+
+// Csc returns a big.Float representation of the cosecant of z (in
+// radians), 1/sin(z). Precision is the same as the one of the
+// argument. The function panics if z is ±Inf, and returns ±Inf when z
+// = ±0. Close to the poles at the multiples of π the result is large
+// but accurate, as for Cot.
+func Csc(z *big.Float) *big.Float {
+
+	// panic on ±Inf
+	if z.IsInf() {
+		panic("Csc: argument is infinite")
+	}
+
+	// Csc(±0) = ±Inf
+	if z.Sign() == 0 {
+		return new(big.Float).SetPrec(z.Prec()).SetInf(z.Signbit())
+	}
+
+	x := Sin(WithGuard(z, 64)) // guard digits
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(z.Prec()).SetInf(false)
+	}
+
+	return x.Quo(big.NewFloat(1), x).SetPrec(z.Prec())
+}
+
+// Deg returns a big.Float representation of z radians converted to
+// degrees, z·180/π. Precision is the same as the one of the argument.
+// The function returns ±0 when z = ±0, and ±Inf when z = ±Inf.
+func Deg(z *big.Float) *big.Float {
+	prec := z.Prec() + 64 // guard digits
+	x := new(big.Float).SetPrec(prec).Mul(z, big.NewFloat(180))
+	return x.Quo(x, pi(prec)).SetPrec(z.Prec())
+}
+
+// Rad returns a big.Float representation of z degrees converted to
+// radians, z·π/180. Precision is the same as the one of the argument.
+// The function returns ±0 when z = ±0, and ±Inf when z = ±Inf. Both
+// Deg and Rad are computed with 64 guard digits, so Deg(Rad(z)) is
+// within an ulp of z.
+func Rad(z *big.Float) *big.Float {
+	prec := z.Prec() + 64 // guard digits
+	x := new(big.Float).SetPrec(prec).Mul(z, pi(prec))
+	return x.Quo(x, big.NewFloat(180)).SetPrec(z.Prec())
+}
+
+// ReduceAngle returns z (in radians) reduced into [0, π/2), and the
+// quadrant q, 0 <= q < 4, such that z = reduced + q·π/2 + 2kπ for some
+// integer k. The reduced angle has the same precision as the argument,
+// and it's accurate to the last bit even when z is huge or very close
+// to a multiple of π/2, since enough extra bits of π are used. The
+// function panics if z is ±Inf, and returns ±0 and 0 when z = ±0.
+func ReduceAngle(z *big.Float) (reduced *big.Float, quadrant int) {
+
+	// panic on ±Inf
+	if z.IsInf() {
+		panic("ReduceAngle: argument is infinite")
+	}
+
+	// ReduceAngle(±0) = ±0, 0
+	if z.Sign() == 0 {
+		return new(big.Float).Copy(z), 0
+	}
+
+	prec := z.Prec() + 64 // guard digits
+
+	// reduce returns |r| <= π/4; move the negative ones to the
+	// previous quadrant
+	r, q := reduce(z, prec)
+	if r.Sign() < 0 {
+		halfPi := pi(prec)
+		halfPi.SetMantExp(halfPi, -1)
+		r.Add(r, halfPi)
+		q = (q + 3) % 4
+	}
+
+	return r.SetPrec(z.Prec()), q
+}
+
+// reduce returns r and q such that z = r + q·π/2 + 2kπ for some
+// integer k, with |r| <= π/4 and 0 <= q < 4. The result r has prec
+// bits of precision relative to its own magnitude.
+func reduce(z *big.Float, prec uint) (*big.Float, int) {
+
+	r := new(big.Float).SetPrec(prec).Set(z)
+
+	// fast path: no reduction needed if |z| <= 0.78 < π/4
+	if new(big.Float).Abs(z).Cmp(big.NewFloat(0.78)) <= 0 {
+		return r, 0
+	}
+
+	// Computing r = z - k·π/2 loses exp(z) bits of z's magnitude, and
+	// another -exp(r) bits if z is close to a multiple of π/2. Add
+	// the first amount of guard digits upfront, and retry with more
+	// if r turns out to be small.
+	zexp := 0
+	if exp := z.MantExp(nil); exp > 0 {
+		zexp = exp
+	}
+	wp := prec + uint(zexp)
+
+	k := new(big.Int)
+	for {
+		halfPi := pi(wp)
+		halfPi.SetMantExp(halfPi, -1) // π/2
+
+		// k = round(z / (π/2))
+		t := new(big.Float).SetPrec(wp).Quo(z, halfPi)
+		if t.Sign() > 0 {
+			t.Add(t, big.NewFloat(0.5))
+		} else {
+			t.Sub(t, big.NewFloat(0.5))
+		}
+		t.Int(k)
+
+		// r = z - k·π/2
+		r.SetPrec(wp).SetInt(k)
+		r.Mul(r, halfPi)
+		r.Sub(z, r)
+
+		// r has about wp - exp(z) correct bits after the binary point
+		loss := 0
+		if r.Sign() == 0 {
+			loss = int(wp) - zexp
+		} else if exp := r.MantExp(nil); exp < 0 {
+			loss = -exp
+		}
+		if wp >= prec+uint(zexp+loss) {
+			break
+		}
+		wp = prec + uint(zexp+loss)
+	}
+
+	q := new(big.Int).Mod(k, big.NewInt(4))
+	return r.SetPrec(prec), int(q.Int64())
+}
+
+// sinTaylor returns sin(x), computed with x's precision using the
+// Taylor series, for small |x|.
+func sinTaylor(x *big.Float) *big.Float {
+	prec := x.Prec()
+
+	x2 := new(big.Float).SetPrec(prec).Mul(x, x)
+	term := new(big.Float).SetPrec(prec).Set(x)
+	sum := new(big.Float).SetPrec(prec).Set(x)
+
+	// sin(x) = Σ (-1)ⁿ x²ⁿ⁺¹ / (2n+1)!
+	for n := int64(1); ; n++ {
+		term.Mul(term, x2)
+		term.Quo(term, new(big.Float).SetInt64(-(2*n)*(2*n+1)))
+		if term.Sign() == 0 || term.MantExp(nil) < sum.MantExp(nil)-int(prec) {
+			break
+		}
+		sum.Add(sum, term)
+	}
+
+	return sum
+}
+
+// cosTaylor returns cos(x), computed with x's precision using the
+// Taylor series, for small |x|.
+func cosTaylor(x *big.Float) *big.Float {
+	prec := x.Prec()
+
+	x2 := new(big.Float).SetPrec(prec).Mul(x, x)
+	term := big.NewFloat(1).SetPrec(prec)
+	sum := big.NewFloat(1).SetPrec(prec)
+
+	// cos(x) = Σ (-1)ⁿ x²ⁿ / (2n)!
+	for n := int64(1); ; n++ {
+		term.Mul(term, x2)
+		term.Quo(term, new(big.Float).SetInt64(-(2*n-1)*(2*n)))
+		if term.Sign() == 0 || term.MantExp(nil) < sum.MantExp(nil)-int(prec) {
+			break
+		}
+		sum.Add(sum, term)
+	}
+
+	return sum
+}
@@ -0,0 +1,491 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+const piStr = "3.14159265358979323846264338327950288419716939937510582097494459230781640628620899862803482534211706798214808651328230664709384460955058223172535940812848111745028410270193852110555964462294895493038196442881097566593344612847564823378678316527120190914564856692346034861045432664821339360726024914127372458700660631558817488152092096282925409171536436789259036"
+
+// exact reports whether z holds exactly the value represented by s.
+// See note in sqrt_test.go about why we can only test inputs that are
+// exactly representable.
+func exact(z *big.Float, s string) bool {
+	x := new(big.Float).SetPrec(4096)
+	x.Parse(s, 10)
+	return z.Cmp(x) == 0
+}
+
+func TestSin(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		// 350 decimal digits are enough to give us up to 1000 binary digits
+		{"0.5", "0.47942553860420300027328793521557138808180336794060067518861661312553500028781483220963127468434826908613209108450571741781109374860994028278015396204619192460995729393228140053354633818805522859567013569985423363912107172077738015297987137716951517618072114969807370147476869703198703900097339549102989443417733111109673903936124163653480401918346314"},
+		{"1", "0.84147098480789650665250232163029899962256306079837106567275170999191040439123966894863974354305269585434903790792067429325911892099189888119341032772921240948079195582676660699990776401197840878273256634748480287029865615701796245539489357292467012708648628105338203056137721820386844966776167426623901338275339795676425556547796398976482432869027570"},
+		{"2", "0.90929742682568169539601986591174484270225497144789026837897301153096730154078354462012668892495938030996789674239948626128095310867532812027002033974677378284837931019696699774984357047516517548098734245516884866266599397842058560483528737652460663019429655921188458358194895013349986918835827100625452967334980513265003744042450761680167910319685805"},
+		{"3", "0.14112000805986722210074480280811027984693326425226558415188264123242200996701447191128217285344986375041367294826732741684445703166885757375403365785491121781178547683482078216676413721556665886468984403153833012515278359076522350444195094488983392554562224160383624182939544259174410366457405665411545993098230085116590155481231031583793547592135167"},
+		{"-3", "-0.14112000805986722210074480280811027984693326425226558415188264123242200996701447191128217285344986375041367294826732741684445703166885757375403365785491121781178547683482078216676413721556665886468984403153833012515278359076522350444195094488983392554562224160383624182939544259174410366457405665411545993098230085116590155481231031583793547592135167"},
+		{"10", "-0.54402111088936981340474766185137728168364301291622389157418401261675720964049342570707567389498321615829382423826283228551950705643829970313082429461063364026321628198485632926404765679566632046377926927402537727290611276706451048487110457126379414682139289420875720845835061967150157964481785854175893752427652673361879499395584873262026366411112981"},
+		{"-100", "0.50636564110975879365655761045978543206503272129065732344339247359435791341947669649923666451292739220724408939256384041734195258712185803214291600745205302216595592860066245980977228740963745401096581977857948848371085635802444878878658375061266623770906368058416751175458193330505719053287199439438601699247162602814750041192576881095436662487737016"},
+		{"1e10", "-0.48750602508751069152779429434810604167644731692278688574525453784515856344707479443421318014319580445673582074044958027848886690330185397453744113472227571468279768127506113756547890315114765933583181985257287238364869754259839024682495051395899555320028920582415115425213957160554513047713266902762008578952441090654668732374837579460299290792697253"},
+		{"1p-100", "7.8886090522101180541172856528278622967320643510902300477027884884583807837122411507046741689540595041307479167584058147418392599521533764197076898119627739255061988255761752002193612542137083185029762058325304258948677852605808340784904354102492225824714249798896155834447566852292791342645301742720107313691862869435072147620666965315382618251597788e-31"},
+		{"355", "-0.000030144353359488449214330280008650099590255807066324649105789848240673538365472712835310236700034038428087189487440128730780516899432182890951729942306365519514209008846907553575425899328947118261562273390940254594298922673709711023580897196691970305735517491392674588313805131850501731876526093911307723487905971828509463484489340661763408835781335106"},
+		{"1e100", "-0.37237612366127668826208669555316429571966788356743470236441538829671922404375644118873660041620302321867558548499796658023861252813730579852162566088617268652048851744358672454980799378100554605424109144669569468122066424932563095811773374728903106989719226519111278506294247139611174805788229931959127169098562551858319162783491583083180351566665726"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+			if !exact(z, test.z) {
+				continue
+			}
+
+			x := bigfloat.Sin(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Sin(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestCos(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		// 350 decimal digits are enough to give us up to 1000 binary digits
+		{"0.5", "0.87758256189037271611628158260382965199164519710974405299761086831595076327421394740579418408468225835547840059310905399341382797683328026679975612095022401558762915687859072347693931098961673967701440899764912857021346821838454381839331616880754066081115940348983190805262434229367983882103953443260971069339648047544648581904315236807834735418729900"},
+		{"1", "0.54030230586813971740093660744297660373231042061792222767009725538110039477447176451795185608718308934357173116003008909786063376002166345640651226541731858471797116447447949423311792455139325433594351775670289259637573615432754964175449177511513122273010063135707823223677140151746899593667873067422762024507763744067587498161784272021645585111563297"},
+		{"2", "-0.41614683654714238699756822950076218976600077107554489075514997378196493612407916907453177786016914036736679136521572855928865639989117238568344207401996469532153261824797838625058514854625158662802103917920150882900864801241661553785130325917557827506595881767731719659857167856253840727708179472680315694079627056450891381882083423299032067055638422"},
+		{"3", "-0.98999249660044545727157279473126130239367909661558832881408593292832919751313322042829447935569260217149599311241416918957162928632022968860216854267923487181998624962238918750102662403323599641829172990863918642957643094487719043469800557150234267777061537999045713799044260508809640238555764543144773660106106153314952977753115597937518306184526791"},
+		{"-3", "-0.98999249660044545727157279473126130239367909661558832881408593292832919751313322042829447935569260217149599311241416918957162928632022968860216854267923487181998624962238918750102662403323599641829172990863918642957643094487719043469800557150234267777061537999045713799044260508809640238555764543144773660106106153314952977753115597937518306184526791"},
+		{"10", "-0.83907152907645245225886394782406483451993016513316854683595373104879258686627076840093371276042213892745105440535024362369842337987957751969618636138599016240576199182006400100966550965469041048284459666898038675471697117101052082692130732418341256707226561830110093135614920902814223325290814789712587963413460106057971478089694004611010062472713254"},
+		{"-100", "0.86231887228768393410193851395084253551008400853551082928016211269272108805092662410309510568427728506713560755516233048110552806801933854109344620694888493101589381654033594033322660640404071140713031362693461456084835935012094553621793549185347052804201912015877548597641586198668157658201586236725323084778293019089407310749466861180205318485594108"},
+		{"1e10", "0.87311962267685600117619134530769519619041260016768673606921929287592643512588906075470321814384561213052983448679296638440258106065032468160048585221123258749319250522814638723064023497486486506662624566588012019588793794665313765024259532748324844628491846162927752239953080909091223837186962067706138704621654838386571474857829382741234369368635847"},
+		{"1p-100", "0.99999999999999999999999999999999999999999999999999999999999968884923610694291464279679731099378797048739156394164334494418539631470350372363134926373087018035069473163739220581542610945205036123971999461182367115497243076058844052296231419387238537687030080353773366401628419134095539166623482252826836686187765511496353295427227619770298904685434489"},
+		{"355", "-0.99999999954565898016593584169275408112382495149992824477155120372835763685454592108020187211303948132378920387563743696043780850153667769894819061454902690677758855740914041567136558809847299515290515255909440307752067038668710996051793392579963670466591385163661487716826469741131267633198999728586412661385306411299431626113411263136023952921129582"},
+		{"1e100", "-0.92808190507465534345619464377695592818318207643905039332511420954252122203079670126138117700511187183691887194853024728615605695907425513675173585071858321493571448832095620926108356044934987172984273382870885342391199230275287067704554054053067417796557923986530689105434504198271194809890024026376216528603175703780875494511166527647038142872740492"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+			if !exact(z, test.z) {
+				continue
+			}
+
+			x := bigfloat.Cos(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Cos(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestTan(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		// 350 decimal digits are enough to give us up to 1000 binary digits
+		{"0.5", "0.54630248984379051325517946578028538329755172017979124616409138593290751051802581571518064827065621858910486260026411426549323009116840284321739092991091421663694074378847426895741040125791175687874599972450891821223775084383916081374829936617341645137771586441314008924018941493144864805865005196743513425749778729084152210854672419703314467905278807"},
+		{"1", "1.5574077246549022305069748074583601730872507723815200383839466056988613971517272895550999652022429838046338214117481666133235546181245589376060716845489044392935860431671479080368246132747069555973416406107755352473025067968505070413523851449176214816275700278860224507720140161857721306739416643223690166756717950962610882330224852131148350591629693"},
+		{"2", "-2.1850398632615189916433061023136825434320177462276631645629558699667737472091941823197435421047285475948985174498074965400688638458055934211425062956577695798678592535036602405569710732478901351051735600482636740607119750656328918214031175714616400741333916942845955581150814690308178844175015693596747358953495964178463621632718143152782432469001893"},
+		{"3", "-0.14254654307427780529563541053391349322609228490180464763323897668885859522153853805910605834776691136525987824550788877247201907692008784636934399940897964933075931283726732417684987337527424533374797618775218953211258680629930769327129157138377665906409969845784736055736249015746629261748408373582981672544122284548359961003092435894312061713774125"},
+		{"-3", "0.14254654307427780529563541053391349322609228490180464763323897668885859522153853805910605834776691136525987824550788877247201907692008784636934399940897964933075931283726732417684987337527424533374797618775218953211258680629930769327129157138377665906409969845784736055736249015746629261748408373582981672544122284548359961003092435894312061713774125"},
+		{"10", "0.64836082745908667125912493300980867681687434298372497563362796739585560037462390087171720629715228615496490827456283238812470577683319955544820674667816840830129284763138332749873429475978601014989908550803245699050701161993219186087178125248089376887105767052268113156144152045503201689723479824910318562021826453983250939137614636439618761402749258"},
+		{"-100", "0.58721391515692907667780963564458789425876598687291954412663968360989401555009191438374039204102745805716589753154687490451338557144467854586684630748989484305723558153579376528259604083197289516406495585722788667858763273206211828454289046277059162957101486433765076228955547409298333247878128454834134825078270193333012396210423255402949217609700885"},
+		{"1e10", "-0.55834963781124184656189340731863681858164809933060716499623295934358238707735772496680484170418873366477826214726885131989830603051545795380256819428132790811769396039851047990791749085428685948955326017453646615760900621736868677146943390611171745209092951123629422959917860489842265663184397884494424007343699501293331189186914321148052202300541453"},
+		{"1p-100", "7.8886090522101180541172856528278622967320643510902300477027909430051134325755176985906516620918809917385041664831883705167797205502181878628924172850564948039560127599334985991453196798089681584991747738690966174998475671926128033284344372035067674294206878399230440200879589623943277339647887187872285007580097085778716290829007100624209390696370270e-31"},
+		{"355", "0.000030144353373184265468141231180133022308157835292371585323347444982110081188301252672556008908985881141684987409691476547535378804868443420338628829861609268012766251900086569963129538050426712667206266258593651013241010765307060919223425813708932517381028680609384667967268922329036256989307587347732761229457291039018423379226738669137028138769124170"},
+		{"1e100", "0.40123196199081435418575434365329495832387026112924406831944153811687180982211912114672673097493208311349271262118182247468378149091725522386243554917465545722784440111720235095531949895778243975743592175961184986297278625640178859651996834427229517886673276474761372577678161777224889895701619284376719528013048342159509190974623957857047231383752778"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+			if !exact(z, test.z) {
+				continue
+			}
+
+			x := bigfloat.Tan(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Tan(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+// Tan(π/4) = 1, but π/4 is not exactly representable, so only require
+// the result to be within one ulp of 1.
+func TestTanPiOverFour(t *testing.T) {
+	for _, prec := range []uint{53, 100, 200, 500, 1000} {
+		z := new(big.Float).SetPrec(prec)
+		z.Parse(piStr, 10)
+		z.Quo(z, big.NewFloat(4))
+
+		x := bigfloat.Tan(z)
+
+		diff := new(big.Float).Sub(x, big.NewFloat(1))
+		ulp := new(big.Float).SetMantExp(big.NewFloat(1), 1-int(prec))
+		if diff.Abs(diff).Cmp(ulp) > 0 {
+			t.Errorf("prec = %d, Tan(π/4) =\ngot  %g;\nwant 1", prec, x)
+		}
+	}
+}
+
+// Sin(π/6) = 1/2, but π/6 is not exactly representable, so only
+// require the result to be within one ulp of 1/2.
+func TestSinPiOverSix(t *testing.T) {
+	for _, prec := range []uint{53, 100, 200, 500, 1000} {
+		z := new(big.Float).SetPrec(prec)
+		z.Parse(piStr, 10)
+		z.Quo(z, big.NewFloat(6))
+
+		x := bigfloat.Sin(z)
+
+		diff := new(big.Float).Sub(x, big.NewFloat(0.5))
+		ulp := new(big.Float).SetMantExp(big.NewFloat(1), -int(prec))
+		if diff.Abs(diff).Cmp(ulp) > 0 {
+			t.Errorf("prec = %d, Sin(π/6) =\ngot  %g;\nwant 0.5", prec, x)
+		}
+	}
+}
+
+func testTrigFloat64(scale float64, nTests int, t *testing.T) {
+	for i := 0; i < nTests; i++ {
+		r := rand.Float64() * scale
+
+		z := big.NewFloat(r)
+		s64, sacc := bigfloat.Sin(z).Float64()
+		c64, cacc := bigfloat.Cos(z).Float64()
+
+		// math.Sin and math.Cos are not correctly rounded, so just
+		// require an absolute error smaller than 1e-15.
+		if want := math.Sin(r); math.Abs(s64-want) > 1e-15 || sacc != big.Exact {
+			t.Errorf("Sin(%g) =\n got %g (%s);\nwant %g (Exact)", z, s64, sacc, want)
+		}
+		if want := math.Cos(r); math.Abs(c64-want) > 1e-15 || cacc != big.Exact {
+			t.Errorf("Cos(%g) =\n got %g (%s);\nwant %g (Exact)", z, c64, cacc, want)
+		}
+		t64, tacc := bigfloat.Tan(z).Float64()
+		if want := math.Tan(r); math.Abs((t64-want)/want) > 1e-14 || tacc != big.Exact {
+			t.Errorf("Tan(%g) =\n got %g (%s);\nwant %g (Exact)", z, t64, tacc, want)
+		}
+	}
+}
+
+func TestTrigFloat64Small(t *testing.T) {
+	testTrigFloat64(1e-10, 1e4, t)
+	testTrigFloat64(-0.5, 1e4, t)
+}
+
+func TestTrigFloat64Medium(t *testing.T) {
+	testTrigFloat64(10, 1e4, t)
+	testTrigFloat64(-100, 1e4, t)
+}
+
+func TestTrigFloat64Big(t *testing.T) {
+	testTrigFloat64(1e5, 1e4, t)
+}
+
+// SinCos must return exactly the same results as Sin and Cos.
+func TestSinCos(t *testing.T) {
+	rnd := rand.New(rand.NewSource(65))
+	for _, prec := range []uint{24, 53, 64, 100, 200, 500, 1000} {
+		for i := 0; i < 100; i++ {
+			z := big.NewFloat(rnd.NormFloat64()).SetPrec(prec)
+			z.SetMantExp(z, rnd.Intn(100)-30)
+
+			s, c := bigfloat.SinCos(z)
+			if want := bigfloat.Sin(z); s.Cmp(want) != 0 || s.Prec() != prec {
+				t.Errorf("prec = %d, SinCos(%g) sin =\ngot  %g;\nwant %g", prec, z, s, want)
+			}
+			if want := bigfloat.Cos(z); c.Cmp(want) != 0 || c.Prec() != prec {
+				t.Errorf("prec = %d, SinCos(%g) cos =\ngot  %g;\nwant %g", prec, z, c, want)
+			}
+		}
+	}
+
+	for _, f := range []float64{+0.0, math.Copysign(0, -1)} {
+		s, c := bigfloat.SinCos(big.NewFloat(f))
+		if s.Sign() != 0 || s.Signbit() != math.Signbit(f) || c.Cmp(big.NewFloat(1)) != 0 {
+			t.Errorf("SinCos(%g) = %g, %g; want %g, 1", f, s, c, f)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("SinCos(+Inf) did not panic")
+		}
+	}()
+	bigfloat.SinCos(big.NewFloat(math.Inf(+1)))
+}
+
+func TestCotSecCsc(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		ulp := new(big.Float).SetMantExp(big.NewFloat(1), 1-int(prec))
+
+		// π/4 is not exact, and cot'(π/4) = -2, so Cot(π/4) is within
+		// an ulp of 1
+		z := new(big.Float).SetPrec(prec)
+		z.Parse(piStr, 10)
+		z.Quo(z, big.NewFloat(4))
+		x := bigfloat.Cot(z)
+		if d := new(big.Float).Sub(x, big.NewFloat(1)); d.Abs(d).Cmp(ulp) > 0 || x.Prec() != prec {
+			t.Errorf("prec = %d, Cot(π/4) = %g; want 1", prec, x)
+		}
+
+		// csc'(π/2) = 0, so Csc(π/2) rounds to 1
+		z.SetMantExp(z, 1)
+		if x := bigfloat.Csc(z); x.Cmp(big.NewFloat(1)) != 0 || x.Prec() != prec {
+			t.Errorf("prec = %d, Csc(π/2) = %g; want 1", prec, x)
+		}
+
+		if x := bigfloat.Sec(big.NewFloat(0).SetPrec(prec)); x.Cmp(big.NewFloat(1)) != 0 || x.Prec() != prec {
+			t.Errorf("prec = %d, Sec(0) = %g; want 1", prec, x)
+		}
+	}
+}
+
+// Close to the poles the results are about ±1/δ, where δ is the
+// distance from the pole, and they must be accurate.
+func TestCotSecCscPoles(t *testing.T) {
+	for _, prec := range []uint{24, 53, 100, 500, 1000} {
+		piP := new(big.Float).SetPrec(prec)
+		piP.Parse(piStr, 10)
+		// δ is about 2**-prec, so π needs 2·prec bits
+		pi := bigfloat.Pi(2*prec + 64)
+
+		halfPiP := new(big.Float).SetMantExp(piP, -1)
+		halfPi := new(big.Float).SetMantExp(pi, -1)
+
+		for _, test := range []struct {
+			name string
+			f    func(*big.Float) *big.Float
+			z    *big.Float
+			want *big.Float
+		}{
+			// cot(π - δ) = -cot(δ) ≈ -1/δ
+			{"Cot(π)", bigfloat.Cot, piP, new(big.Float).Sub(piP, pi)},
+			// csc(π - δ) = 1/sin(δ) ≈ 1/δ
+			{"Csc(π)", bigfloat.Csc, piP, new(big.Float).Sub(pi, piP)},
+			// sec(π/2 - δ) = 1/sin(δ) ≈ 1/δ
+			{"Sec(π/2)", bigfloat.Sec, halfPiP, new(big.Float).Sub(halfPi, halfPiP)},
+		} {
+			test.want.Quo(big.NewFloat(1), test.want)
+
+			x := test.f(test.z)
+			d := new(big.Float).Sub(x, test.want)
+			if d.Sign() != 0 && d.MantExp(nil) > test.want.MantExp(nil)-int(prec)+2 {
+				t.Errorf("prec = %d, %s =\ngot  %g;\nwant %g", prec, test.name, x, test.want)
+			}
+		}
+	}
+}
+
+func TestCotSecCscSpecialValues(t *testing.T) {
+	for _, f := range []float64{+0.0, math.Copysign(0, -1)} {
+		z := big.NewFloat(f)
+		for name, fn := range map[string]func(*big.Float) *big.Float{
+			"Cot": bigfloat.Cot,
+			"Csc": bigfloat.Csc,
+		} {
+			if x := fn(z); !x.IsInf() || x.Signbit() != math.Signbit(f) {
+				t.Errorf("%s(%g) = %g; want %g", name, f, x, math.Copysign(math.Inf(+1), f))
+			}
+		}
+		if x := bigfloat.Sec(z); x.Cmp(big.NewFloat(1)) != 0 {
+			t.Errorf("Sec(%g) = %g; want 1", f, x)
+		}
+	}
+
+	for name, fn := range map[string]func(*big.Float) *big.Float{
+		"Cot": bigfloat.Cot,
+		"Sec": bigfloat.Sec,
+		"Csc": bigfloat.Csc,
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s(+Inf) did not panic", name)
+				}
+			}()
+			fn(big.NewFloat(math.Inf(+1)))
+		}()
+	}
+}
+
+func TestReduceAngle(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		q    int
+		want string
+	}{
+		{"7", 0, "0.71681469282041352307471323344099423160566120124978835805011081538436718742758200274393034931576586403570382697343538670581231078089883553654928118374303776509943179459612295778888071075410209013923607114237804866813310774304870353242643366945759618170870286615307930277909134670357321278547950171745255082598678736882365023695815807434149181656927126"},
+		{"-1", 3, "0.57079632679489661923132169163975144209858469968755291048747229615390820314310449931401741267105853399107404325664115332354692230477529111586267970406424055872514205135096926055277982231147447746519098221440548783296672306423782411689339158263560095457282428346173017430522716332410669680363012457063686229350330315779408744076046048141462704585768218"},
+		{"355", 2, "0.000030144353364053721297689416174085719857870613042229831261069216746089658383155032064736340771318017266223999099348878395559120784207815034386881481633728117896394680947115071760157606768092866838019544359749749520587482251749582093502324354184266541711937648980607018661088751886522379591847036069121668253486338536238388135931200294287636163826428137"},
+		{"1e20", 3, "0.86944416907955123703635812746544916302256697675099277764367575337033310741143908649540605038147272732550842553290677016316338805705529148420837744655787351557565268135930274650432386675277728038042987600373231703579424275736950072642391579880771574703128584593398277610784760044499255907284251353416633202916893417270607666910014405481342050079044451"},
+		{"-1e20", 0, "0.70135215771534538219496356417430227907601772293656013284379654278357509573166541281861136228958580666556561772373438316038353424771999963165430225750636704314948936999166651404845595555869719708476110621067317079717248030686832339046947578382788520754153843752774739819737956287911413773078761103647053026433436898508801077166031642660120654506723767"},
+		{"1606938044258990275541962092341162602522202993782792835301376", 2, "0.49939874344187675027343636782032335741780945127124476762951353361934781379140935125042955272994259681453127181082523997722903303287914762429044845602504601980106419568577251393976644024200213510914134875755776564148412926417866996069257751396210164633136232455713826535832192024452187051175699489654062188075060577503936292089902646626926691344776245"},
+		{"-1606938044258990275541962092341162602522202993782792835301376", 1, "1.07139758335301986895788532381942808468077524841630814285795876253456038935169514806358785994111593717654277144581591334631788927189614349157223124803919453892407785566519674661301338206947234235604963345684772219148259380005915415620081406867349930824146195890459190894690524307958482629187312967409624041275269738275472451986143401514536013240991972"},
+	} {
+		// 1e20 is not exact with 24 bits
+		for _, prec := range []uint{53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			r, q := bigfloat.ReduceAngle(z)
+			if r.Cmp(want) != 0 || q != test.q || r.Prec() != prec {
+				t.Errorf("prec = %d, ReduceAngle(%s) =\ngot  %g, %d;\nwant %g, %d", prec, test.z, r, q, want, test.q)
+			}
+		}
+	}
+}
+
+func TestReduceAngleSpecialValues(t *testing.T) {
+	for _, f := range []float64{+0.0, math.Copysign(0, -1)} {
+		r, q := bigfloat.ReduceAngle(big.NewFloat(f))
+		if r.Sign() != 0 || r.Signbit() != math.Signbit(f) || q != 0 {
+			t.Errorf("ReduceAngle(%g) = %g, %d; want %g, 0", f, r, q, f)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("ReduceAngle(-Inf) did not panic")
+		}
+	}()
+	bigfloat.ReduceAngle(big.NewFloat(math.Inf(-1)))
+}
+
+func TestDegRad(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		pi := bigfloat.Pi(prec)
+		if x := bigfloat.Rad(big.NewFloat(180).SetPrec(prec)); x.Cmp(pi) != 0 || x.Prec() != prec {
+			t.Errorf("prec = %d, Rad(180) =\ngot  %g;\nwant %g", prec, x, pi)
+		}
+		halfPi := new(big.Float).SetMantExp(pi, -1)
+		if x := bigfloat.Rad(big.NewFloat(-90).SetPrec(prec)); x.Cmp(halfPi.Neg(halfPi)) != 0 {
+			t.Errorf("prec = %d, Rad(-90) =\ngot  %g;\nwant %g", prec, x, halfPi)
+		}
+
+		// π is rounded, so Deg(π) is only within an ulp of 180
+		x := bigfloat.Deg(pi)
+		d := new(big.Float).Sub(x, big.NewFloat(180))
+		if d.Sign() != 0 && d.MantExp(nil) > 9-int(prec) || x.Prec() != prec {
+			t.Errorf("prec = %d, Deg(π) = %g (prec = %d); want 180", prec, x, x.Prec())
+		}
+	}
+
+	for _, f := range []float64{+0.0, math.Copysign(0, -1), math.Inf(+1), math.Inf(-1)} {
+		for name, fn := range map[string]func(*big.Float) *big.Float{"Deg": bigfloat.Deg, "Rad": bigfloat.Rad} {
+			x64, acc := fn(big.NewFloat(f)).Float64()
+			if x64 != f || math.Signbit(x64) != math.Signbit(f) || acc != big.Exact {
+				t.Errorf("%s(%g) = %g (%s); want %g (Exact)", name, f, x64, acc, f)
+			}
+		}
+	}
+}
+
+// Deg(Rad(z)) is within an ulp of z.
+func TestDegRadRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(64))
+	for _, prec := range []uint{24, 53, 64, 100, 200, 500, 1000} {
+		for i := 0; i < 200; i++ {
+			z := big.NewFloat(rnd.NormFloat64()).SetPrec(prec)
+			z.SetMantExp(z, rnd.Intn(80)-40)
+			z.Add(z, new(big.Float).Quo(z, big.NewFloat(3))) // use all the bits
+
+			x := bigfloat.Deg(bigfloat.Rad(z))
+			d := new(big.Float).Sub(x, z)
+			if d.Sign() != 0 && d.MantExp(nil) > z.MantExp(nil)-int(prec)+1 {
+				t.Errorf("prec = %d, Deg(Rad(%g)) = %g", prec, z, x)
+			}
+			y := bigfloat.Rad(bigfloat.Deg(z))
+			if d := new(big.Float).Sub(y, z); d.Sign() != 0 && d.MantExp(nil) > z.MantExp(nil)-int(prec)+1 {
+				t.Errorf("prec = %d, Rad(Deg(%g)) = %g", prec, z, y)
+			}
+		}
+	}
+}
+
+func TestTrigSpecialValues(t *testing.T) {
+	for _, f := range []float64{
+		+0.0,
+		-0.0,
+	} {
+		z := big.NewFloat(f)
+		s := bigfloat.Sin(z)
+		s64, sacc := s.Float64()
+		if s64 != 0 || s.Signbit() != math.Signbit(f) || sacc != big.Exact {
+			t.Errorf("Sin(%g) =\n got %g (%s);\nwant %g (Exact)", z, s64, sacc, f)
+		}
+		if c64, cacc := bigfloat.Cos(z).Float64(); c64 != 1 || cacc != big.Exact {
+			t.Errorf("Cos(%g) =\n got %g (%s);\nwant 1 (Exact)", z, c64, cacc)
+		}
+		tn := bigfloat.Tan(z)
+		if t64, tacc := tn.Float64(); t64 != 0 || tn.Signbit() != math.Signbit(f) || tacc != big.Exact {
+			t.Errorf("Tan(%g) =\n got %g (%s);\nwant %g (Exact)", z, t64, tacc, f)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkSin(b *testing.B) {
+	z := big.NewFloat(2).SetPrec(1e4)
+	_ = bigfloat.Sin(z) // fill pi cache before benchmarking
+
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		z = big.NewFloat(2).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Sin(z)
+			}
+		})
+	}
+}
+
+func BenchmarkSinCos(b *testing.B) {
+	z := new(big.Float).SetPrec(1e4)
+	z.Parse("1e20", 10)
+	_ = bigfloat.Sin(z) // fill pi cache before benchmarking
+
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		z = new(big.Float).SetPrec(prec)
+		z.Parse("1e20", 10)
+		b.Run(fmt.Sprintf("SinCos/%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.SinCos(z)
+			}
+		})
+		b.Run(fmt.Sprintf("SinAndCos/%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Sin(z)
+				bigfloat.Cos(z)
+			}
+		})
+	}
+}
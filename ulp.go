@@ -0,0 +1,88 @@
+package bigfloat
+
+import "math/big"
+
+// NextAfter returns the next big.Float after x in the direction of y,
+// with the same precision and rounding mode as x. The distance between
+// x and the result is one ulp of x's precision and exponent, or half
+// of that when stepping toward zero from a power of two. The function
+// returns a copy of x when x = y, ±0 when stepping toward zero from
+// the smallest representable value, ±Inf when stepping away from zero
+// from the largest one, and the largest finite value when stepping
+// from ±Inf toward y.
+func NextAfter(x, y *big.Float) *big.Float {
+	c := x.Cmp(y)
+	if c == 0 {
+		return new(big.Float).Copy(x)
+	}
+
+	prec := x.Prec()
+	if prec == 0 {
+		prec = 1
+	}
+
+	// the largest finite value is (1 - 2**-prec)·2**MaxExp
+	if x.IsInf() {
+		frac := big.NewFloat(1).SetPrec(prec)
+		frac.SetMantExp(frac, -int(prec))
+		frac.Sub(big.NewFloat(1), frac)
+		if x.Sign() < 0 {
+			frac.Neg(frac)
+		}
+		return new(big.Float).SetMantExp(frac, big.MaxExp).SetMode(x.Mode())
+	}
+
+	// the smallest positive value is 0.5·2**MinExp
+	if x.Sign() == 0 {
+		z := new(big.Float).SetPrec(prec).SetMode(x.Mode()).SetFloat64(0.5)
+		if c > 0 {
+			z.Neg(z)
+		}
+		return z.SetMantExp(z, big.MinExp)
+	}
+
+	// Step the fraction 0.5 <= |frac| < 1, whose ulp is 2**-prec, and
+	// then re-attach the exponent, so that the half ulp that is added
+	// or subtracted never underflows. Rounding frac ± half an ulp away
+	// from frac gives the next float, also when it crosses a power of
+	// two and the ulp changes, and re-attaching the exponent overflows
+	// to ±Inf or underflows to ±0 at the ends of the exponent range.
+	frac := new(big.Float)
+	exp := x.MantExp(frac)
+	half := new(big.Float).SetMantExp(big.NewFloat(1), -int(prec)-1)
+	if c < 0 {
+		frac.SetMode(big.ToPositiveInf).Add(frac, half)
+	} else {
+		frac.SetMode(big.ToNegativeInf).Sub(frac, half)
+	}
+
+	return frac.SetMantExp(frac, exp).SetMode(x.Mode())
+}
+
+// Ulp returns a big.Float representation of the unit in the last
+// place of z, 2**(exp-prec) where exp is the exponent of z as returned
+// by MantExp and prec its precision; it's the distance between |z| and
+// the next float away from zero. Precision is the same as the one of
+// the argument. The function returns +Inf when z = ±Inf, and the
+// smallest positive big.Float when z = ±0. If the ulp is below the
+// big.Float exponent range, the result is 0.
+func Ulp(z *big.Float) *big.Float {
+	prec := z.Prec()
+	if prec == 0 {
+		prec = 1
+	}
+	x := big.NewFloat(1).SetPrec(prec)
+
+	// Ulp(±Inf) = +Inf
+	if z.IsInf() {
+		return x.SetInf(false)
+	}
+
+	// Ulp(±0) is the distance to the smallest positive value,
+	// 0.5·2**MinExp
+	if z.Sign() == 0 {
+		return x.SetMantExp(x, big.MinExp-1)
+	}
+
+	return x.SetMantExp(x, z.MantExp(nil)-int(prec))
+}
@@ -0,0 +1,178 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestNextAfterFloat64(t *testing.T) {
+	inf := math.Inf(+1)
+	for _, f := range []float64{
+		1, 2, 3, 0.5, 1e300, 1e-300, math.Pi,
+		-1, -2, -0.75, -1e-300,
+		math.MaxFloat64 / 2,
+	} {
+		for _, y := range []float64{inf, -inf} {
+			want := math.Nextafter(f, y)
+			x64, acc := bigfloat.NextAfter(big.NewFloat(f), big.NewFloat(y)).Float64()
+			if x64 != want || acc != big.Exact {
+				t.Errorf("NextAfter(%g, %g) = %g (%s); want %g", f, y, x64, acc, want)
+			}
+		}
+	}
+}
+
+// Stepping up and down across powers of two, where the ulp changes.
+func TestNextAfterPowerOfTwo(t *testing.T) {
+	inf, ninf := big.NewFloat(math.Inf(+1)), big.NewFloat(math.Inf(-1))
+	for _, prec := range []uint{1, 2, 24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		for _, e := range []int{0, 1, -1, 1000, -1000} {
+			p := big.NewFloat(1).SetPrec(prec)
+			p.SetMantExp(p, e) // p = 2**e
+
+			// the ulp of p is 2**(e+1-prec), and the one of the float
+			// below p is half of that
+			ulp := new(big.Float).SetMantExp(big.NewFloat(1), e+1-int(prec))
+			half := new(big.Float).SetMantExp(big.NewFloat(1), e-int(prec))
+
+			up := bigfloat.NextAfter(p, inf)
+			down := bigfloat.NextAfter(p, ninf)
+			if d := new(big.Float).Sub(up, p); d.Cmp(ulp) != 0 || up.Prec() != prec {
+				t.Errorf("prec = %d, NextAfter(2**%d, +Inf) - 2**%d = %g; want %g", prec, e, e, d, ulp)
+			}
+			if d := new(big.Float).Sub(p, down); d.Cmp(half) != 0 || down.Prec() != prec {
+				t.Errorf("prec = %d, 2**%d - NextAfter(2**%d, -Inf) = %g; want %g", prec, e, e, d, half)
+			}
+
+			// and back
+			if x := bigfloat.NextAfter(up, ninf); x.Cmp(p) != 0 {
+				t.Errorf("prec = %d, NextAfter(NextAfter(2**%d, +Inf), -Inf) = %g; want 2**%d", prec, e, x, e)
+			}
+			if x := bigfloat.NextAfter(down, inf); x.Cmp(p) != 0 {
+				t.Errorf("prec = %d, NextAfter(NextAfter(2**%d, -Inf), +Inf) = %g; want 2**%d", prec, e, x, e)
+			}
+
+			// the float below the next power of two steps up to it
+			if x := bigfloat.NextAfter(bigfloat.NextAfter(new(big.Float).SetMantExp(p, 1), ninf), inf); x.Cmp(new(big.Float).SetMantExp(p, 1)) != 0 {
+				t.Errorf("prec = %d, stepping down and up from 2**%d gave %g", prec, e+1, x)
+			}
+
+			// negative values mirror positive ones
+			np := new(big.Float).Neg(p)
+			if x := bigfloat.NextAfter(np, ninf); x.Cmp(new(big.Float).Neg(up)) != 0 {
+				t.Errorf("prec = %d, NextAfter(-2**%d, -Inf) = %g; want %g", prec, e, x, new(big.Float).Neg(up))
+			}
+		}
+	}
+}
+
+func TestNextAfterSpecialValues(t *testing.T) {
+	for _, prec := range []uint{24, 53, 1000} {
+		zero := new(big.Float).SetPrec(prec)
+		one := big.NewFloat(1).SetPrec(prec)
+
+		// NextAfter(x, x) = x
+		if x := bigfloat.NextAfter(one, one); x.Cmp(one) != 0 || x == one {
+			t.Errorf("prec = %d, NextAfter(1, 1) = %g; want a copy of 1", prec, x)
+		}
+
+		// from ±0 to the smallest value, and back to ±0
+		tiny := bigfloat.NextAfter(zero, one)
+		if mant := new(big.Float); tiny.MantExp(mant) != big.MinExp || mant.Cmp(big.NewFloat(0.5)) != 0 || tiny.Prec() != prec {
+			t.Errorf("prec = %d, NextAfter(0, 1) = %g; want 0.5·2**MinExp", prec, tiny)
+		}
+		if x := bigfloat.NextAfter(tiny, zero); x.Sign() != 0 || x.Signbit() {
+			t.Errorf("prec = %d, NextAfter(tiny, 0) = %g; want +0", prec, x)
+		}
+		ntiny := bigfloat.NextAfter(zero, new(big.Float).Neg(one))
+		if ntiny.Sign() >= 0 || ntiny.MantExp(nil) != big.MinExp {
+			t.Errorf("prec = %d, NextAfter(0, -1) = %g; want -0.5·2**MinExp", prec, ntiny)
+		}
+		if x := bigfloat.NextAfter(ntiny, zero); x.Sign() != 0 || !x.Signbit() {
+			t.Errorf("prec = %d, NextAfter(-tiny, 0) = %g; want -0", prec, x)
+		}
+
+		// from ±Inf to the largest value, and back to ±Inf
+		inf := new(big.Float).SetPrec(prec).SetInf(false)
+		huge := bigfloat.NextAfter(inf, zero)
+		if huge.IsInf() || huge.MantExp(nil) != big.MaxExp || huge.Prec() != prec {
+			t.Errorf("prec = %d, NextAfter(+Inf, 0) = %g; want the largest float", prec, huge)
+		}
+		if x := bigfloat.NextAfter(huge, inf); !x.IsInf() || x.Sign() < 0 {
+			t.Errorf("prec = %d, NextAfter(huge, +Inf) = %g; want +Inf", prec, x)
+		}
+		if x := bigfloat.NextAfter(bigfloat.NextAfter(huge, zero), inf); x.Cmp(huge) != 0 {
+			t.Errorf("prec = %d, stepping down and up from the largest float gave %g", prec, x)
+		}
+		nhuge := bigfloat.NextAfter(new(big.Float).Neg(inf), zero)
+		if nhuge.Cmp(new(big.Float).Neg(huge)) != 0 {
+			t.Errorf("prec = %d, NextAfter(-Inf, 0) = %g; want %g", prec, nhuge, new(big.Float).Neg(huge))
+		}
+	}
+}
+
+func TestUlp(t *testing.T) {
+	inf := big.NewFloat(math.Inf(+1))
+	for _, prec := range []uint{1, 2, 24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		for _, f := range []float64{1, 1.5, 2, 3, math.Pi, 0.1, 1e300, 1e-300, -1, -7.25} {
+			z := big.NewFloat(f).SetPrec(prec)
+			ulp := bigfloat.Ulp(z)
+			if ulp.Sign() <= 0 || ulp.Prec() != prec {
+				t.Errorf("prec = %d, Ulp(%g) = %g (prec = %d); want > 0 (prec = %d)", prec, z, ulp, ulp.Prec(), prec)
+			}
+
+			// the distance to the next float away from zero
+			away := inf
+			if z.Sign() < 0 {
+				away = new(big.Float).Neg(inf)
+			}
+			d := new(big.Float).Sub(bigfloat.NextAfter(z, away), z)
+			if d.Abs(d).Cmp(ulp) != 0 {
+				t.Errorf("prec = %d, |NextAfter(%g, ±Inf) - %g| = %g; want Ulp = %g", prec, z, z, d, ulp)
+			}
+
+			// doubling z doubles the ulp
+			z2 := new(big.Float).SetMantExp(z, 1)
+			if ulp2 := bigfloat.Ulp(z2); ulp2.Cmp(new(big.Float).SetMantExp(ulp, 1)) != 0 {
+				t.Errorf("prec = %d, Ulp(2·%g) = %g; want 2·%g", prec, z, ulp2, ulp)
+			}
+		}
+	}
+}
+
+func TestUlpFloat64(t *testing.T) {
+	for _, f := range []float64{1, 1.5, 2, 3, math.Pi, 0.1, 1e300, 1e-300, -1, -7.25} {
+		want := math.Nextafter(math.Abs(f), math.Inf(+1)) - math.Abs(f)
+		if x64, _ := bigfloat.Ulp(big.NewFloat(f)).Float64(); x64 != want {
+			t.Errorf("Ulp(%g) = %g; want %g", f, x64, want)
+		}
+	}
+}
+
+func TestUlpSpecialValues(t *testing.T) {
+	for _, prec := range []uint{24, 53, 1000} {
+		for _, f := range []float64{+0.0, math.Copysign(0, -1)} {
+			z := big.NewFloat(f).SetPrec(prec)
+			want := bigfloat.NextAfter(z, big.NewFloat(1))
+			if x := bigfloat.Ulp(z); x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, Ulp(%g) = %g; want %g", prec, f, x, want)
+			}
+		}
+
+		for _, f := range []float64{math.Inf(+1), math.Inf(-1)} {
+			if x := bigfloat.Ulp(big.NewFloat(f).SetPrec(prec)); !x.IsInf() || x.Sign() < 0 {
+				t.Errorf("prec = %d, Ulp(%g) = %g; want +Inf", prec, f, x)
+			}
+		}
+
+		// below the exponent range
+		z := big.NewFloat(1).SetPrec(prec)
+		z.SetMantExp(z, big.MinExp)
+		if x := bigfloat.Ulp(z); x.Sign() != 0 {
+			t.Errorf("prec = %d, Ulp(2**MinExp) = %g; want 0", prec, x)
+		}
+	}
+}
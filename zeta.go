@@ -0,0 +1,87 @@
+package bigfloat
+
+import "math/big"
+
+// Zeta returns a big.Float representation of the Riemann zeta function
+// of s,
+//
+//	ζ(s) = Σ 1/nˢ
+//
+// for real s > 1. Precision is the same as the one of the argument.
+// The function panics if s = 1, the pole of ζ, and if s < 1, where the
+// series doesn't converge and ζ is not supported yet. It returns 1
+// when s = +Inf.
+func Zeta(s *big.Float) *big.Float {
+
+	// panic on the pole, and outside of the domain
+	switch c := s.Cmp(big.NewFloat(1)); {
+	case c == 0:
+		panic("Zeta: argument is 1")
+	case c < 0:
+		panic("Zeta: argument is less than 1")
+	}
+
+	// ζ(s) = 1 + 2⁻ˢ + ... rounds to 1 when s > prec+1, and in
+	// particular when s = +Inf
+	if s.Cmp(new(big.Float).SetUint64(uint64(s.Prec())+2)) > 0 {
+		return big.NewFloat(1).SetPrec(s.Prec())
+	}
+
+	prec := s.Prec() + 64 // guard digits
+
+	// Euler–Maclaurin summation
+	//
+	//   ζ(s) = Σ[n < N] n⁻ˢ + N¹⁻ˢ/(s-1) + N⁻ˢ/2 + Σ[k >= 1] Tₖ
+	//
+	// with Tₖ = B₂ₖ/(2k)!·s(s+1)...(s+2k-2)·N^(-s-2k+1). The ratio
+	// between consecutive Tₖ is about ((s+2k)/2πN)², so the terms
+	// decrease until k ≈ πN, when they are about e^(-2πN); N = prec/8
+	// makes that smaller than 2**-prec. The terms must also decrease
+	// from the start, so N is at least about s.
+	n := int64(prec/8 + 10)
+	if sf, _ := s.Float64(); sf > float64(n) {
+		n = int64(sf) + 10
+	}
+
+	sw := new(big.Float).SetPrec(prec).Set(s)
+	negS := new(big.Float).SetPrec(prec).Neg(s)
+	nf := new(big.Float).SetPrec(prec)
+
+	// Σ[n < N] n⁻ˢ
+	sum := big.NewFloat(1).SetPrec(prec)
+	for i := int64(2); i < n; i++ {
+		sum.Add(sum, Pow(nf.SetInt64(i), negS))
+	}
+
+	// N¹⁻ˢ/(s-1) + N⁻ˢ/2
+	nPow := Pow(nf.SetInt64(n), negS) // N⁻ˢ
+	t := new(big.Float).SetPrec(prec).Sub(sw, big.NewFloat(1))
+	t.Quo(nf, t)
+	t.Mul(t, nPow)
+	sum.Add(sum, t)
+	t.Quo(nPow, big.NewFloat(2))
+	sum.Add(sum, t)
+
+	// Σ Tₖ, with r = s(s+1)...(s+2k-2)·N^(-s-2k+1)/(2k)!
+	r := new(big.Float).SetPrec(prec).Mul(nPow, sw)
+	r.Quo(r, nf)
+	r.Quo(r, big.NewFloat(2))
+	n2 := new(big.Float).SetPrec(prec).Mul(nf, nf)
+	term := new(big.Float).SetPrec(prec)
+	for k := int64(1); ; k++ {
+		term.SetRat(bernoulli(int(k)))
+		term.Mul(term, r)
+		if term.Sign() == 0 || term.MantExp(nil) < sum.MantExp(nil)-int(prec) {
+			break
+		}
+		sum.Add(sum, term)
+
+		// r = r·(s+2k-1)(s+2k)/((2k+1)(2k+2)·N²)
+		r.Mul(r, t.Add(sw, nf.SetInt64(2*k-1)))
+		r.Mul(r, t.Add(sw, nf.SetInt64(2*k)))
+		r.Quo(r, t.SetInt64((2*k+1)*(2*k+2)))
+		r.Quo(r, n2)
+	}
+
+	return sum.SetPrec(s.Prec())
+}
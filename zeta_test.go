@@ -0,0 +1,93 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestZeta(t *testing.T) {
+	for _, test := range []struct {
+		s    string
+		want string
+	}{
+		{"1.5", "2.6123753486854883433485675679240716305708006524000634075733282488149277676882728609962438681263119523829763587721497556981576329684344591344383205618083360083393339628054805416629485268482979816864584755018789924255279091964562598574662095781917898324779805261481407047226084652406958685642314207077101533123221432686836188442339399975130904191612079"},
+		{"10.25", "1.0008348121745023500874120656108707850728817301174279512211611262907636837769139103947137776127761260969933226524065493411808209137847857426108501425432840570510840951965229404342230868193859511621390520835849480564604237095080313614505328145786695490412249089967017777105036454399761778596503642521365477512784241337772509002579323188914480343313095"},
+		{"3", "1.2020569031595942853997381615114499907649862923404988817922715553418382057863130901864558736093352581461991577952607194184919959986732832137763968372079001614539417829493600667191915755222424942439615639096641032911590957809655146512799184051057152559880154371097811020398275325667876035223369849416618110570147157786394997375237852779370309560257019"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			s := new(big.Float).SetPrec(prec)
+			s.Parse(test.s, 10)
+
+			x := bigfloat.Zeta(s)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Zeta(%v) =\ngot  %g;\nwant %g", prec, test.s, x, want)
+			}
+		}
+	}
+}
+
+// ζ(2) = π²/6, ζ(4) = π⁴/90, ζ(6) = π⁶/945
+func TestZetaEven(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		for _, test := range []struct {
+			s   int
+			den float64
+		}{
+			{2, 6},
+			{4, 90},
+			{6, 945},
+		} {
+			p := bigfloat.Pi(prec + 64)
+			want := new(big.Float).SetPrec(prec + 64).SetInt64(1)
+			for i := 0; i < test.s; i++ {
+				want.Mul(want, p)
+			}
+			want.Quo(want, big.NewFloat(test.den)).SetPrec(prec)
+
+			s := big.NewFloat(float64(test.s)).SetPrec(prec)
+			if x := bigfloat.Zeta(s); x.Cmp(want) != 0 || x.Prec() != prec {
+				t.Errorf("prec = %d, Zeta(%d) =\ngot  %g;\nwant %g", prec, test.s, x, want)
+			}
+		}
+	}
+}
+
+// Close to the pole, ζ(1+h) = 1/h + γ + O(h), and for large s it
+// rounds to 1.
+func TestZetaLimits(t *testing.T) {
+	for _, e := range []int{-10, -20, -40} {
+		h := math.Ldexp(1, e)
+		s := big.NewFloat(1 + h).SetPrec(100)
+		x, _ := bigfloat.Zeta(s).Float64()
+		if want := 1/h + 0.5772156649015329; math.Abs(x-want) > h {
+			t.Errorf("Zeta(1 + 2**%d) = %g; want %g", e, x, want)
+		}
+	}
+
+	// ζ(30) - 1 ≈ 2**-30 is below the precision
+	for _, f := range []float64{30, 100, 1000, math.Inf(+1)} {
+		if x := bigfloat.Zeta(big.NewFloat(f).SetPrec(24)); x.Cmp(big.NewFloat(1)) != 0 || x.Prec() != 24 {
+			t.Errorf("Zeta(%g) = %g (prec = %d); want 1 (prec = 24)", f, x, x.Prec())
+		}
+	}
+}
+
+func TestZetaPanics(t *testing.T) {
+	for _, f := range []float64{1, 0.5, 0, -2, math.Inf(-1)} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Zeta(%g) did not panic", f)
+				}
+			}()
+			bigfloat.Zeta(big.NewFloat(f))
+		}()
+	}
+}